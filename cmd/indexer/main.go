@@ -0,0 +1,242 @@
+// Command indexer copies the events of owner contracts into a local
+// database and answers queries about them without touching the chain.
+//
+// Usage:
+//
+//	indexer <command> [flags]
+//
+// Run "indexer help" for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/indexer"
+)
+
+var commands []cli.Command
+
+func init() {
+	commands = []cli.Command{
+		{Name: "sync", Summary: "index new events from the checkpoints, once or continuously", Run: runSync},
+		{Name: "backfill", Summary: "index a block range without moving the checkpoints", Run: runBackfill},
+		{Name: "query", Summary: "list indexed events by contract, event, operation id, nonce and time", Run: runQuery},
+	}
+}
+
+func main() {
+	cli.Main("indexer", commands)
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return cli.NewFlagSet("indexer", name)
+}
+
+// source holds the flags shared by the commands that read from the chain.
+type source struct {
+	rpc       string
+	db        string
+	contracts *cli.ContractFlags
+	fromBlock uint64
+	batchSize uint64
+}
+
+func addSourceFlags(fs *flag.FlagSet) *source {
+	s := &source{contracts: cli.AddContractFlags(fs)}
+	fs.StringVar(&s.rpc, "rpc", "", "RPC endpoint of the chain")
+	fs.StringVar(&s.db, "db", "", "directory of the index database")
+	fs.Uint64Var(&s.fromBlock, "from-block", 0, "first block to index for contracts without a checkpoint")
+	fs.Uint64Var(&s.batchSize, "batch-size", 0, "blocks per eth_getLogs call (default 10000)")
+	return s
+}
+
+const sourceUsage = "-rpc URL -db DIR " + cli.ContractsUsage
+
+// open dials the chain, opens the database and returns an indexer for the
+// selected contracts. The caller closes the client and the store.
+func (s *source) open(ctx context.Context, confirmations uint64) (*ethclient.Client, *indexer.Store, *indexer.Indexer, error) {
+	if s.rpc == "" || s.db == "" {
+		return nil, nil, nil, errors.New("-rpc and -db are required")
+	}
+	client, err := ethclient.DialContext(ctx, s.rpc)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	d, err := s.contracts.Resolve(ctx, client)
+	if err != nil {
+		client.Close()
+		return nil, nil, nil, err
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return nil, nil, nil, err
+	}
+	store, err := indexer.Open(s.db)
+	if err != nil {
+		client.Close()
+		return nil, nil, nil, err
+	}
+	ix, err := indexer.New(client, store, indexer.Config{
+		ChainID:       chainID,
+		Deployment:    d,
+		FromBlock:     s.fromBlock,
+		BatchSize:     s.batchSize,
+		Confirmations: confirmations,
+	})
+	if err != nil {
+		store.Close()
+		client.Close()
+		return nil, nil, nil, err
+	}
+	return client, store, ix, nil
+}
+
+func runSync(ctx context.Context, args []string) error {
+	fs := newFlagSet("sync")
+	src := addSourceFlags(fs)
+	confirmations := fs.Uint64("confirmations", 12, "stay this many blocks behind the head")
+	follow := fs.Duration("follow", 0, "keep syncing at this interval instead of exiting")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: indexer sync "+sourceUsage+" [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, store, ix, err := src.open(ctx, *confirmations)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	defer store.Close()
+	if *follow > 0 {
+		return ix.Run(ctx, *follow)
+	}
+	synced, err := ix.Sync(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("indexed up to block %d\n", synced)
+	return nil
+}
+
+func runBackfill(ctx context.Context, args []string) error {
+	fs := newFlagSet("backfill")
+	src := addSourceFlags(fs)
+	from := fs.Uint64("from", 0, "first block of the range")
+	to := fs.Uint64("to", 0, "last block of the range")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: indexer backfill "+sourceUsage+" -from BLOCK -to BLOCK")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *to == 0 {
+		return errors.New("-to is required")
+	}
+	if *from > *to {
+		return errors.New("-from is after -to")
+	}
+	client, store, ix, err := src.open(ctx, 0)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	defer store.Close()
+	if err := ix.Backfill(ctx, *from, *to); err != nil {
+		return err
+	}
+	fmt.Printf("indexed blocks [%d, %d]\n", *from, *to)
+	return nil
+}
+
+func runQuery(ctx context.Context, args []string) error {
+	fs := newFlagSet("query")
+	db := fs.String("db", "", "directory of the index database")
+	chainID := fs.Uint64("chain-id", 0, "chain to query")
+	var contracts cli.AddressesFlag
+	fs.Var(&contracts, "contract", "only events of this contract, repeatable")
+	events := fs.String("event", "", "only these events, comma separated, e.g. NewRoot,CallScheduled")
+	opID := fs.String("op-id", "", "only events of this timelock operation")
+	nonce := fs.Int64("nonce", -1, "only the OpExecuted events of this nonce")
+	since := fs.String("since", "", "only events from this time on, RFC 3339 or YYYY-MM-DD")
+	until := fs.String("until", "", "only events before this time, RFC 3339 or YYYY-MM-DD")
+	limit := fs.Int("limit", 0, "return at most this many events")
+	jsonOut := fs.Bool("json", false, "print the events as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: indexer query -db DIR -chain-id ID [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "db"); err != nil {
+		return err
+	}
+	if *chainID == 0 {
+		return errors.New("-chain-id is required")
+	}
+	q := indexer.Query{ChainID: new(big.Int).SetUint64(*chainID), Contracts: contracts, Limit: *limit}
+	if *events != "" {
+		q.Names = strings.Split(*events, ",")
+	}
+	if *opID != "" {
+		id := common.HexToHash(*opID)
+		q.OpID = &id
+	}
+	if *nonce >= 0 {
+		n := uint64(*nonce)
+		q.Nonce = &n
+	}
+	var err error
+	if q.From, err = parseTime(*since); err != nil {
+		return fmt.Errorf("-since: %w", err)
+	}
+	if q.To, err = parseTime(*until); err != nil {
+		return fmt.Errorf("-until: %w", err)
+	}
+
+	store, err := indexer.Open(*db)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	found, err := store.Query(q)
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		return cli.PrintJSON(found)
+	}
+	tw := cli.NewTable(os.Stdout)
+	fmt.Fprintln(tw, "BLOCK\tTIME\tCONTRACT\tEVENT\tARGS")
+	for _, e := range found {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", e.BlockNumber, time.Unix(int64(e.BlockTime), 0).UTC().Format(time.RFC3339), e.Contract, e.Name, e.Args)
+	}
+	return tw.Flush()
+}
+
+// parseTime parses an RFC 3339 time or a date; the empty string is the zero
+// time.
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
@@ -0,0 +1,209 @@
+// Command inspect snapshots the state of an owner-contract deployment and
+// diffs snapshots. It only ever reads from the chain.
+//
+// Usage:
+//
+//	inspect <command> [flags]
+//
+// Run "inspect help" for the list of commands.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/bytecode"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/inspect"
+)
+
+var commands []cli.Command
+
+func init() {
+	commands = []cli.Command{
+		{Name: "snapshot", Summary: "dump the state of a deployment as JSON", Run: runSnapshot},
+		{Name: "diff", Summary: "list the differences between two snapshots", Run: runDiff},
+		{Name: "verify-manifest", Summary: "check the code of the manifest's contracts on a chain", Run: runVerifyManifest},
+		{Name: "verify-code", Summary: "check that contracts run the code compiled from this repository", Run: runVerifyCode},
+	}
+}
+
+func main() {
+	cli.Main("inspect", commands)
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return cli.NewFlagSet("inspect", name)
+}
+
+func runSnapshot(ctx context.Context, args []string) error {
+	fs := newFlagSet("snapshot")
+	rpcURL := fs.String("rpc", "", "RPC endpoint of the chain")
+	selected := cli.AddContractFlags(fs)
+	block := fs.Int64("block", -1, "block to snapshot (default latest; older blocks need an archive node)")
+	out := fs.String("out", "", "write the snapshot to this file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: inspect snapshot -rpc URL "+cli.ContractsUsage+" [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "rpc"); err != nil {
+		return err
+	}
+	var blockNumber *big.Int
+	if *block >= 0 {
+		blockNumber = big.NewInt(*block)
+	}
+
+	client, err := ethclient.DialContext(ctx, *rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	d, err := selected.Resolve(ctx, client)
+	if err != nil {
+		return err
+	}
+	snapshot, err := inspect.Take(ctx, client, d, blockNumber)
+	if err != nil {
+		return err
+	}
+	if *out != "" {
+		return cli.WriteJSONFile(*out, snapshot)
+	}
+	return cli.PrintJSON(snapshot)
+}
+
+func runVerifyCode(ctx context.Context, args []string) error {
+	fs := newFlagSet("verify-code")
+	rpcURL := fs.String("rpc", "", "RPC endpoint of the chain")
+	selected := cli.AddContractFlags(fs)
+	jsonOut := fs.Bool("json", false, "print the results as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: inspect verify-code -rpc URL "+cli.ContractsUsage+" [flags]")
+		fmt.Fprintln(fs.Output(), "Compares the deployed code with the code compiled from this repository.")
+		fmt.Fprintln(fs.Output(), "Exits with status 1 unless every contract matches exactly.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "rpc"); err != nil {
+		return err
+	}
+	client, err := ethclient.DialContext(ctx, *rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	d, err := selected.Resolve(ctx, client)
+	if err != nil {
+		return err
+	}
+	results, err := bytecode.Verify(ctx, client, d)
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		if err := cli.PrintJSON(results); err != nil {
+			return err
+		}
+	} else {
+		tw := cli.NewTable(os.Stdout)
+		fmt.Fprintln(tw, "TYPE\tADDRESS\tMATCH")
+		for _, r := range results {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Type, r.Address, r.Match)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	if !bytecode.AllExact(results) {
+		return cli.ErrSilent
+	}
+	return nil
+}
+
+func runDiff(ctx context.Context, args []string) error {
+	fs := newFlagSet("diff")
+	jsonOut := fs.Bool("json", false, "print the changes as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: inspect diff [flags] OLD.json NEW.json")
+		fmt.Fprintln(fs.Output(), "Exits with status 1 if the snapshots differ.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return cli.ErrSilent
+	}
+	var a, b inspect.Snapshot
+	if err := cli.ReadJSONFile(fs.Arg(0), &a); err != nil {
+		return err
+	}
+	if err := cli.ReadJSONFile(fs.Arg(1), &b); err != nil {
+		return err
+	}
+	changes, err := inspect.Diff(&a, &b)
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		if changes == nil {
+			changes = []inspect.Change{}
+		}
+		err = cli.PrintJSON(changes)
+	} else {
+		fmt.Printf("block %d -> %d\n", a.BlockNumber, b.BlockNumber)
+		for _, c := range changes {
+			fmt.Printf("%s: %v -> %v\n", c.Path, c.Old, c.New)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(changes) > 0 {
+		return cli.ErrSilent
+	}
+	return nil
+}
+
+func runVerifyManifest(ctx context.Context, args []string) error {
+	fs := newFlagSet("verify-manifest")
+	rpcURL := fs.String("rpc", "", "RPC endpoint of the chain")
+	manifestPath := fs.String("manifest", "", "deployment manifest")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: inspect verify-manifest -rpc URL -manifest FILE")
+		fmt.Fprintln(fs.Output(), "Checks that every contract the manifest lists for the chain has the recorded code hash.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "rpc", "manifest"); err != nil {
+		return err
+	}
+	client, err := ethclient.DialContext(ctx, *rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	chain, err := cli.ManifestChain(ctx, client, *manifestPath)
+	if err != nil {
+		return err
+	}
+	if err := chain.VerifyCode(ctx, client); err != nil {
+		return err
+	}
+	fmt.Printf("%d contracts on chain %v match the manifest\n", len(chain.Contracts), chain.ChainID)
+	return nil
+}
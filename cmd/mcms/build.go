@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/manifest"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+func runBuild(ctx context.Context, args []string) error {
+	fs := newFlagSet("build")
+	specPath := fs.String("spec", "", "proposal spec file")
+	outPath := fs.String("out", "", "file to write the proposal to")
+	rpcURLs := cli.RPCFlag{}
+	fs.Var(rpcURLs, "rpc", "CHAINID=URL RPC endpoint used to read opCounts missing from the spec, repeatable")
+	manifestPath := fs.String("manifest", "", "deployment manifest resolving the multiSigRole of chains in the spec")
+	jsonOut := fs.Bool("json", false, "print the root and proposal hash as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "spec", "out"); err != nil {
+		return err
+	}
+	spec, err := mcms.LoadProposalSpec(*specPath)
+	if err != nil {
+		return err
+	}
+	if *manifestPath != "" {
+		m, err := manifest.Load(*manifestPath)
+		if err != nil {
+			return err
+		}
+		if err := m.ResolveProposalSpec(spec); err != nil {
+			return err
+		}
+	}
+
+	c := cli.NewClients(rpcURLs)
+	defer c.Close()
+	opCount := func(ctx context.Context, chainID *big.Int, multiSig common.Address) (uint64, error) {
+		client, err := c.Get(ctx, chainID)
+		if err != nil {
+			return 0, err
+		}
+		caller, err := gethwrappers.NewManyChainMultiSigCaller(multiSig, client)
+		if err != nil {
+			return 0, err
+		}
+		count, err := caller.GetOpCount(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return 0, err
+		}
+		return count.Uint64(), nil
+	}
+	p, err := mcms.BuildProposal(ctx, spec, time.Now(), opCount)
+	if err != nil {
+		return err
+	}
+	if err := p.Save(*outPath); err != nil {
+		return err
+	}
+
+	root, err := p.Root()
+	if err != nil {
+		return err
+	}
+	hash, err := p.Hash()
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		return cli.PrintJSON(map[string]interface{}{"root": root, "validUntil": p.ValidUntil, "proposalHash": hash})
+	}
+	fmt.Printf("wrote %s\nroot:          %s\nvalidUntil:    %d\nproposal hash: %s\n", *outPath, root, p.ValidUntil, hash)
+	return nil
+}
+
+// printOpSummary writes a table of the proposal's ops for human review.
+func printOpSummary(w io.Writer, summary []mcms.OpSummary) error {
+	tw := cli.NewTable(w)
+	fmt.Fprintln(tw, "CHAIN\tMULTISIG\tNONCE\tTO\tVALUE\tCALL")
+	for _, op := range summary {
+		fmt.Fprintf(tw, "%v\t%s\t%d\t%s\t%v\t%s\n", op.ChainID, op.MultiSig, op.Nonce, op.To, op.Value, op.Call)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,40 @@
+// Command mcms works with ManyChainMultiSig proposals.
+//
+// Usage:
+//
+//	mcms <command> [flags]
+//
+// Run "mcms help" for the list of commands.
+package main
+
+import (
+	"flag"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+)
+
+var commands []cli.Command
+
+func init() {
+	commands = []cli.Command{
+		{Name: "build", Summary: "build a proposal from a spec file", Run: runBuild},
+		{Name: "sign", Summary: "sign a proposal and add the signature to it", Run: runSign},
+		{Name: "export-request", Summary: "write a signing request for an offline signer", Run: runExportRequest},
+		{Name: "sign-request", Summary: "verify and sign a signing request, writing a signature bundle", Run: runSignRequest},
+		{Name: "aggregate", Summary: "import signature bundles into a proposal", Run: runAggregate},
+		{Name: "verify-signatures", Summary: "report on the signatures collected for a proposal", Run: runVerifySignatures},
+		{Name: "verify-quorum", Summary: "check the collected signatures reach quorum on every chain", Run: runVerifyQuorum},
+		{Name: "check-replay", Summary: "check that a proposal's (root, validUntil) was not used before", Run: runCheckReplay},
+		{Name: "submit", Summary: "call setRoot on every chain and execute the ops in nonce order", Run: runSubmit},
+		{Name: "relay", Summary: "submit a proposal to all of its chains concurrently", Run: runRelay},
+		{Name: "progress", Summary: "report how far a proposal got on each of its chains", Run: runProgress},
+	}
+}
+
+func main() {
+	cli.Main("mcms", commands)
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return cli.NewFlagSet("mcms", name)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+func runProgress(ctx context.Context, args []string) error {
+	fs := newFlagSet("progress")
+	proposalPath := fs.String("proposal", "", "proposal file")
+	rpcURLs := cli.RPCFlag{}
+	fs.Var(rpcURLs, "rpc", "CHAINID=URL RPC endpoint, repeated for every chain in the proposal")
+	fromBlock := fs.Uint64("from-block", 0, "first block to scan for NewRoot and OpExecuted events")
+	jsonOut := fs.Bool("json", false, "print the progress as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mcms progress -proposal FILE -rpc CHAINID=URL... [flags]")
+		fmt.Fprintln(fs.Output(), "Exits with status 1 if the proposal can no longer complete on some chain.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "proposal", "rpc"); err != nil {
+		return err
+	}
+	p, err := mcms.LoadProposal(*proposalPath)
+	if err != nil {
+		return err
+	}
+
+	c := cli.NewClients(rpcURLs)
+	defer c.Close()
+	progress, err := mcms.TrackProgress(ctx, p, func(chainID *big.Int) (mcms.ProgressBackend, error) {
+		return c.Get(ctx, chainID)
+	}, *fromBlock)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		err = cli.PrintJSON(progress)
+	} else {
+		fmt.Printf("root %s: %s\n", progress.Root, progress.Status)
+		tw := cli.NewTable(os.Stdout)
+		fmt.Fprintln(tw, "CHAIN\tMULTISIG\tSTATUS\tROOT SET\tEXECUTED\tNEXT NONCE\tNOTE")
+		for _, cp := range progress.Chains {
+			next := "-"
+			if cp.NextNonce != nil {
+				next = fmt.Sprintf("%d (op %d)", *cp.NextNonce, *cp.NextOp)
+			}
+			note := cp.Problem
+			if note == "" && cp.Expired && cp.Status != mcms.ProgressDone {
+				note = "validUntil has passed"
+			}
+			fmt.Fprintf(tw, "%v\t%s\t%s\t%t\t%d/%d\t%s\t%s\n", cp.ChainID, cp.MultiSig, cp.Status, cp.RootSet,
+				cp.Executed, cp.PostOpCount-cp.PreOpCount, next, note)
+		}
+		err = tw.Flush()
+	}
+	if err != nil {
+		return err
+	}
+	if progress.Status == mcms.ProgressStuck {
+		return cli.ErrSilent
+	}
+	return nil
+}
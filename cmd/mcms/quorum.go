@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+type chainQuorum struct {
+	ChainID       *big.Int       `json:"chainId"`
+	MultiSig      common.Address `json:"multiSig"`
+	ValidSigners  int            `json:"validSigners"`
+	QuorumReached bool           `json:"quorumReached"`
+}
+
+func runVerifyQuorum(ctx context.Context, args []string) error {
+	fs := newFlagSet("verify-quorum")
+	proposalPath := fs.String("proposal", "", "proposal file")
+	rpcURLs := cli.RPCFlag{}
+	fs.Var(rpcURLs, "rpc", "CHAINID=URL RPC endpoint, repeated for every chain in the proposal")
+	jsonOut := fs.Bool("json", false, "print the result as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mcms verify-quorum -proposal FILE -rpc CHAINID=URL...")
+		fmt.Fprintln(fs.Output(), "Checks the collected signatures against the current config of every chain.")
+		fmt.Fprintln(fs.Output(), "Exits with status 1 if quorum is not reached on some chain.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "proposal", "rpc"); err != nil {
+		return err
+	}
+	p, err := mcms.LoadProposal(*proposalPath)
+	if err != nil {
+		return err
+	}
+
+	c := cli.NewClients(rpcURLs)
+	defer c.Close()
+	results := make([]chainQuorum, len(p.Chains))
+	ok := true
+	for i, chain := range p.Chains {
+		config, err := fetchConfig(ctx, c, p, i)
+		if err != nil {
+			return fmt.Errorf("chain %v: %w", chain.ChainID, err)
+		}
+		report, err := mcms.NewSignatureReport(p, config, nil)
+		if err != nil {
+			return err
+		}
+		results[i] = chainQuorum{
+			ChainID:       chain.ChainID,
+			MultiSig:      chain.MultiSig,
+			ValidSigners:  report.ValidSigners,
+			QuorumReached: report.QuorumReached,
+		}
+		ok = ok && report.QuorumReached
+	}
+
+	if *jsonOut {
+		err = cli.PrintJSON(results)
+	} else {
+		tw := cli.NewTable(os.Stdout)
+		fmt.Fprintln(tw, "CHAIN\tMULTISIG\tVALID SIGNERS\tQUORUM")
+		for _, r := range results {
+			fmt.Fprintf(tw, "%v\t%s\t%d\t%t\n", r.ChainID, r.MultiSig, r.ValidSigners, r.QuorumReached)
+		}
+		err = tw.Flush()
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return cli.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+func runRelay(ctx context.Context, args []string) error {
+	fs := newFlagSet("relay")
+	proposalPath := fs.String("proposal", "", "signed proposal file")
+	rpcURLs := cli.RPCFlag{}
+	fs.Var(rpcURLs, "rpc", "CHAINID=URL RPC endpoint, repeated for every chain in the proposal")
+	txFlags := cli.AddTransactorFlags(fs)
+	concurrency := fs.Int("concurrency", 0, "number of chains relayed to at once (default all)")
+	fromBlock := fs.Uint64("from-block", 0, "first block to scan for OpExecuted events when a chain's root was replaced")
+	chainTimeout := fs.Duration("chain-timeout", 0, "give up on a chain after this long (default never)")
+	setRootOnly := fs.Bool("set-root-only", false, "only call setRoot, do not execute ops")
+	jsonOut := fs.Bool("json", false, "print the results as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mcms relay -proposal FILE -rpc CHAINID=URL... -tx-keystore FILE [flags]")
+		fmt.Fprintln(fs.Output(), "Calls setRoot on every chain of the proposal concurrently, then executes each chain's ops in nonce order.")
+		fmt.Fprintln(fs.Output(), "A failing chain does not stop the others; run again to resume. Exits with status 1 if any chain failed.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "proposal", "rpc"); err != nil {
+		return err
+	}
+	p, err := mcms.LoadProposal(*proposalPath)
+	if err != nil {
+		return err
+	}
+
+	// The key is decrypted once, up front; each chain's client is set up in
+	// its worker, under -chain-timeout, so that a slow endpoint only fails
+	// its own chains.
+	if _, err := txFlags.Opts(ctx, p.Chains[0].ChainID); err != nil {
+		return err
+	}
+	c := cli.NewClients(rpcURLs)
+	defer c.Close()
+	results := mcms.Relay(ctx, p, func(ctx context.Context, chainID *big.Int, multiSig common.Address) (*mcms.Client, error) {
+		client, err := c.Get(ctx, chainID)
+		if err != nil {
+			return nil, err
+		}
+		opts, err := txFlags.Opts(ctx, chainID)
+		if err != nil {
+			return nil, err
+		}
+		mc, err := mcms.NewClient(chainID, multiSig, client, opts)
+		if err != nil {
+			return nil, err
+		}
+		mc.FromBlock = *fromBlock
+		return mc, nil
+	}, mcms.RelayOptions{
+		Concurrency:  *concurrency,
+		ChainTimeout: *chainTimeout,
+		SetRootOnly:  *setRootOnly,
+		Logf: func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, format+"\n", args...)
+		},
+	})
+
+	failed := false
+	for _, r := range results {
+		failed = failed || r.Err != nil
+	}
+	if *jsonOut {
+		err = cli.PrintJSON(results)
+	} else {
+		tw := cli.NewTable(os.Stdout)
+		fmt.Fprintln(tw, "CHAIN\tMULTISIG\tROOT SET\tEXECUTED\tDONE\tTIME\tERROR")
+		for _, r := range results {
+			fmt.Fprintf(tw, "%v\t%s\t%t\t%d\t%t\t%s\t%s\n", r.ChainID, r.MultiSig, r.RootSet, len(r.Executed), r.Done,
+				r.Duration.Round(time.Second), r.Error)
+		}
+		err = tw.Flush()
+	}
+	if err != nil {
+		return err
+	}
+	if failed {
+		return cli.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+func runCheckReplay(ctx context.Context, args []string) error {
+	fs := newFlagSet("check-replay")
+	proposalPath := fs.String("proposal", "", "proposal file")
+	rpcURLs := cli.RPCFlag{}
+	fs.Var(rpcURLs, "rpc", "CHAINID=URL RPC endpoint, repeated for every chain in the proposal")
+	fromBlock := fs.Uint64("from-block", 0, "first block to scan for NewRoot events")
+	jsonOut := fs.Bool("json", false, "print the result as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mcms check-replay -proposal FILE -rpc CHAINID=URL... [flags]")
+		fmt.Fprintln(fs.Output(), "Exits with status 1 if (root, validUntil) was already used on any chain.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "proposal", "rpc"); err != nil {
+		return err
+	}
+	p, err := mcms.LoadProposal(*proposalPath)
+	if err != nil {
+		return err
+	}
+
+	c := cli.NewClients(rpcURLs)
+	defer c.Close()
+	checks, err := mcms.CheckReplay(ctx, p, func(chainID *big.Int) (mcms.LogBackend, error) {
+		return c.Get(ctx, chainID)
+	}, *fromBlock)
+	if err != nil {
+		return err
+	}
+
+	replayed := false
+	for _, check := range checks {
+		replayed = replayed || check.Seen != nil
+	}
+	if *jsonOut {
+		err = cli.PrintJSON(checks)
+	} else {
+		tw := cli.NewTable(os.Stdout)
+		fmt.Fprintln(tw, "CHAIN\tMULTISIG\tSTATUS")
+		for _, check := range checks {
+			status := "unused"
+			if check.Seen != nil {
+				status = fmt.Sprintf("already used in block %d (tx %s)", check.Seen.BlockNumber, check.Seen.TxHash)
+			}
+			fmt.Fprintf(tw, "%v\t%s\t%s\n", check.ChainID, check.MultiSig, status)
+		}
+		err = tw.Flush()
+	}
+	if err != nil {
+		return err
+	}
+	if replayed {
+		return cli.ErrSilent
+	}
+	return nil
+}
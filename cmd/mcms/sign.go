@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+)
+
+func runSign(ctx context.Context, args []string) error {
+	fs := newFlagSet("sign")
+	proposalPath := fs.String("proposal", "", "proposal file, updated in place with the new signature")
+	signerFlags := cli.AddSignerFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "proposal"); err != nil {
+		return err
+	}
+	p, err := mcms.LoadProposal(*proposalPath)
+	if err != nil {
+		return err
+	}
+	s, err := signerFlags.Signer(ctx)
+	if err != nil {
+		return err
+	}
+	summary, err := p.Summarize()
+	if err != nil {
+		return err
+	}
+	if err := printOpSummary(os.Stderr, summary); err != nil {
+		return err
+	}
+	root, err := p.Root()
+	if err != nil {
+		return err
+	}
+	sig, err := signer.SignRoot(ctx, s, root, p.ValidUntil)
+	if err != nil {
+		return err
+	}
+	if _, err := p.AddSignature(signer.FromGethSignature(sig)); err != nil {
+		return err
+	}
+	if err := p.Save(*proposalPath); err != nil {
+		return err
+	}
+	fmt.Printf("added signature from %s (%d signatures collected)\n", s.Address(), len(p.Signatures))
+	return nil
+}
+
+func runExportRequest(_ context.Context, args []string) error {
+	fs := newFlagSet("export-request")
+	proposalPath := fs.String("proposal", "", "proposal file")
+	outPath := fs.String("out", "", "file to write the signing request to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "proposal", "out"); err != nil {
+		return err
+	}
+	p, err := mcms.LoadProposal(*proposalPath)
+	if err != nil {
+		return err
+	}
+	request, err := mcms.NewSigningRequest(p)
+	if err != nil {
+		return err
+	}
+	if err := request.Save(*outPath); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s for root %s, validUntil %d\n", *outPath, request.Root, request.ValidUntil)
+	return nil
+}
+
+func runSignRequest(ctx context.Context, args []string) error {
+	fs := newFlagSet("sign-request")
+	requestPath := fs.String("request", "", "signing request file")
+	outPath := fs.String("out", "", "file to write the signature bundle to")
+	signerFlags := cli.AddSignerFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "request", "out"); err != nil {
+		return err
+	}
+	request, err := mcms.LoadSigningRequest(*requestPath)
+	if err != nil {
+		return err
+	}
+	if err := request.Verify(); err != nil {
+		return fmt.Errorf("refusing to sign: %w", err)
+	}
+	if err := printOpSummary(os.Stderr, request.Summary); err != nil {
+		return err
+	}
+	s, err := signerFlags.Signer(ctx)
+	if err != nil {
+		return err
+	}
+	bundle, err := request.Sign(ctx, s)
+	if err != nil {
+		return err
+	}
+	if err := bundle.Save(*outPath); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s signed by %s\n", *outPath, bundle.Signer)
+	return nil
+}
+
+func runAggregate(_ context.Context, args []string) error {
+	fs := newFlagSet("aggregate")
+	proposalPath := fs.String("proposal", "", "proposal file, updated in place with the imported signatures")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mcms aggregate -proposal FILE BUNDLE...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "proposal"); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("no signature bundles given")
+	}
+	p, err := mcms.LoadProposal(*proposalPath)
+	if err != nil {
+		return err
+	}
+	for _, path := range fs.Args() {
+		bundle, err := mcms.LoadSignatureBundle(path)
+		if err != nil {
+			return err
+		}
+		if err := mcms.ImportSignatureBundle(p, bundle); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		fmt.Printf("imported signature from %s\n", bundle.Signer)
+	}
+	return p.Save(*proposalPath)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+func runVerifySignatures(ctx context.Context, args []string) error {
+	fs := newFlagSet("verify-signatures")
+	proposalPath := fs.String("proposal", "", "proposal file")
+	configPath := fs.String("config", "", "ManyChainMultiSigConfig JSON file (instead of -rpc)")
+	rpcURLs := cli.RPCFlag{}
+	fs.Var(rpcURLs, "rpc", "CHAINID=URL RPC endpoint to read the current config from")
+	chainIndex := fs.Int("chain", 0, "index into the proposal's chains of the instance to read the config from")
+	aliasesPath := fs.String("aliases", "", "JSON file mapping signer addresses to names")
+	jsonOut := fs.Bool("json", false, "print the report as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mcms verify-signatures -proposal FILE (-config FILE | -rpc CHAINID=URL) [flags]")
+		fmt.Fprintln(fs.Output(), "Exits with status 1 if the valid signatures do not reach quorum.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "proposal"); err != nil {
+		return err
+	}
+
+	p, err := mcms.LoadProposal(*proposalPath)
+	if err != nil {
+		return err
+	}
+	var config gethwrappers.ManyChainMultiSigConfig
+	switch {
+	case *configPath != "":
+		data, err := os.ReadFile(*configPath)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return fmt.Errorf("decoding %s: %w", *configPath, err)
+		}
+	case len(rpcURLs) > 0:
+		c := cli.NewClients(rpcURLs)
+		defer c.Close()
+		if config, err = fetchConfig(ctx, c, p, *chainIndex); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("one of -config or -rpc is required")
+	}
+	aliases := mcms.Aliases{}
+	if *aliasesPath != "" {
+		if aliases, err = mcms.LoadAliases(*aliasesPath); err != nil {
+			return err
+		}
+	}
+
+	report, err := mcms.NewSignatureReport(p, config, aliases)
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		err = cli.PrintJSON(report)
+	} else {
+		err = printSignatureReport(report)
+	}
+	if err != nil {
+		return err
+	}
+	if !report.QuorumReached {
+		return cli.ErrSilent
+	}
+	return nil
+}
+
+func fetchConfig(ctx context.Context, c *cli.Clients, p *mcms.Proposal, chainIndex int) (gethwrappers.ManyChainMultiSigConfig, error) {
+	if chainIndex < 0 || chainIndex >= len(p.Chains) {
+		return gethwrappers.ManyChainMultiSigConfig{}, fmt.Errorf("proposal has no chain with index %d", chainIndex)
+	}
+	chain := p.Chains[chainIndex]
+	client, err := c.Get(ctx, chain.ChainID)
+	if err != nil {
+		return gethwrappers.ManyChainMultiSigConfig{}, err
+	}
+	caller, err := gethwrappers.NewManyChainMultiSigCaller(chain.MultiSig, client)
+	if err != nil {
+		return gethwrappers.ManyChainMultiSigConfig{}, err
+	}
+	return caller.GetConfig(&bind.CallOpts{Context: ctx})
+}
+
+func printSignatureReport(report *mcms.SignatureReport) error {
+	fmt.Printf("root:         %s\n", report.Root)
+	fmt.Printf("validUntil:   %d\n", report.ValidUntil)
+	fmt.Printf("signing hash: %s\n\n", report.SigningHash)
+
+	tw := cli.NewTable(os.Stdout)
+	fmt.Fprintln(tw, "#\tSIGNER\tALIAS\tGROUP\tVALID\tDUPLICATE\tDIAGNOSIS")
+	for _, e := range report.Entries {
+		group := "-"
+		if e.Group != nil {
+			group = fmt.Sprint(*e.Group)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%t\t%t\t%s\n", e.Index, e.Recovered, e.Alias, group, e.Valid, e.Duplicate, e.Diagnosis)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\n%d distinct valid signers, quorum reached: %t\n", report.ValidSigners, report.QuorumReached)
+	return nil
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+// submitProgress is persisted after every transaction so that an interrupted
+// submission can be resumed. The chain itself remains the source of truth:
+// on resume, the current root and opCount decide what is left to do.
+type submitProgress struct {
+	ProposalHash common.Hash     `json:"proposalHash"`
+	Root         common.Hash     `json:"root"`
+	Chains       []chainProgress `json:"chains"`
+}
+
+type chainProgress struct {
+	ChainID     *big.Int       `json:"chainId"`
+	MultiSig    common.Address `json:"multiSig"`
+	SetRootTx   *common.Hash   `json:"setRootTx,omitempty"`
+	RootSet     bool           `json:"rootSet"`
+	ExecutedOps []executedOp   `json:"executedOps"`
+	Done        bool           `json:"done"`
+	Error       string         `json:"error,omitempty"`
+}
+
+type executedOp struct {
+	Nonce uint64       `json:"nonce"`
+	Tx    *common.Hash `json:"tx,omitempty"`
+}
+
+func runSubmit(ctx context.Context, args []string) error {
+	fs := newFlagSet("submit")
+	proposalPath := fs.String("proposal", "", "signed proposal file")
+	progressPath := fs.String("progress", "", "file recording progress, resumed from if it exists")
+	rpcURLs := cli.RPCFlag{}
+	fs.Var(rpcURLs, "rpc", "CHAINID=URL RPC endpoint, repeated for every chain in the proposal")
+	txFlags := cli.AddTransactorFlags(fs)
+	setRootOnly := fs.Bool("set-root-only", false, "only call setRoot, do not execute ops")
+	jsonOut := fs.Bool("json", false, "print the final progress as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mcms submit -proposal FILE -rpc CHAINID=URL... -tx-keystore FILE [flags]")
+		fmt.Fprintln(fs.Output(), "Calls setRoot on every chain of the proposal, then executes each chain's ops in nonce order.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "proposal", "rpc"); err != nil {
+		return err
+	}
+	p, err := mcms.LoadProposal(*proposalPath)
+	if err != nil {
+		return err
+	}
+	progress, err := loadProgress(*progressPath, p)
+	if err != nil {
+		return err
+	}
+	save := func() error {
+		if *progressPath == "" {
+			return nil
+		}
+		return cli.WriteJSONFile(*progressPath, progress)
+	}
+
+	c := cli.NewClients(rpcURLs)
+	defer c.Close()
+	failed := false
+	for i := range p.Chains {
+		cp := &progress.Chains[i]
+		if cp.Done {
+			continue
+		}
+		cp.Error = ""
+		if err := submitChain(ctx, c, txFlags, p, i, progress.Root, cp, *setRootOnly, save); err != nil {
+			cp.Error = err.Error()
+			failed = true
+			fmt.Fprintf(os.Stderr, "chain %v: %v\n", cp.ChainID, err)
+		}
+		if err := save(); err != nil {
+			return err
+		}
+	}
+
+	if *jsonOut {
+		err = cli.PrintJSON(progress)
+	} else {
+		for _, cp := range progress.Chains {
+			fmt.Printf("chain %v %s: root set %t, %d ops executed, done %t\n",
+				cp.ChainID, cp.MultiSig, cp.RootSet, len(cp.ExecutedOps), cp.Done)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if failed {
+		return cli.ErrSilent
+	}
+	return nil
+}
+
+func submitChain(
+	ctx context.Context,
+	c *cli.Clients,
+	txFlags *cli.TransactorFlags,
+	p *mcms.Proposal,
+	chainIndex int,
+	root common.Hash,
+	cp *chainProgress,
+	setRootOnly bool,
+	save func() error,
+) error {
+	chain := p.Chains[chainIndex]
+	client, err := c.Get(ctx, chain.ChainID)
+	if err != nil {
+		return err
+	}
+	opts, err := txFlags.Opts(ctx, chain.ChainID)
+	if err != nil {
+		return err
+	}
+	contract, err := gethwrappers.NewManyChainMultiSig(chain.MultiSig, client)
+	if err != nil {
+		return err
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	current, err := contract.GetRoot(callOpts)
+	if err != nil {
+		return err
+	}
+	if (current.Root != root || current.ValidUntil != p.ValidUntil) && cp.SetRootTx != nil {
+		// A previous run sent setRoot; wait for it rather than sending a
+		// second one, and only resend if the node has dropped it.
+		if _, err := evm.WaitSent(ctx, client, *cp.SetRootTx); err != nil && !errors.Is(err, ethereum.NotFound) {
+			return fmt.Errorf("setRoot tx %s: %w", cp.SetRootTx, err)
+		}
+		if current, err = contract.GetRoot(callOpts); err != nil {
+			return err
+		}
+	}
+	if current.Root != root || current.ValidUntil != p.ValidUntil {
+		args, err := p.SetRootArgs(chainIndex)
+		if err != nil {
+			return err
+		}
+		tx, err := contract.SetRoot(opts, args.Root, args.ValidUntil, args.Metadata, args.MetadataProof, args.Signatures)
+		if err != nil {
+			return fmt.Errorf("setRoot: %w", err)
+		}
+		hash := tx.Hash()
+		cp.SetRootTx = &hash
+		if err := save(); err != nil {
+			return err
+		}
+		if _, err := evm.WaitSuccess(ctx, client, tx); err != nil {
+			return fmt.Errorf("setRoot: %w", err)
+		}
+	}
+	cp.RootSet = true
+	if setRootOnly {
+		return save()
+	}
+
+	opCount, err := contract.GetOpCount(callOpts)
+	if err != nil {
+		return err
+	}
+	executeArgs, err := p.ExecuteArgs(chainIndex)
+	if err != nil {
+		return err
+	}
+	for _, args := range executeArgs {
+		nonce := args.Op.Nonce.Uint64()
+		if nonce < opCount.Uint64() {
+			cp.recordExecuted(nonce, nil)
+			continue
+		}
+		tx, err := contract.Execute(opts, args.Op, args.Proof)
+		if err != nil {
+			return fmt.Errorf("execute nonce %d: %w", nonce, err)
+		}
+		if _, err := evm.WaitSuccess(ctx, client, tx); err != nil {
+			return fmt.Errorf("execute nonce %d: %w", nonce, err)
+		}
+		hash := tx.Hash()
+		cp.recordExecuted(nonce, &hash)
+		if err := save(); err != nil {
+			return err
+		}
+	}
+	cp.Done = true
+	return nil
+}
+
+func (cp *chainProgress) recordExecuted(nonce uint64, tx *common.Hash) {
+	for _, op := range cp.ExecutedOps {
+		if op.Nonce == nonce {
+			return
+		}
+	}
+	cp.ExecutedOps = append(cp.ExecutedOps, executedOp{Nonce: nonce, Tx: tx})
+}
+
+func loadProgress(path string, p *mcms.Proposal) (*submitProgress, error) {
+	hash, err := p.Hash()
+	if err != nil {
+		return nil, err
+	}
+	root, err := p.Root()
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		var progress submitProgress
+		err := cli.ReadJSONFile(path, &progress)
+		switch {
+		case err == nil:
+			if progress.ProposalHash != hash || len(progress.Chains) != len(p.Chains) {
+				return nil, fmt.Errorf("%s records progress for another proposal", path)
+			}
+			return &progress, nil
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, err
+		}
+	}
+	progress := &submitProgress{ProposalHash: hash, Root: root, Chains: make([]chainProgress, len(p.Chains))}
+	for i, chain := range p.Chains {
+		progress.Chains[i] = chainProgress{ChainID: chain.ChainID, MultiSig: chain.MultiSig, ExecutedOps: []executedOp{}}
+	}
+	return progress, nil
+}
@@ -0,0 +1,107 @@
+// Command monitor watches owner-contract deployments and raises alerts.
+//
+// Usage:
+//
+//	monitor <command> [flags]
+//
+// Run "monitor help" for the list of commands.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/alert"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/manifest"
+)
+
+var commands []cli.Command
+
+func init() {
+	commands = []cli.Command{
+		{Name: "roots", Summary: "alert on NewRoot events that match no approved proposal", Run: runRoots},
+		{Name: "scheduled", Summary: "announce timelock operations with decoded calls and their ETA", Run: runScheduled},
+		{Name: "metrics", Summary: "serve the state of the contracts as Prometheus metrics", Run: runMetrics},
+	}
+}
+
+func main() {
+	cli.Main("monitor", commands)
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return cli.NewFlagSet("monitor", name)
+}
+
+// addAlertFlag registers the repeatable -alert flag.
+func addAlertFlag(fs *flag.FlagSet) *cli.StringsFlag {
+	var specs cli.StringsFlag
+	fs.Var(&specs, "alert", "where to send alerts: stdout, file:PATH or a webhook URL, repeatable (default stdout)")
+	return &specs
+}
+
+// newSink returns the sink of the -alert flags.
+func newSink(specs cli.StringsFlag) (alert.Sink, error) {
+	if len(specs) == 0 {
+		specs = cli.StringsFlag{"stdout"}
+	}
+	var sinks alert.Multi
+	for _, spec := range specs {
+		s, err := alert.Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	return sinks, nil
+}
+
+// chain is a chain of the manifest with an RPC endpoint.
+type chain struct {
+	*manifest.Chain
+	client *ethclient.Client
+	// fromBlock is lookback blocks behind the head.
+	fromBlock uint64
+}
+
+// dialChains connects to every chain given with -rpc and looks it up in the
+// manifest at path.
+func dialChains(ctx context.Context, clients *cli.Clients, urls cli.RPCFlag, path string, lookback uint64) ([]*chain, error) {
+	m, err := manifest.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(urls))
+	for id := range urls {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var chains []*chain
+	for _, id := range ids {
+		chainID, _ := new(big.Int).SetString(id, 10)
+		c := m.Chain(chainID)
+		if c == nil {
+			return nil, fmt.Errorf("%s has no chain %s", path, id)
+		}
+		client, err := clients.Get(ctx, chainID)
+		if err != nil {
+			return nil, err
+		}
+		head, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		from := uint64(0)
+		if head.Number.Uint64() > lookback {
+			from = head.Number.Uint64() - lookback
+		}
+		chains = append(chains, &chain{Chain: c, client: client, fromBlock: from})
+	}
+	return chains, nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/exporter"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/manifest"
+)
+
+func runMetrics(ctx context.Context, args []string) error {
+	fs := newFlagSet("metrics")
+	rpcURLs := cli.RPCFlag{}
+	fs.Var(rpcURLs, "rpc", "CHAINID=URL RPC endpoint, repeated for every chain to export")
+	manifestPath := fs.String("manifest", "", "deployment manifest listing the contracts of each chain")
+	listen := fs.String("listen", ":9464", "address to serve /metrics on")
+	interval := fs.Duration("interval", 30*time.Second, "polling interval")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: monitor metrics -manifest FILE -rpc CHAINID=URL... [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "manifest"); err != nil {
+		return err
+	}
+	if len(rpcURLs) == 0 {
+		return errors.New("-rpc is required")
+	}
+
+	clients := cli.NewClients(rpcURLs)
+	defer clients.Close()
+	chains, err := dialChains(ctx, clients, rpcURLs, *manifestPath, 0)
+	if err != nil {
+		return err
+	}
+	var polled []exporter.Chain
+	for _, c := range chains {
+		polled = append(polled, exporter.Chain{
+			ChainID:    c.ChainID,
+			Backend:    c.client,
+			Deployment: c.Deployment(),
+			FromBlock:  deployBlock(c.Chain),
+		})
+	}
+	ex, err := exporter.New(polled)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", ex.Handler())
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	ln, err := net.Listen("tcp", *listen)
+	if err != nil {
+		return err
+	}
+	go srv.Serve(ln)
+	defer srv.Close()
+	fmt.Fprintf(os.Stderr, "exporting %d chains on http://%s/metrics\n", len(polled), ln.Addr())
+	return ex.Run(ctx, *interval, func(err error) {
+		fmt.Fprintln(os.Stderr, "poll:", err)
+	})
+}
+
+// deployBlock returns the block the first contract of c was deployed in, or
+// 0 if the manifest does not record it.
+func deployBlock(c *manifest.Chain) uint64 {
+	var first uint64
+	for _, contract := range c.Contracts {
+		if contract.Block == 0 {
+			return 0
+		}
+		if first == 0 || contract.Block < first {
+			first = contract.Block
+		}
+	}
+	return first
+}
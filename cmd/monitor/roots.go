@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/follow"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/rootwatch"
+)
+
+func runRoots(ctx context.Context, args []string) error {
+	fs := newFlagSet("roots")
+	rpcURLs := cli.RPCFlag{}
+	fs.Var(rpcURLs, "rpc", "CHAINID=URL RPC endpoint, repeated for every chain to watch")
+	manifestPath := fs.String("manifest", "", "deployment manifest listing the ManyChainMultiSigs of each chain")
+	approved := fs.String("approved", "", "approved proposal file, or directory of them; re-read when an unknown root appears")
+	sinks := addAlertFlag(fs)
+	maxValidity := fs.Duration("max-validity", rootwatch.DefaultMaxValidity, "alert on roots valid for longer than this after being set (0 disables)")
+	confirmations := fs.Uint64("confirmations", 0, "blocks to wait before checking a root")
+	lookback := fs.Uint64("lookback", 0, "also check roots set in this many blocks before the head")
+	interval := fs.Duration("interval", 15*time.Second, "polling interval")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: monitor roots -manifest FILE -approved PATH -rpc CHAINID=URL... [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "manifest", "approved"); err != nil {
+		return err
+	}
+	if len(rpcURLs) == 0 {
+		return errors.New("-rpc is required")
+	}
+	sink, err := newSink(*sinks)
+	if err != nil {
+		return err
+	}
+	registry, err := rootwatch.LoadRegistry(*approved)
+	if registry == nil {
+		return err
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "approved proposals:", err)
+	}
+
+	clients := cli.NewClients(rpcURLs)
+	defer clients.Close()
+	chains, err := dialChains(ctx, clients, rpcURLs, *manifestPath, *lookback)
+	if err != nil {
+		return err
+	}
+	var watched []follow.Chain
+	for _, c := range chains {
+		watched = append(watched, follow.Chain{
+			ChainID:       c.ChainID,
+			Backend:       c.client,
+			Contracts:     c.Deployment().MultiSigs,
+			FromBlock:     c.fromBlock,
+			Confirmations: *confirmations,
+		})
+	}
+	m, err := rootwatch.NewMonitor(registry, sink, *maxValidity, watched)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "watching %d chains against %d approved roots\n", len(watched), registry.Len())
+	return m.Run(ctx, *interval, func(err error) {
+		fmt.Fprintln(os.Stderr, "poll:", err)
+	})
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/follow"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/schedwatch"
+)
+
+func runScheduled(ctx context.Context, args []string) error {
+	fs := newFlagSet("scheduled")
+	rpcURLs := cli.RPCFlag{}
+	fs.Var(rpcURLs, "rpc", "CHAINID=URL RPC endpoint, repeated for every chain to watch")
+	manifestPath := fs.String("manifest", "", "deployment manifest listing the RBACTimelocks of each chain")
+	proposalsPath := fs.String("proposals", "", "proposal file, or directory of them, to link operations to; re-read when an unknown operation appears")
+	linkBase := fs.String("link-base", "", "URL that proposal file names are appended to in links, e.g. of a repository (default the file path)")
+	var abiPaths cli.StringsFlag
+	fs.Var(&abiPaths, "abi", "ABI or artifact JSON file of a call target, repeatable; the owner contract ABIs are always known")
+	sinks := addAlertFlag(fs)
+	confirmations := fs.Uint64("confirmations", 0, "blocks to wait before announcing an operation")
+	lookback := fs.Uint64("lookback", 0, "also announce operations scheduled in this many blocks before the head")
+	interval := fs.Duration("interval", 15*time.Second, "polling interval")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: monitor scheduled -manifest FILE -rpc CHAINID=URL... [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "manifest"); err != nil {
+		return err
+	}
+	if len(rpcURLs) == 0 {
+		return errors.New("-rpc is required")
+	}
+	sink, err := newSink(*sinks)
+	if err != nil {
+		return err
+	}
+	var abis []*abi.ABI
+	for _, path := range abiPaths {
+		a, err := mcms.LoadABI(path)
+		if err != nil {
+			return err
+		}
+		abis = append(abis, a)
+	}
+	proposals := schedwatch.NewProposals()
+	if *proposalsPath != "" {
+		if proposals, err = schedwatch.LoadProposals(*linkBase, *proposalsPath); proposals == nil {
+			return err
+		} else if err != nil {
+			fmt.Fprintln(os.Stderr, "proposals:", err)
+		}
+	}
+
+	clients := cli.NewClients(rpcURLs)
+	defer clients.Close()
+	chains, err := dialChains(ctx, clients, rpcURLs, *manifestPath, *lookback)
+	if err != nil {
+		return err
+	}
+	var watched []follow.Chain
+	for _, c := range chains {
+		watched = append(watched, follow.Chain{
+			ChainID:       c.ChainID,
+			Backend:       c.client,
+			Contracts:     c.Deployment().Timelocks,
+			FromBlock:     c.fromBlock,
+			Confirmations: *confirmations,
+		})
+	}
+	w, err := schedwatch.New(mcms.NewCallDecoder(abis...), proposals, sink, watched)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "watching the timelocks of %d chains\n", len(watched))
+	return w.Run(ctx, *interval, func(err error) {
+		fmt.Fprintln(os.Stderr, "poll:", err)
+	})
+}
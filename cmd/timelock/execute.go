@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+func runOperationID(ctx context.Context, args []string) error {
+	fs := newFlagSet("operation-id")
+	batchPath := fs.String("batch", "", "batch file")
+	t := addTargetFlags(fs)
+	jsonOut := fs.Bool("json", false, "print the id as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timelock operation-id -batch FILE [-rpc URL (-timelock ADDR | -manifest FILE)] [flags]")
+		fmt.Fprintln(fs.Output(), "With -rpc and -timelock, the operation's current status is reported too.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "batch"); err != nil {
+		return err
+	}
+	b, err := timelock.LoadBatch(*batchPath)
+	if err != nil {
+		return err
+	}
+	id, err := b.ID()
+	if err != nil {
+		return err
+	}
+	out := struct {
+		ID     common.Hash     `json:"id"`
+		Status timelock.Status `json:"status,omitempty"`
+	}{ID: id}
+	if t.rpc != "" {
+		client, addr, _, err := t.dial(ctx)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		if out.Status, _, err = timelock.GetStatus(ctx, client, addr, id); err != nil {
+			return err
+		}
+	}
+	if *jsonOut {
+		return cli.PrintJSON(out)
+	}
+	if out.Status != "" {
+		fmt.Printf("%s  %s\n", id, out.Status)
+	} else {
+		fmt.Println(id)
+	}
+	return nil
+}
+
+func runExecute(ctx context.Context, args []string) error {
+	fs := newFlagSet("execute")
+	batchPath := fs.String("batch", "", "batch file")
+	t := addTargetFlags(fs)
+	callProxy := fs.String("call-proxy", "", "CallProxy to execute through (default: call the timelock directly)")
+	txFlags := cli.AddTransactorFlags(fs)
+	jsonOut := fs.Bool("json", false, "print the result as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timelock execute -batch FILE -rpc URL (-timelock ADDR | -manifest FILE) -tx-keystore FILE [flags]")
+		fmt.Fprintln(fs.Output(), "Checks that the operation is ready and its predecessor done, then calls executeBatch.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "batch"); err != nil {
+		return err
+	}
+	if *callProxy != "" && !common.IsHexAddress(*callProxy) {
+		return errors.New("-call-proxy must be a valid address")
+	}
+	b, err := timelock.LoadBatch(*batchPath)
+	if err != nil {
+		return err
+	}
+	client, addr, _, err := t.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	id, err := timelock.CheckExecutable(ctx, client, addr, b)
+	if err != nil {
+		return err
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+	opts, err := txFlags.Opts(ctx, chainID)
+	if err != nil {
+		return err
+	}
+	via := addr
+	if *callProxy != "" {
+		via = common.HexToAddress(*callProxy)
+	}
+	tx, err := timelock.ExecuteBatch(opts, client, via, b)
+	if err != nil {
+		return fmt.Errorf("executeBatch: %w", err)
+	}
+	if _, err := evm.WaitSuccess(ctx, client, tx); err != nil {
+		return fmt.Errorf("executeBatch: %w", err)
+	}
+	if *jsonOut {
+		return cli.PrintJSON(struct {
+			ID common.Hash `json:"id"`
+			Tx common.Hash `json:"tx"`
+		}{id, tx.Hash()})
+	}
+	fmt.Printf("executed %s in tx %s\n", id, tx.Hash())
+	return nil
+}
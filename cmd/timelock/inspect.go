@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+func runOperations(ctx context.Context, args []string) error {
+	fs := newFlagSet("operations")
+	t := addTargetFlags(fs)
+	fromBlock := fs.Uint64("from-block", 0, "first block to scan for CallScheduled events")
+	status := fs.String("status", "", "only list operations with this status (pending, ready, done, cancelled)")
+	jsonOut := fs.Bool("json", false, "print the operations as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timelock operations -rpc URL (-timelock ADDR | -manifest FILE) [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	switch timelock.Status(*status) {
+	case "", timelock.StatusPending, timelock.StatusReady, timelock.StatusDone, timelock.StatusCancelled:
+	default:
+		return fmt.Errorf("unknown status %q", *status)
+	}
+	client, addr, _, err := t.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	all, err := timelock.FetchOperations(ctx, client, addr, *fromBlock, evm.DefaultLogBatchSize)
+	if err != nil {
+		return err
+	}
+	ops := []*timelock.Operation{}
+	for _, op := range all {
+		if *status == "" || op.Status == timelock.Status(*status) {
+			ops = append(ops, op)
+		}
+	}
+	if *jsonOut {
+		return cli.PrintJSON(ops)
+	}
+	for _, op := range ops {
+		fmt.Printf("%s  %s", op.ID, op.Status)
+		if op.Status == timelock.StatusPending || op.Status == timelock.StatusReady {
+			fmt.Printf(" (ready at %s)", time.Unix(op.ReadyAt.Int64(), 0).UTC().Format(time.RFC3339))
+		}
+		fmt.Printf("\n  scheduled in block %d, tx %s, delay %vs\n", op.BlockNumber, op.TxHash, op.Delay)
+		if op.Predecessor != (common.Hash{}) {
+			fmt.Printf("  predecessor %s\n", op.Predecessor)
+		}
+		for i, call := range op.Calls {
+			fmt.Printf("  %d: %s value %v %s\n", i, call.Target, valueOrZero(call.Value), mcms.DescribeCall(call.Data))
+		}
+	}
+	return nil
+}
+
+func valueOrZero(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return v
+}
+
+func runMinDelay(ctx context.Context, args []string) error {
+	fs := newFlagSet("min-delay")
+	t := addTargetFlags(fs)
+	jsonOut := fs.Bool("json", false, "print the delay as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, _, contract, err := t.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	delay, err := contract.GetMinDelay(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		return cli.PrintJSON(struct {
+			MinDelay *big.Int `json:"minDelay"`
+		}{delay})
+	}
+	fmt.Printf("%vs (%v)\n", delay, time.Duration(delay.Int64())*time.Second)
+	return nil
+}
+
+func runBlockedSelectors(ctx context.Context, args []string) error {
+	fs := newFlagSet("blocked-selectors")
+	t := addTargetFlags(fs)
+	jsonOut := fs.Bool("json", false, "print the selectors as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, _, contract, err := t.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	selectors, err := timelock.BlockedSelectors(&bind.CallOpts{Context: ctx}, &contract.RBACTimelockCaller)
+	if err != nil {
+		return err
+	}
+	encoded := make([]string, len(selectors))
+	for i, s := range selectors {
+		encoded[i] = hexutil.Encode(s[:])
+	}
+	if *jsonOut {
+		return cli.PrintJSON(encoded)
+	}
+	for i, s := range selectors {
+		name := ""
+		if method, err := timelock.RBACTimelockABI.MethodById(s[:]); err == nil {
+			name = method.Sig
+		}
+		fmt.Printf("%s  %s\n", encoded[i], name)
+	}
+	return nil
+}
+
+type roleMembers struct {
+	Role    string           `json:"role"`
+	ID      common.Hash      `json:"id"`
+	Members []common.Address `json:"members"`
+}
+
+func runRoles(ctx context.Context, args []string) error {
+	fs := newFlagSet("roles")
+	t := addTargetFlags(fs)
+	jsonOut := fs.Bool("json", false, "print the role members as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, _, contract, err := t.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	roles := make([]roleMembers, len(timelock.Roles))
+	for i, role := range timelock.Roles {
+		members, err := timelock.RoleMembers(&bind.CallOpts{Context: ctx}, &contract.RBACTimelockCaller, role)
+		if err != nil {
+			return fmt.Errorf("%s: %w", role.Name, err)
+		}
+		roles[i] = roleMembers{Role: role.Name, ID: role.ID, Members: members}
+	}
+	if *jsonOut {
+		return cli.PrintJSON(roles)
+	}
+	tw := cli.NewTable(os.Stdout)
+	for _, r := range roles {
+		if len(r.Members) == 0 {
+			fmt.Fprintf(tw, "%s\t(none)\n", r.Role)
+		}
+		for _, m := range r.Members {
+			fmt.Fprintf(tw, "%s\t%s\n", r.Role, m)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,104 @@
+// Command timelock inspects and operates RBACTimelock instances.
+//
+// Usage:
+//
+//	timelock <command> [flags]
+//
+// Run "timelock help" for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/manifest"
+)
+
+var commands []cli.Command
+
+func init() {
+	commands = []cli.Command{
+		{Name: "operations", Summary: "list scheduled operations and their status", Run: runOperations},
+		{Name: "min-delay", Summary: "show the minimum delay", Run: runMinDelay},
+		{Name: "blocked-selectors", Summary: "list the function selectors that may not be scheduled", Run: runBlockedSelectors},
+		{Name: "roles", Summary: "list the members of every role", Run: runRoles},
+		{Name: "operation-id", Summary: "compute the id of the operation in a batch file", Run: runOperationID},
+		{Name: "execute", Summary: "execute a ready batch, directly or through a CallProxy", Run: runExecute},
+		{Name: "ownership-plan", Summary: "plan the Ownable2Step calls that move contracts to or from the timelock", Run: runOwnershipPlan},
+		{Name: "ownership-status", Summary: "list ownership events and warn about stuck transfers", Run: runOwnershipStatus},
+	}
+}
+
+func main() {
+	cli.Main("timelock", commands)
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return cli.NewFlagSet("timelock", name)
+}
+
+// target holds the -rpc, -timelock and -manifest flags shared by the
+// commands that read from a deployed RBACTimelock.
+type target struct {
+	rpc      string
+	timelock string
+	manifest string
+}
+
+func addTargetFlags(fs *flag.FlagSet) *target {
+	t := &target{}
+	fs.StringVar(&t.rpc, "rpc", "", "RPC endpoint of the chain")
+	fs.StringVar(&t.timelock, "timelock", "", "address of the RBACTimelock")
+	fs.StringVar(&t.manifest, "manifest", "", "deployment manifest to look the RBACTimelock up in when -timelock is not given")
+	return t
+}
+
+// dial connects to the chain and binds the RBACTimelock.
+func (t *target) dial(ctx context.Context) (*ethclient.Client, common.Address, *gethwrappers.RBACTimelock, error) {
+	if t.rpc == "" {
+		return nil, common.Address{}, nil, errors.New("-rpc is required")
+	}
+	if t.timelock == "" && t.manifest == "" {
+		return nil, common.Address{}, nil, errors.New("one of -timelock and -manifest is required")
+	}
+	if t.timelock != "" && !common.IsHexAddress(t.timelock) {
+		return nil, common.Address{}, nil, errors.New("-timelock must be a valid address")
+	}
+	client, err := ethclient.DialContext(ctx, t.rpc)
+	if err != nil {
+		return nil, common.Address{}, nil, err
+	}
+	addr := common.HexToAddress(t.timelock)
+	if t.timelock == "" {
+		if addr, err = t.lookup(ctx, client); err != nil {
+			client.Close()
+			return nil, common.Address{}, nil, err
+		}
+	}
+	contract, err := gethwrappers.NewRBACTimelock(addr, client)
+	if err != nil {
+		client.Close()
+		return nil, common.Address{}, nil, err
+	}
+	return client, addr, contract, nil
+}
+
+// lookup returns the RBACTimelock of the chain served by client from the
+// manifest.
+func (t *target) lookup(ctx context.Context, client *ethclient.Client) (common.Address, error) {
+	m, err := manifest.Load(t.manifest)
+	if err != nil {
+		return common.Address{}, err
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return m.Address(chainID, manifest.RoleTimelock)
+}
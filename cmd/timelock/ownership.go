@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/cli"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/manifest"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/ownership"
+)
+
+func runOwnershipPlan(ctx context.Context, args []string) error {
+	fs := newFlagSet("ownership-plan")
+	t := addTargetFlags(fs)
+	var contracts cli.AddressesFlag
+	fs.Var(&contracts, "contract", "Ownable2Step contract to transfer, repeatable")
+	newOwner := fs.String("new-owner", "", "new owner (default: the timelock)")
+	salt := fs.String("salt", "", "salt of the timelock batch (default zero)")
+	delay := fs.Int64("delay", -1, "delay in seconds to schedule the batch with (default: the minimum delay)")
+	batchOut := fs.String("batch-out", "", "write the calls the timelock has to make to this batch file")
+	opsOut := fs.String("ops-out", "", "write the ManyChainMultiSig op scheduling the batch to this file, for the ops of a proposal spec")
+	jsonOut := fs.Bool("json", false, "print the plans as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timelock ownership-plan -rpc URL (-timelock ADDR | -manifest FILE) -contract ADDR... [flags]")
+		fmt.Fprintln(fs.Output(), "Plans the transferOwnership and acceptOwnership calls that remain to hand the contracts to the new owner.")
+		fmt.Fprintln(fs.Output(), "Calls the timelock has to make are collected into one batch to be scheduled through the proposer.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(contracts) == 0 {
+		return errors.New("-contract is required")
+	}
+	if *newOwner != "" && !common.IsHexAddress(*newOwner) {
+		return errors.New("-new-owner must be a valid address")
+	}
+	var saltHash common.Hash
+	if *salt != "" {
+		decoded, err := hexutil.Decode(*salt)
+		if err != nil || len(decoded) != common.HashLength {
+			return errors.New("-salt must be 32 bytes of hex")
+		}
+		saltHash = common.BytesToHash(decoded)
+	}
+	client, addr, contract, err := t.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	to := addr
+	if *newOwner != "" {
+		to = common.HexToAddress(*newOwner)
+	}
+
+	var plans []*ownership.Plan
+	for _, c := range contracts {
+		plan, err := ownership.PlanTransfer(ctx, client, c, to, addr)
+		if err != nil {
+			return err
+		}
+		plans = append(plans, plan)
+	}
+	b := ownership.Batch(plans, common.Hash{}, saltHash)
+	if b != nil && *batchOut != "" {
+		if err := cli.WriteJSONFile(*batchOut, b); err != nil {
+			return err
+		}
+	}
+	if b != nil && *opsOut != "" {
+		scheduleDelay := big.NewInt(*delay)
+		if *delay < 0 {
+			if scheduleDelay, err = contract.GetMinDelay(&bind.CallOpts{Context: ctx}); err != nil {
+				return fmt.Errorf("getMinDelay: %w", err)
+			}
+		}
+		op, err := ownership.ScheduleOp(addr, b, scheduleDelay)
+		if err != nil {
+			return err
+		}
+		if op.ChainID, err = client.ChainID(ctx); err != nil {
+			return err
+		}
+		if t.manifest != "" {
+			m, err := manifest.Load(t.manifest)
+			if err != nil {
+				return err
+			}
+			if op.MultiSig, err = m.Address(op.ChainID, manifest.RoleProposer); err != nil {
+				return err
+			}
+		}
+		if err := cli.WriteJSONFile(*opsOut, []mcms.Operation{op}); err != nil {
+			return err
+		}
+	}
+
+	if *jsonOut {
+		return cli.PrintJSON(plans)
+	}
+	for _, p := range plans {
+		fmt.Printf("%s  owner %s", p.Contract, p.Owner)
+		if p.PendingOwner != (common.Address{}) {
+			fmt.Printf(", pending owner %s", p.PendingOwner)
+		}
+		fmt.Println()
+		if len(p.Steps) == 0 {
+			fmt.Printf("  already owned by %s\n", p.NewOwner)
+		}
+		for i, s := range p.Steps {
+			how := "send from " + s.Sender.Hex()
+			if s.ViaTimelock {
+				how = "schedule on the timelock"
+			}
+			fmt.Printf("  %d: %s: %s\n", i+1, how, mcms.DescribeCall(s.Call.Data))
+		}
+	}
+	switch {
+	case b == nil:
+	case *batchOut == "" && *opsOut == "":
+		fmt.Println("\nthe timelock has calls to make; write them out with -batch-out or -ops-out")
+	case *opsOut != "" && t.manifest == "":
+		fmt.Printf("\nwrote %s; fill in the multiSig of the op with the proposer\n", *opsOut)
+	}
+	return nil
+}
+
+func runOwnershipStatus(ctx context.Context, args []string) error {
+	fs := newFlagSet("ownership-status")
+	rpcURL := fs.String("rpc", "", "RPC endpoint of the chain")
+	manifestPath := fs.String("manifest", "", "deployment manifest; its multisigs are checked when no -contract is given")
+	var contracts cli.AddressesFlag
+	fs.Var(&contracts, "contract", "Ownable2Step contract to check, repeatable")
+	fromBlock := fs.Uint64("from-block", 0, "first block to scan for ownership events")
+	maxAge := fs.Duration("max-age", 24*time.Hour, "warn about transfers pending for longer than this")
+	jsonOut := fs.Bool("json", false, "print the events and stuck transfers as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timelock ownership-status -rpc URL (-contract ADDR... | -manifest FILE) [flags]")
+		fmt.Fprintln(fs.Output(), "Lists ownership events and exits with status 1 if a transfer has been pending for longer than -max-age.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := cli.RequireFlags(fs, "rpc"); err != nil {
+		return err
+	}
+	client, err := ethclient.DialContext(ctx, *rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if len(contracts) == 0 && *manifestPath != "" {
+		chain, err := cli.ManifestChain(ctx, client, *manifestPath)
+		if err != nil {
+			return err
+		}
+		contracts = chain.Deployment().MultiSigs
+	}
+	if len(contracts) == 0 {
+		return errors.New("no contracts given")
+	}
+
+	events := make(map[common.Address][]ownership.Event)
+	for _, c := range contracts {
+		if events[c], err = ownership.FetchEvents(ctx, client, c, *fromBlock, 0); err != nil {
+			return err
+		}
+	}
+	stuck, err := ownership.FindStuck(ctx, client, contracts, *fromBlock, *maxAge)
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		if err := cli.PrintJSON(struct {
+			Events map[common.Address][]ownership.Event `json:"events"`
+			Stuck  []*ownership.Stuck                   `json:"stuck"`
+		}{events, stuck}); err != nil {
+			return err
+		}
+	} else {
+		tw := cli.NewTable(os.Stdout)
+		fmt.Fprintln(tw, "CONTRACT\tBLOCK\tEVENT\tFROM\tTO")
+		for _, c := range contracts {
+			for _, ev := range events[c] {
+				fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", c, ev.BlockNumber, ev.Kind, ev.PreviousOwner, ev.NewOwner)
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		for _, s := range stuck {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", s)
+		}
+	}
+	if len(stuck) > 0 {
+		return cli.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package gethwrappers
+
+// CustomError is implemented by the generated types of the contracts' custom
+// errors, see the *_errors.go files.
+type CustomError interface {
+	error
+	// ErrorName returns the Solidity name of the error.
+	ErrorName() string
+}
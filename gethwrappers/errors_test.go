@@ -0,0 +1,45 @@
+package gethwrappers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUnpackManyChainMultiSigError(t *testing.T) {
+	parsed, err := ManyChainMultiSigMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := parsed.Errors["CallReverted"].Inputs.Pack([]byte{0xde, 0xad})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unpacked := UnpackManyChainMultiSigError(append(ManyChainMultiSigCallRevertedSelector[:], data...))
+	callReverted, isCallReverted := unpacked.(*ManyChainMultiSigCallReverted)
+	if !isCallReverted || string(callReverted.ErrorArg) != "\xde\xad" {
+		t.Fatalf("got %#v", unpacked)
+	}
+	if got, want := unpacked.Error(), "CallReverted(error=0xdead)"; got != want {
+		t.Fatalf("Error() = %q, want %q", got, want)
+	}
+	if unpacked.ErrorName() != "CallReverted" {
+		t.Fatalf("ErrorName() = %q", unpacked.ErrorName())
+	}
+	if !errors.Is(unpacked, &ManyChainMultiSigCallReverted{}) || errors.Is(unpacked, &ManyChainMultiSigWrongNonce{}) {
+		t.Fatal("errors.Is must match the error type only")
+	}
+
+	unpacked = UnpackManyChainMultiSigError(ManyChainMultiSigWrongNonceSelector[:])
+	if _, isWrongNonce := unpacked.(*ManyChainMultiSigWrongNonce); !isWrongNonce {
+		t.Fatalf("got %#v", unpacked)
+	}
+
+	for _, data := range [][]byte{nil, {0x01, 0x02}, {0x08, 0xc3, 0x79, 0xa0}} {
+		if unpacked := UnpackManyChainMultiSigError(data); unpacked != nil {
+			t.Fatalf("%x decoded as %v", data, unpacked)
+		}
+	}
+	if UnpackRBACTimelockError(ManyChainMultiSigWrongNonceSelector[:]) != nil {
+		t.Fatal("RBACTimelock has no custom errors")
+	}
+}
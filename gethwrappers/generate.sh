@@ -20,6 +20,10 @@ abigen_owner_contracts() {
   --bin  <(jq --raw-output .bytecode.object ../out/"$1".sol/"$1".json) \
   --type "$1" \
   --out "$1".go
+  go run ./generrors \
+  --abi <(jq .abi ../out/"$1".sol/"$1".json) \
+  --type "$1" \
+  --out "$1"_errors.go
 }
 
 forge build
@@ -0,0 +1,213 @@
+// Command generrors generates Go bindings for the custom errors of a contract,
+// which abigen does not cover. For every error in the ABI it emits a type
+// implementing CustomError, the error's selector, and one function per
+// contract decoding revert data into those types. CustomError itself is
+// declared once in the package, outside the generated files.
+//
+// Usage:
+//
+//	go run ./generrors --abi FILE --type NAME --pkg PACKAGE --out FILE
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+type field struct {
+	Name    string // Go field name
+	ABIName string
+	Type    string // Go type
+	Verb    string // fmt verb used by Error
+}
+
+type customError struct {
+	Type     string // Go type name
+	Name     string // Solidity error name
+	Sig      string
+	Selector string // Go [4]byte literal
+	Fields   []field
+}
+
+type contract struct {
+	Package string
+	Type    string
+	Errors  []customError
+}
+
+const source = `// Code generated by generrors - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package {{.Package}}
+
+{{if .Errors}}import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = fmt.Sprintf
+	_ = big.NewInt
+	_ = abi.ConvertType
+	_ = common.Big1
+)
+{{end}}
+{{range .Errors}}
+// {{.Type}}Selector is the selector of {{.Sig}}.
+var {{.Type}}Selector = {{.Selector}}
+
+// {{.Type}} is the {{.Name}} custom error of {{$.Type}}.
+{{if .Fields}}type {{.Type}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}{{else}}type {{.Type}} struct{}{{end}}
+
+// ErrorName returns the Solidity name of the error, "{{.Name}}".
+func (e *{{.Type}}) ErrorName() string {
+	return "{{.Name}}"
+}
+
+// Is reports whether target is a {{.Type}}, whatever its fields, so that
+// errors.Is matches any {{.Name}} error.
+func (e *{{.Type}}) Is(target error) bool {
+	_, ok := target.(*{{.Type}})
+	return ok
+}
+
+// Error implements error.
+func (e *{{.Type}}) Error() string {
+{{- if .Fields}}
+	return fmt.Sprintf("{{.Name}}({{range $i, $f := .Fields}}{{if $i}}, {{end}}{{$f.ABIName}}={{$f.Verb}}{{end}})"{{range .Fields}}, e.{{.Name}}{{end}})
+{{- else}}
+	return "{{.Name}}()"
+{{- end}}
+}
+{{end}}
+// Unpack{{.Type}}Error decodes revert data returned by {{.Type}} into the
+// custom error it encodes, or returns nil if data does not encode one of
+// {{.Type}}'s custom errors.
+func Unpack{{.Type}}Error(data []byte) CustomError {
+{{- if .Errors}}
+	if len(data) < 4 {
+		return nil
+	}
+	var selector [4]byte
+	copy(selector[:], data)
+	switch selector {
+{{- range .Errors}}
+	case {{.Type}}Selector:
+{{- if .Fields}}
+		parsed, abiErr := {{$.Type}}MetaData.GetAbi()
+		if abiErr != nil {
+			return nil
+		}
+		values, unpackErr := parsed.Errors["{{.Name}}"].Inputs.Unpack(data[4:])
+		if unpackErr != nil {
+			return nil
+		}
+		return &{{.Type}}{
+{{- range $i, $f := .Fields}}
+			{{$f.Name}}: *abi.ConvertType(values[{{$i}}], new({{$f.Type}})).(*{{$f.Type}}),
+{{- end}}
+		}
+{{- else}}
+		return &{{.Type}}{}
+{{- end}}
+{{- end}}
+	}
+	return nil
+{{- else}}
+	return nil
+{{- end}}
+}
+`
+
+func main() {
+	abiPath := flag.String("abi", "", "contract ABI file")
+	typeName := flag.String("type", "", "contract name, prefixed to the generated identifiers")
+	pkg := flag.String("pkg", "gethwrappers", "package of the generated file")
+	out := flag.String("out", "", "output file")
+	flag.Parse()
+	if *abiPath == "" || *typeName == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	abiJSON, err := os.ReadFile(*abiPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	code, err := generate(*pkg, *typeName, string(abiJSON))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(pkg, typeName, abiJSON string) ([]byte, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	c := contract{Package: pkg, Type: typeName}
+	for _, e := range parsed.Errors {
+		ce := customError{
+			Type:     typeName + abi.ToCamelCase(e.Name),
+			Name:     e.Name,
+			Sig:      e.Sig,
+			Selector: fmt.Sprintf("[4]byte{%#02x, %#02x, %#02x, %#02x}", e.ID[0], e.ID[1], e.ID[2], e.ID[3]),
+		}
+		for i, input := range e.Inputs {
+			name := input.Name
+			if name == "" {
+				name = fmt.Sprintf("arg%d", i)
+			}
+			goName := abi.ToCamelCase(name)
+			// Error and ErrorName are methods of the error type.
+			if goName == "Error" || goName == "ErrorName" {
+				goName += "Arg"
+			}
+			verb := "%v"
+			if input.Type.T == abi.BytesTy || input.Type.T == abi.FixedBytesTy {
+				verb = "%#x"
+			}
+			ce.Fields = append(ce.Fields, field{Name: goName, ABIName: name, Type: bindType(input.Type), Verb: verb})
+		}
+		c.Errors = append(c.Errors, ce)
+	}
+	sort.Slice(c.Errors, func(i, j int) bool { return c.Errors[i].Name < c.Errors[j].Name })
+
+	var buf bytes.Buffer
+	if err := template.Must(template.New("errors").Parse(source)).Execute(&buf, c); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// bindType returns the Go type abigen uses for t. Errors with tuple
+// arguments are not supported.
+func bindType(t abi.Type) string {
+	if t.T == abi.TupleTy {
+		log.Fatalf("tuple error arguments are not supported")
+	}
+	if t.T == abi.BytesTy {
+		return "[]byte"
+	}
+	return t.GetType().String()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+)
+
+// TestGeneratedFilesUpToDate regenerates the checked-in error bindings from
+// the ABIs embedded in the abigen wrappers.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	for typeName, md := range map[string]*bind.MetaData{
+		"ManyChainMultiSig": gethwrappers.ManyChainMultiSigMetaData,
+		"RBACTimelock":      gethwrappers.RBACTimelockMetaData,
+		"CallProxy":         gethwrappers.CallProxyMetaData,
+	} {
+		want, err := generate("gethwrappers", typeName, md.ABI)
+		if err != nil {
+			t.Fatalf("%s: %v", typeName, err)
+		}
+		path := "../" + typeName + "_errors.go"
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date, run go generate", path)
+		}
+	}
+}
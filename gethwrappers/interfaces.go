@@ -0,0 +1,192 @@
+package gethwrappers
+
+// The interfaces in this file are maintained by hand; the bindings they
+// describe are generated by abigen, see generate.sh. They leave out the
+// receive function and the ERC721/ERC1155 receiver hooks, which tooling has
+// no reason to call.
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// ManyChainMultiSigCallerInterface covers the read-only methods of
+// ManyChainMultiSig, as implemented by ManyChainMultiSigCaller and
+// ManyChainMultiSig.
+type ManyChainMultiSigCallerInterface interface {
+	MAXNUMSIGNERS(opts *bind.CallOpts) (uint8, error)
+	NUMGROUPS(opts *bind.CallOpts) (uint8, error)
+	GetConfig(opts *bind.CallOpts) (ManyChainMultiSigConfig, error)
+	GetOpCount(opts *bind.CallOpts) (*big.Int, error)
+	GetRoot(opts *bind.CallOpts) (struct {
+		Root       [32]byte
+		ValidUntil uint32
+	}, error)
+	GetRootMetadata(opts *bind.CallOpts) (ManyChainMultiSigRootMetadata, error)
+	Owner(opts *bind.CallOpts) (common.Address, error)
+	PendingOwner(opts *bind.CallOpts) (common.Address, error)
+}
+
+// ManyChainMultiSigTransactorInterface covers the state-changing methods of
+// ManyChainMultiSig, as implemented by ManyChainMultiSigTransactor and
+// ManyChainMultiSig.
+type ManyChainMultiSigTransactorInterface interface {
+	AcceptOwnership(opts *bind.TransactOpts) (*types.Transaction, error)
+	Execute(opts *bind.TransactOpts, op ManyChainMultiSigOp, proof [][32]byte) (*types.Transaction, error)
+	RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error)
+	SetConfig(opts *bind.TransactOpts, signerAddresses []common.Address, signerGroups []uint8, groupQuorums [32]uint8, groupParents [32]uint8, clearRoot bool) (*types.Transaction, error)
+	SetRoot(opts *bind.TransactOpts, root [32]byte, validUntil uint32, metadata ManyChainMultiSigRootMetadata, metadataProof [][32]byte, signatures []ManyChainMultiSigSignature) (*types.Transaction, error)
+	TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error)
+}
+
+// ManyChainMultiSigFiltererInterface covers the log filtering, watching and
+// parsing methods of ManyChainMultiSig, as implemented by
+// ManyChainMultiSigFilterer and ManyChainMultiSig.
+type ManyChainMultiSigFiltererInterface interface {
+	FilterConfigSet(opts *bind.FilterOpts) (*ManyChainMultiSigConfigSetIterator, error)
+	WatchConfigSet(opts *bind.WatchOpts, sink chan<- *ManyChainMultiSigConfigSet) (event.Subscription, error)
+	ParseConfigSet(log types.Log) (*ManyChainMultiSigConfigSet, error)
+	FilterNewRoot(opts *bind.FilterOpts, root [][32]byte) (*ManyChainMultiSigNewRootIterator, error)
+	WatchNewRoot(opts *bind.WatchOpts, sink chan<- *ManyChainMultiSigNewRoot, root [][32]byte) (event.Subscription, error)
+	ParseNewRoot(log types.Log) (*ManyChainMultiSigNewRoot, error)
+	FilterOpExecuted(opts *bind.FilterOpts, nonce []*big.Int) (*ManyChainMultiSigOpExecutedIterator, error)
+	WatchOpExecuted(opts *bind.WatchOpts, sink chan<- *ManyChainMultiSigOpExecuted, nonce []*big.Int) (event.Subscription, error)
+	ParseOpExecuted(log types.Log) (*ManyChainMultiSigOpExecuted, error)
+	FilterOwnershipTransferStarted(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*ManyChainMultiSigOwnershipTransferStartedIterator, error)
+	WatchOwnershipTransferStarted(opts *bind.WatchOpts, sink chan<- *ManyChainMultiSigOwnershipTransferStarted, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error)
+	ParseOwnershipTransferStarted(log types.Log) (*ManyChainMultiSigOwnershipTransferStarted, error)
+	FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*ManyChainMultiSigOwnershipTransferredIterator, error)
+	WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *ManyChainMultiSigOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error)
+	ParseOwnershipTransferred(log types.Log) (*ManyChainMultiSigOwnershipTransferred, error)
+}
+
+// ManyChainMultiSigInterface is ManyChainMultiSig with its concrete binding
+// replaced by interfaces, so that code built on it can run against fakes.
+type ManyChainMultiSigInterface interface {
+	ManyChainMultiSigCallerInterface
+	ManyChainMultiSigTransactorInterface
+	ManyChainMultiSigFiltererInterface
+}
+
+// RBACTimelockCallerInterface covers the read-only methods of RBACTimelock, as
+// implemented by RBACTimelockCaller and RBACTimelock.
+type RBACTimelockCallerInterface interface {
+	ADMINROLE(opts *bind.CallOpts) ([32]byte, error)
+	BYPASSERROLE(opts *bind.CallOpts) ([32]byte, error)
+	CANCELLERROLE(opts *bind.CallOpts) ([32]byte, error)
+	DEFAULTADMINROLE(opts *bind.CallOpts) ([32]byte, error)
+	EXECUTORROLE(opts *bind.CallOpts) ([32]byte, error)
+	PROPOSERROLE(opts *bind.CallOpts) ([32]byte, error)
+	GetBlockedFunctionSelectorAt(opts *bind.CallOpts, index *big.Int) ([4]byte, error)
+	GetBlockedFunctionSelectorCount(opts *bind.CallOpts) (*big.Int, error)
+	GetMinDelay(opts *bind.CallOpts) (*big.Int, error)
+	GetRoleAdmin(opts *bind.CallOpts, role [32]byte) ([32]byte, error)
+	GetRoleMember(opts *bind.CallOpts, role [32]byte, index *big.Int) (common.Address, error)
+	GetRoleMemberCount(opts *bind.CallOpts, role [32]byte) (*big.Int, error)
+	GetTimestamp(opts *bind.CallOpts, id [32]byte) (*big.Int, error)
+	HasRole(opts *bind.CallOpts, role [32]byte, account common.Address) (bool, error)
+	HashOperationBatch(opts *bind.CallOpts, calls []RBACTimelockCall, predecessor [32]byte, salt [32]byte) ([32]byte, error)
+	IsOperation(opts *bind.CallOpts, id [32]byte) (bool, error)
+	IsOperationDone(opts *bind.CallOpts, id [32]byte) (bool, error)
+	IsOperationPending(opts *bind.CallOpts, id [32]byte) (bool, error)
+	IsOperationReady(opts *bind.CallOpts, id [32]byte) (bool, error)
+	SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error)
+}
+
+// RBACTimelockTransactorInterface covers the state-changing methods of
+// RBACTimelock, as implemented by RBACTimelockTransactor and RBACTimelock.
+type RBACTimelockTransactorInterface interface {
+	BlockFunctionSelector(opts *bind.TransactOpts, selector [4]byte) (*types.Transaction, error)
+	BypasserExecuteBatch(opts *bind.TransactOpts, calls []RBACTimelockCall) (*types.Transaction, error)
+	Cancel(opts *bind.TransactOpts, id [32]byte) (*types.Transaction, error)
+	ExecuteBatch(opts *bind.TransactOpts, calls []RBACTimelockCall, predecessor [32]byte, salt [32]byte) (*types.Transaction, error)
+	GrantRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error)
+	RenounceRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error)
+	RevokeRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error)
+	ScheduleBatch(opts *bind.TransactOpts, calls []RBACTimelockCall, predecessor [32]byte, salt [32]byte, delay *big.Int) (*types.Transaction, error)
+	UnblockFunctionSelector(opts *bind.TransactOpts, selector [4]byte) (*types.Transaction, error)
+	UpdateDelay(opts *bind.TransactOpts, newDelay *big.Int) (*types.Transaction, error)
+}
+
+// RBACTimelockFiltererInterface covers the log filtering, watching and parsing
+// methods of RBACTimelock, as implemented by RBACTimelockFilterer and
+// RBACTimelock.
+type RBACTimelockFiltererInterface interface {
+	FilterBypasserCallExecuted(opts *bind.FilterOpts, index []*big.Int) (*RBACTimelockBypasserCallExecutedIterator, error)
+	WatchBypasserCallExecuted(opts *bind.WatchOpts, sink chan<- *RBACTimelockBypasserCallExecuted, index []*big.Int) (event.Subscription, error)
+	ParseBypasserCallExecuted(log types.Log) (*RBACTimelockBypasserCallExecuted, error)
+	FilterCallExecuted(opts *bind.FilterOpts, id [][32]byte, index []*big.Int) (*RBACTimelockCallExecutedIterator, error)
+	WatchCallExecuted(opts *bind.WatchOpts, sink chan<- *RBACTimelockCallExecuted, id [][32]byte, index []*big.Int) (event.Subscription, error)
+	ParseCallExecuted(log types.Log) (*RBACTimelockCallExecuted, error)
+	FilterCallScheduled(opts *bind.FilterOpts, id [][32]byte, index []*big.Int) (*RBACTimelockCallScheduledIterator, error)
+	WatchCallScheduled(opts *bind.WatchOpts, sink chan<- *RBACTimelockCallScheduled, id [][32]byte, index []*big.Int) (event.Subscription, error)
+	ParseCallScheduled(log types.Log) (*RBACTimelockCallScheduled, error)
+	FilterCancelled(opts *bind.FilterOpts, id [][32]byte) (*RBACTimelockCancelledIterator, error)
+	WatchCancelled(opts *bind.WatchOpts, sink chan<- *RBACTimelockCancelled, id [][32]byte) (event.Subscription, error)
+	ParseCancelled(log types.Log) (*RBACTimelockCancelled, error)
+	FilterFunctionSelectorBlocked(opts *bind.FilterOpts, selector [][4]byte) (*RBACTimelockFunctionSelectorBlockedIterator, error)
+	WatchFunctionSelectorBlocked(opts *bind.WatchOpts, sink chan<- *RBACTimelockFunctionSelectorBlocked, selector [][4]byte) (event.Subscription, error)
+	ParseFunctionSelectorBlocked(log types.Log) (*RBACTimelockFunctionSelectorBlocked, error)
+	FilterFunctionSelectorUnblocked(opts *bind.FilterOpts, selector [][4]byte) (*RBACTimelockFunctionSelectorUnblockedIterator, error)
+	WatchFunctionSelectorUnblocked(opts *bind.WatchOpts, sink chan<- *RBACTimelockFunctionSelectorUnblocked, selector [][4]byte) (event.Subscription, error)
+	ParseFunctionSelectorUnblocked(log types.Log) (*RBACTimelockFunctionSelectorUnblocked, error)
+	FilterMinDelayChange(opts *bind.FilterOpts) (*RBACTimelockMinDelayChangeIterator, error)
+	WatchMinDelayChange(opts *bind.WatchOpts, sink chan<- *RBACTimelockMinDelayChange) (event.Subscription, error)
+	ParseMinDelayChange(log types.Log) (*RBACTimelockMinDelayChange, error)
+	FilterRoleAdminChanged(opts *bind.FilterOpts, role [][32]byte, previousAdminRole [][32]byte, newAdminRole [][32]byte) (*RBACTimelockRoleAdminChangedIterator, error)
+	WatchRoleAdminChanged(opts *bind.WatchOpts, sink chan<- *RBACTimelockRoleAdminChanged, role [][32]byte, previousAdminRole [][32]byte, newAdminRole [][32]byte) (event.Subscription, error)
+	ParseRoleAdminChanged(log types.Log) (*RBACTimelockRoleAdminChanged, error)
+	FilterRoleGranted(opts *bind.FilterOpts, role [][32]byte, account []common.Address, sender []common.Address) (*RBACTimelockRoleGrantedIterator, error)
+	WatchRoleGranted(opts *bind.WatchOpts, sink chan<- *RBACTimelockRoleGranted, role [][32]byte, account []common.Address, sender []common.Address) (event.Subscription, error)
+	ParseRoleGranted(log types.Log) (*RBACTimelockRoleGranted, error)
+	FilterRoleRevoked(opts *bind.FilterOpts, role [][32]byte, account []common.Address, sender []common.Address) (*RBACTimelockRoleRevokedIterator, error)
+	WatchRoleRevoked(opts *bind.WatchOpts, sink chan<- *RBACTimelockRoleRevoked, role [][32]byte, account []common.Address, sender []common.Address) (event.Subscription, error)
+	ParseRoleRevoked(log types.Log) (*RBACTimelockRoleRevoked, error)
+}
+
+// RBACTimelockInterface is RBACTimelock with its concrete binding
+// replaced by interfaces, so that code built on it can run against fakes.
+type RBACTimelockInterface interface {
+	RBACTimelockCallerInterface
+	RBACTimelockTransactorInterface
+	RBACTimelockFiltererInterface
+}
+
+// CallProxyTransactorInterface covers the state-changing methods of CallProxy,
+// as implemented by CallProxyTransactor and CallProxy.
+type CallProxyTransactorInterface interface {
+	Fallback(opts *bind.TransactOpts, calldata []byte) (*types.Transaction, error)
+}
+
+// CallProxyFiltererInterface covers the log filtering, watching and parsing
+// methods of CallProxy, as implemented by CallProxyFilterer and CallProxy.
+type CallProxyFiltererInterface interface {
+	FilterTargetSet(opts *bind.FilterOpts) (*CallProxyTargetSetIterator, error)
+	WatchTargetSet(opts *bind.WatchOpts, sink chan<- *CallProxyTargetSet) (event.Subscription, error)
+	ParseTargetSet(log types.Log) (*CallProxyTargetSet, error)
+}
+
+// CallProxyInterface is CallProxy with its concrete binding
+// replaced by interfaces, so that code built on it can run against fakes.
+type CallProxyInterface interface {
+	CallProxyTransactorInterface
+	CallProxyFiltererInterface
+}
+
+var (
+	_ ManyChainMultiSigCallerInterface     = (*ManyChainMultiSigCaller)(nil)
+	_ ManyChainMultiSigTransactorInterface = (*ManyChainMultiSigTransactor)(nil)
+	_ ManyChainMultiSigFiltererInterface   = (*ManyChainMultiSigFilterer)(nil)
+	_ ManyChainMultiSigInterface           = (*ManyChainMultiSig)(nil)
+	_ RBACTimelockCallerInterface          = (*RBACTimelockCaller)(nil)
+	_ RBACTimelockTransactorInterface      = (*RBACTimelockTransactor)(nil)
+	_ RBACTimelockFiltererInterface        = (*RBACTimelockFilterer)(nil)
+	_ RBACTimelockInterface                = (*RBACTimelock)(nil)
+	_ CallProxyTransactorInterface         = (*CallProxyTransactor)(nil)
+	_ CallProxyFiltererInterface           = (*CallProxyFilterer)(nil)
+	_ CallProxyInterface                   = (*CallProxy)(nil)
+)
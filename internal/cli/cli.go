@@ -0,0 +1,112 @@
+// Package cli holds the plumbing shared by the command-line tools in cmd/.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+)
+
+// Command is a subcommand of a tool.
+type Command struct {
+	Name    string
+	Summary string
+	Run     func(ctx context.Context, args []string) error
+}
+
+// ErrSilent is returned by commands that already reported their failure and
+// only need the tool to exit with status 1.
+var ErrSilent = errors.New("")
+
+// Main dispatches os.Args to commands and exits on failure.
+func Main(tool string, commands []Command) {
+	if len(os.Args) < 2 || os.Args[1] == "help" || os.Args[1] == "-h" || os.Args[1] == "--help" {
+		usage(os.Stdout, tool, commands)
+		return
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	for _, c := range commands {
+		if c.Name != os.Args[1] {
+			continue
+		}
+		if err := c.Run(ctx, os.Args[2:]); err != nil {
+			if err != ErrSilent && err != flag.ErrHelp {
+				fmt.Fprintf(os.Stderr, "%s %s: %v\n", tool, c.Name, err)
+			}
+			stop()
+			os.Exit(1)
+		}
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s: unknown command %q\n\n", tool, os.Args[1])
+	usage(os.Stderr, tool, commands)
+	stop()
+	os.Exit(2)
+}
+
+func usage(w io.Writer, tool string, commands []Command) {
+	fmt.Fprintf(w, "Usage: %s <command> [flags]\n\n", tool)
+	fmt.Fprintln(w, "Commands:")
+	tw := NewTable(w)
+	for _, c := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", c.Name, c.Summary)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\nRun \"%s <command> -h\" for the flags of a command.\n", tool)
+}
+
+// NewFlagSet returns the flag set of a subcommand.
+func NewFlagSet(tool, name string) *flag.FlagSet {
+	return flag.NewFlagSet(tool+" "+name, flag.ContinueOnError)
+}
+
+// RequireFlags returns an error naming the first of names left empty.
+func RequireFlags(fs *flag.FlagSet, names ...string) error {
+	for _, name := range names {
+		if fs.Lookup(name).Value.String() == "" {
+			return fmt.Errorf("-%s is required", name)
+		}
+	}
+	return nil
+}
+
+// NewTable returns a tabwriter configured for the tools' tabular output.
+func NewTable(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+}
+
+// PrintJSON writes v to stdout as indented JSON.
+func PrintJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// ReadJSONFile decodes the JSON file at path into v.
+func ReadJSONFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteJSONFile writes v to path as indented JSON.
+func WriteJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
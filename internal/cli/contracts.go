@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/inspect"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/manifest"
+)
+
+// ContractsUsage documents the flags added by AddContractFlags.
+const ContractsUsage = "(-manifest FILE | -deployment FILE | -multisig ADDR... -timelock ADDR... -call-proxy ADDR...)"
+
+// ContractFlags holds the flags selecting the contracts of a deployment.
+type ContractFlags struct {
+	deployment                        string
+	manifest                          string
+	multiSigs, timelocks, callProxies AddressesFlag
+}
+
+// AddContractFlags registers the -deployment, -manifest, -multisig,
+// -timelock and -call-proxy flags on fs.
+func AddContractFlags(fs *flag.FlagSet) *ContractFlags {
+	c := &ContractFlags{}
+	fs.StringVar(&c.deployment, "deployment", "", "deployment file listing the contracts")
+	fs.StringVar(&c.manifest, "manifest", "", "deployment manifest; its contracts on the chain are included")
+	fs.Var(&c.multiSigs, "multisig", "ManyChainMultiSig address, repeatable")
+	fs.Var(&c.timelocks, "timelock", "RBACTimelock address, repeatable")
+	fs.Var(&c.callProxies, "call-proxy", "CallProxy address, repeatable")
+	return c
+}
+
+// Resolve returns the union of the selected contracts on the chain served
+// by client.
+func (c *ContractFlags) Resolve(ctx context.Context, client *ethclient.Client) (*inspect.Deployment, error) {
+	d := &inspect.Deployment{MultiSigs: c.multiSigs, Timelocks: c.timelocks, CallProxies: c.callProxies}
+	add := func(other *inspect.Deployment) {
+		d.MultiSigs = append(d.MultiSigs, other.MultiSigs...)
+		d.Timelocks = append(d.Timelocks, other.Timelocks...)
+		d.CallProxies = append(d.CallProxies, other.CallProxies...)
+	}
+	if c.deployment != "" {
+		loaded, err := inspect.LoadDeployment(c.deployment)
+		if err != nil {
+			return nil, err
+		}
+		add(loaded)
+	}
+	if c.manifest != "" {
+		chain, err := ManifestChain(ctx, client, c.manifest)
+		if err != nil {
+			return nil, err
+		}
+		add(chain.Deployment())
+	}
+	if len(d.MultiSigs)+len(d.Timelocks)+len(d.CallProxies) == 0 {
+		return nil, errors.New("no contracts given")
+	}
+	return d, nil
+}
+
+// ManifestChain returns the deployment on the chain served by client from
+// the manifest at path.
+func ManifestChain(ctx context.Context, client *ethclient.Client, path string) (*manifest.Chain, error) {
+	m, err := manifest.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chain := m.Chain(chainID)
+	if chain == nil {
+		return nil, fmt.Errorf("%s has no chain %v", path, chainID)
+	}
+	return chain, nil
+}
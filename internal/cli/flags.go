@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AddressesFlag collects a repeated address flag.
+type AddressesFlag []common.Address
+
+func (f *AddressesFlag) String() string {
+	parts := make([]string, len(*f))
+	for i, addr := range *f {
+		parts[i] = addr.Hex()
+	}
+	return strings.Join(parts, ",")
+}
+
+func (f *AddressesFlag) Set(value string) error {
+	if !common.IsHexAddress(value) {
+		return fmt.Errorf("invalid address %q", value)
+	}
+	*f = append(*f, common.HexToAddress(value))
+	return nil
+}
+
+// StringsFlag collects a repeated string flag.
+type StringsFlag []string
+
+func (f *StringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *StringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
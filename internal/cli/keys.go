@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+)
+
+// passwordEnv is read when no password file is given.
+const passwordEnv = "KEYSTORE_PASSWORD"
+
+// SignerFlags select the Signer used to sign roots. Raw private keys are
+// deliberately not accepted.
+type SignerFlags struct {
+	keystore     string
+	passwordFile string
+	remote       string
+	address      string
+}
+
+// AddSignerFlags registers the signer flags on fs.
+func AddSignerFlags(fs *flag.FlagSet) *SignerFlags {
+	f := &SignerFlags{}
+	fs.StringVar(&f.keystore, "keystore", "", "encrypted keystore file of the signer")
+	fs.StringVar(&f.passwordFile, "password-file", "", "file holding the keystore password (default $"+passwordEnv+")")
+	fs.StringVar(&f.remote, "remote-signer", "", "URL of a clef compatible remote signer (instead of -keystore)")
+	fs.StringVar(&f.address, "address", "", "address to sign as with -remote-signer")
+	return f
+}
+
+// Signer returns the Signer selected by the flags.
+func (f *SignerFlags) Signer(ctx context.Context) (signer.Signer, error) {
+	switch {
+	case f.keystore != "" && f.remote != "":
+		return nil, errors.New("only one of -keystore and -remote-signer may be given")
+	case f.keystore != "":
+		password, err := ReadPassword(f.passwordFile)
+		if err != nil {
+			return nil, err
+		}
+		return signer.NewKeystoreSigner(f.keystore, password)
+	case f.remote != "":
+		if !common.IsHexAddress(f.address) {
+			return nil, errors.New("-remote-signer requires a valid -address")
+		}
+		return signer.DialRemoteSigner(ctx, f.remote, common.HexToAddress(f.address))
+	default:
+		return nil, errors.New("one of -keystore and -remote-signer is required")
+	}
+}
+
+// TransactorFlags select the account that sends transactions.
+type TransactorFlags struct {
+	keystore     string
+	passwordFile string
+	key          *keystore.Key
+}
+
+// AddTransactorFlags registers the transactor flags on fs.
+func AddTransactorFlags(fs *flag.FlagSet) *TransactorFlags {
+	f := &TransactorFlags{}
+	fs.StringVar(&f.keystore, "tx-keystore", "", "encrypted keystore file of the account sending transactions")
+	fs.StringVar(&f.passwordFile, "tx-password-file", "", "file holding the -tx-keystore password (default $"+passwordEnv+")")
+	return f
+}
+
+// Opts returns transact options for chainID. The keystore is decrypted once.
+func (f *TransactorFlags) Opts(ctx context.Context, chainID *big.Int) (*bind.TransactOpts, error) {
+	if f.key == nil {
+		if f.keystore == "" {
+			return nil, errors.New("-tx-keystore is required")
+		}
+		password, err := ReadPassword(f.passwordFile)
+		if err != nil {
+			return nil, err
+		}
+		keyJSON, err := os.ReadFile(f.keystore)
+		if err != nil {
+			return nil, err
+		}
+		if f.key, err = keystore.DecryptKey(keyJSON, password); err != nil {
+			return nil, err
+		}
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(f.key.PrivateKey, chainID)
+	if err != nil {
+		return nil, err
+	}
+	opts.Context = ctx
+	return opts, nil
+}
+
+// ReadPassword reads a keystore password from path, or from $KEYSTORE_PASSWORD
+// when path is empty.
+func ReadPassword(path string) (string, error) {
+	if path == "" {
+		password, ok := os.LookupEnv(passwordEnv)
+		if !ok {
+			return "", errors.New("no password file given and $" + passwordEnv + " is not set")
+		}
+		return password, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// RPCFlag collects repeated -rpc CHAINID=URL flags.
+type RPCFlag map[string]string
+
+func (f RPCFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for chainID, url := range f {
+		pairs = append(pairs, chainID+"="+url)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (f RPCFlag) Set(value string) error {
+	chainID, url, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("expected CHAINID=URL, got %q", value)
+	}
+	id, ok := new(big.Int).SetString(chainID, 10)
+	if !ok {
+		return fmt.Errorf("invalid chain id %q", chainID)
+	}
+	f[id.String()] = url
+	return nil
+}
+
+// Clients dials and caches one client per chain id. It is safe for
+// concurrent use; a slow endpoint does not hold up the others.
+type Clients struct {
+	urls RPCFlag
+
+	mu     sync.Mutex
+	dialed map[string]*ethclient.Client
+}
+
+// NewClients returns a Clients dialing the endpoints of urls.
+func NewClients(urls RPCFlag) *Clients {
+	return &Clients{urls: urls, dialed: make(map[string]*ethclient.Client)}
+}
+
+// Get returns a client for chainID, checking that the endpoint actually
+// serves that chain.
+func (c *Clients) Get(ctx context.Context, chainID *big.Int) (*ethclient.Client, error) {
+	key := chainID.String()
+	c.mu.Lock()
+	client, ok := c.dialed[key]
+	c.mu.Unlock()
+	if ok {
+		return client, nil
+	}
+	url, ok := c.urls[key]
+	if !ok {
+		return nil, fmt.Errorf("no -rpc given for chain %s", key)
+	}
+	client, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	served, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	if served.Cmp(chainID) != 0 {
+		client.Close()
+		return nil, fmt.Errorf("RPC endpoint for chain %s serves chain %v", key, served)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if dialed, ok := c.dialed[key]; ok {
+		// Dialed concurrently by another caller.
+		client.Close()
+		return dialed, nil
+	}
+	c.dialed[key] = client
+	return client, nil
+}
+
+// Close closes every dialed client.
+func (c *Clients) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, client := range c.dialed {
+		client.Close()
+	}
+}
@@ -0,0 +1,282 @@
+// Package harness stands up the owner-contract stack on go-ethereum's
+// simulated backend so the Go tooling can be tested end to end without a
+// node. It deploys the topology described in the README: proposer, canceller
+// and bypasser ManyChainMultiSigs, an RBACTimelock that administers itself
+// and owns the multisigs, and a CallProxy holding the EXECUTOR_ROLE.
+package harness
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+// ChainID is the chain id of the simulated backend.
+var ChainID = big.NewInt(1337)
+
+const gasLimit = 30_000_000
+
+// Options configure the stack. Zero values select the defaults.
+type Options struct {
+	// MinDelay is the timelock's minimum delay. Defaults to 24 hours.
+	MinDelay time.Duration
+	// Signers is the number of signers of each multisig. Defaults to 3.
+	Signers int
+	// Quorum is the root group quorum of each multisig. Defaults to 2.
+	Quorum int
+}
+
+// Account is a funded EOA.
+type Account struct {
+	Key     *ecdsa.PrivateKey
+	Address common.Address
+	Opts    *bind.TransactOpts
+	Signer  signer.Signer
+}
+
+// MultiSig is a deployed ManyChainMultiSig and its signers, sorted by
+// address.
+type MultiSig struct {
+	Address  common.Address
+	Contract *gethwrappers.ManyChainMultiSig
+	Signers  []*Account
+	Config   gethwrappers.ManyChainMultiSigConfig
+}
+
+// Env is a deployed stack.
+type Env struct {
+	t       testing.TB
+	Backend *backends.SimulatedBackend
+
+	// Deployer deployed the stack. It holds no role once New returns.
+	// The stack fields are unset for an Env returned by NewBare.
+	Deployer *Account
+
+	Proposer  *MultiSig
+	Canceller *MultiSig
+	Bypasser  *MultiSig
+
+	TimelockAddress common.Address
+	Timelock        *gethwrappers.RBACTimelock
+	MinDelay        *big.Int
+
+	CallProxyAddress common.Address
+	CallProxy        *gethwrappers.CallProxy
+}
+
+// New deploys the stack. The backend is closed when the test finishes.
+func New(t testing.TB, opts Options) *Env {
+	t.Helper()
+	if opts.MinDelay == 0 {
+		opts.MinDelay = 24 * time.Hour
+	}
+	if opts.Signers == 0 {
+		opts.Signers = 3
+	}
+	if opts.Quorum == 0 {
+		opts.Quorum = 2
+	}
+
+	e := NewBare(t)
+	deployer := e.Deployer
+	e.MinDelay = big.NewInt(int64(opts.MinDelay / time.Second))
+
+	e.Proposer = e.DeployMultiSig(opts.Signers, opts.Quorum)
+	e.Canceller = e.DeployMultiSig(opts.Signers, opts.Quorum)
+	e.Bypasser = e.DeployMultiSig(opts.Signers, opts.Quorum)
+
+	var err error
+	e.TimelockAddress, _, e.Timelock, err = gethwrappers.DeployRBACTimelock(deployer.Opts, e.Backend, e.MinDelay, deployer.Address,
+		[]common.Address{e.Proposer.Address},
+		nil,
+		[]common.Address{e.Proposer.Address, e.Canceller.Address},
+		[]common.Address{e.Bypasser.Address, deployer.Address},
+	)
+	e.check(err)
+	e.CallProxyAddress, _, e.CallProxy, err = gethwrappers.DeployCallProxy(deployer.Opts, e.Backend, e.TimelockAddress)
+	e.check(err)
+	e.Commit()
+	e.Send(e.Timelock.GrantRole(deployer.Opts, timelock.ExecutorRole.ID, e.CallProxyAddress))
+
+	// Ownable2Step: the timelock accepts ownership of the multisigs through
+	// the deployer's temporary bypasser role.
+	var accept []gethwrappers.RBACTimelockCall
+	acceptData, err := mcms.ManyChainMultiSigABI.Pack("acceptOwnership")
+	e.check(err)
+	for _, ms := range []*MultiSig{e.Proposer, e.Canceller, e.Bypasser} {
+		e.Send(ms.Contract.TransferOwnership(deployer.Opts, e.TimelockAddress))
+		accept = append(accept, gethwrappers.RBACTimelockCall{Target: ms.Address, Value: new(big.Int), Data: acceptData})
+	}
+	e.Send(e.Timelock.BypasserExecuteBatch(deployer.Opts, accept))
+
+	e.Send(e.Timelock.GrantRole(deployer.Opts, timelock.AdminRole.ID, e.TimelockAddress))
+	e.Send(e.Timelock.RevokeRole(deployer.Opts, timelock.BypasserRole.ID, deployer.Address))
+	e.Send(e.Timelock.RenounceRole(deployer.Opts, timelock.AdminRole.ID, deployer.Address))
+	return e
+}
+
+// NewBare returns an Env with a funded deployer and nothing deployed, for
+// tests that deploy only the contracts they need. The backend is closed when
+// the test finishes.
+func NewBare(t testing.TB) *Env {
+	t.Helper()
+	deployer := newAccount(t)
+	e := &Env{
+		t:        t,
+		Backend:  backends.NewSimulatedBackend(core.GenesisAlloc{deployer.Address: {Balance: ether(1_000_000)}}, gasLimit),
+		Deployer: deployer,
+	}
+	t.Cleanup(func() { e.Backend.Close() })
+	return e
+}
+
+// DeployMultiSig deploys a ManyChainMultiSig owned by the deployer, with
+// numSigners generated signers in a single root group of the given quorum.
+func (e *Env) DeployMultiSig(numSigners, quorum int) *MultiSig {
+	e.t.Helper()
+	addr, _, contract, err := gethwrappers.DeployManyChainMultiSig(e.Deployer.Opts, e.Backend)
+	e.check(err)
+	e.Commit()
+	ms := &MultiSig{Address: addr, Contract: contract, Signers: GenerateSigners(e.t, numSigners)}
+	groups := make([]uint8, numSigners)
+	var quorums, parents [mcms.NumGroups]uint8
+	quorums[0] = uint8(quorum)
+	e.Send(contract.SetConfig(e.Deployer.Opts, SignerAddresses(ms.Signers), groups, quorums, parents, false))
+	ms.Config, err = contract.GetConfig(&bind.CallOpts{})
+	e.check(err)
+	return ms
+}
+
+// GenerateSigners returns n new accounts sorted by address, the order
+// setConfig requires. They are not funded.
+func GenerateSigners(t testing.TB, n int) []*Account {
+	t.Helper()
+	signers := make([]*Account, n)
+	for i := range signers {
+		signers[i] = newAccount(t)
+	}
+	sort.Slice(signers, func(i, j int) bool {
+		return bytes.Compare(signers[i].Address[:], signers[j].Address[:]) < 0
+	})
+	return signers
+}
+
+// SignerAddresses returns the addresses of accounts.
+func SignerAddresses(accounts []*Account) []common.Address {
+	addrs := make([]common.Address, len(accounts))
+	for i, a := range accounts {
+		addrs[i] = a.Address
+	}
+	return addrs
+}
+
+// NewAccount returns an account funded with 100 ether by the deployer.
+func (e *Env) NewAccount() *Account {
+	e.t.Helper()
+	a := newAccount(e.t)
+	ctx := context.Background()
+	nonce, err := e.Backend.PendingNonceAt(ctx, e.Deployer.Address)
+	e.check(err)
+	gasPrice, err := e.Backend.SuggestGasPrice(ctx)
+	e.check(err)
+	tx, err := types.SignTx(types.NewTransaction(nonce, a.Address, ether(100), 21_000, gasPrice, nil),
+		types.LatestSignerForChainID(ChainID), e.Deployer.Key)
+	e.check(err)
+	e.check(e.Backend.SendTransaction(ctx, tx))
+	e.Commit()
+	return a
+}
+
+func newAccount(t testing.TB) *Account {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, ChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Account{Key: key, Address: opts.From, Opts: opts, Signer: signer.NewKeySigner(key)}
+}
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+// Commit mines a block.
+func (e *Env) Commit() {
+	e.Backend.Commit()
+}
+
+// AdvanceTime moves the clock forward by d and mines a block.
+func (e *Env) AdvanceTime(d time.Duration) {
+	e.t.Helper()
+	e.check(e.Backend.AdjustTime(d))
+	e.Commit()
+}
+
+// AutoMiningBackend is a simulated backend that mines every transaction as
+// soon as it is sent, for code under test that waits for receipts.
+type AutoMiningBackend struct {
+	*backends.SimulatedBackend
+}
+
+// SendTransaction sends tx and mines a block.
+func (b AutoMiningBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := b.SimulatedBackend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	b.Commit()
+	return nil
+}
+
+// AutoMining returns e.Backend as an AutoMiningBackend.
+func (e *Env) AutoMining() AutoMiningBackend {
+	return AutoMiningBackend{e.Backend}
+}
+
+// Now returns the timestamp of the latest block.
+func (e *Env) Now() time.Time {
+	e.t.Helper()
+	head, err := e.Backend.HeaderByNumber(context.Background(), nil)
+	e.check(err)
+	return time.Unix(int64(head.Time), 0)
+}
+
+// Send mines tx, failing the test if sending failed or tx reverted. It is
+// meant to wrap transactor calls: e.Send(contract.Method(opts, ...)).
+func (e *Env) Send(tx *types.Transaction, err error) *types.Receipt {
+	e.t.Helper()
+	e.check(err)
+	e.Commit()
+	receipt, err := e.Backend.TransactionReceipt(context.Background(), tx.Hash())
+	e.check(err)
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		e.t.Fatalf("transaction %s reverted", tx.Hash())
+	}
+	return receipt
+}
+
+func (e *Env) check(err error) {
+	e.t.Helper()
+	if err != nil {
+		e.t.Fatal(err)
+	}
+}
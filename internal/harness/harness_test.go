@@ -0,0 +1,73 @@
+package harness
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+func TestStack(t *testing.T) {
+	e := New(t, Options{MinDelay: time.Hour})
+	opts := &bind.CallOpts{}
+
+	for _, ms := range []*MultiSig{e.Proposer, e.Canceller, e.Bypasser} {
+		owner, err := ms.Contract.Owner(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if owner != e.TimelockAddress {
+			t.Fatalf("multisig %s owned by %s", ms.Address, owner)
+		}
+	}
+	wantRoles := map[timelock.Role][]common.Address{
+		timelock.AdminRole:     {e.TimelockAddress},
+		timelock.ProposerRole:  {e.Proposer.Address},
+		timelock.ExecutorRole:  {e.CallProxyAddress},
+		timelock.CancellerRole: {e.Proposer.Address, e.Canceller.Address},
+		timelock.BypasserRole:  {e.Bypasser.Address},
+	}
+	for role, want := range wantRoles {
+		got, err := timelock.RoleMembers(opts, &e.Timelock.RBACTimelockCaller, role)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s members = %v, want %v", role.Name, got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("%s members = %v, want %v", role.Name, got, want)
+			}
+		}
+	}
+
+	// Propose-and-execute: the timelock administers itself.
+	batch := &timelock.Batch{Calls: []timelock.Call{{
+		Target: e.TimelockAddress,
+		Data:   e.TimelockOp("updateDelay", big.NewInt(7200)).Data,
+	}}}
+	e.Submit(e.NewProposal(e.Proposer, e.ScheduleOp(batch)))
+	ctx := context.Background()
+	if _, err := timelock.CheckExecutable(ctx, e.Backend, e.TimelockAddress, batch); err == nil {
+		t.Fatal("operation executable before its delay passed")
+	}
+	e.AdvanceTime(time.Hour)
+	if _, err := timelock.CheckExecutable(ctx, e.Backend, e.TimelockAddress, batch); err != nil {
+		t.Fatal(err)
+	}
+	anyone := e.NewAccount()
+	e.Send(timelock.ExecuteBatch(anyone.Opts, e.Backend, e.CallProxyAddress, batch))
+	delay, err := e.Timelock.GetMinDelay(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delay.Int64() != 7200 {
+		t.Fatalf("minDelay = %v, want 7200", delay)
+	}
+}
@@ -0,0 +1,92 @@
+package harness
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+// TimelockOp returns an op calling method on the timelock.
+func (e *Env) TimelockOp(method string, args ...interface{}) mcms.Operation {
+	e.t.Helper()
+	data, err := timelock.RBACTimelockABI.Pack(method, args...)
+	e.check(err)
+	return mcms.Operation{To: e.TimelockAddress, Data: data}
+}
+
+// ScheduleOp returns an op scheduling b on the timelock with the minimum
+// delay.
+func (e *Env) ScheduleOp(b *timelock.Batch) mcms.Operation {
+	e.t.Helper()
+	return e.TimelockOp("scheduleBatch", b.RBACTimelockCalls(), b.Predecessor, b.Salt, e.MinDelay)
+}
+
+// NewProposal returns a proposal of ops on ms, starting at its current op
+// count and valid for a day, signed by as many of its signers as the root
+// group quorum needs. ChainID and MultiSig of ops are filled in.
+func (e *Env) NewProposal(ms *MultiSig, ops ...mcms.Operation) *mcms.Proposal {
+	e.t.Helper()
+	ctx := context.Background()
+	opCount, err := ms.Contract.GetOpCount(&bind.CallOpts{Context: ctx})
+	e.check(err)
+	p := &mcms.Proposal{
+		ValidUntil: uint32(e.Now().Add(24 * time.Hour).Unix()),
+		Chains:     []mcms.ChainMetadata{{ChainID: ChainID, MultiSig: ms.Address, PreOpCount: opCount.Uint64()}},
+	}
+	for _, op := range ops {
+		op.ChainID, op.MultiSig = ChainID, ms.Address
+		p.Ops = append(p.Ops, op)
+	}
+	root, err := p.Root()
+	e.check(err)
+	for _, s := range ms.Signers[:ms.Config.GroupQuorums[0]] {
+		sig, err := signer.SignRoot(ctx, s.Signer, root, p.ValidUntil)
+		e.check(err)
+		_, err = p.AddSignature(signer.FromGethSignature(sig))
+		e.check(err)
+	}
+	return p
+}
+
+// SetRoot calls setRoot for the only chain of p.
+func (e *Env) SetRoot(p *mcms.Proposal) {
+	e.t.Helper()
+	args, err := p.SetRootArgs(0)
+	e.check(err)
+	e.Send(e.multiSig(p.Chains[0].MultiSig).SetRoot(e.Deployer.Opts, args.Root, args.ValidUntil, args.Metadata, args.MetadataProof, args.Signatures))
+}
+
+// Execute executes every op of p, in nonce order.
+func (e *Env) Execute(p *mcms.Proposal) {
+	e.t.Helper()
+	executeArgs, err := p.ExecuteArgs(0)
+	e.check(err)
+	contract := e.multiSig(p.Chains[0].MultiSig)
+	for _, args := range executeArgs {
+		e.Send(contract.Execute(e.Deployer.Opts, args.Op, args.Proof))
+	}
+}
+
+// Submit calls setRoot, then executes every op of p.
+func (e *Env) Submit(p *mcms.Proposal) {
+	e.t.Helper()
+	e.SetRoot(p)
+	e.Execute(p)
+}
+
+func (e *Env) multiSig(addr common.Address) *gethwrappers.ManyChainMultiSig {
+	for _, ms := range []*MultiSig{e.Proposer, e.Canceller, e.Bypasser} {
+		if ms.Address == addr {
+			return ms.Contract
+		}
+	}
+	e.t.Fatalf("no multisig at %s", addr)
+	return nil
+}
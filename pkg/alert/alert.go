@@ -0,0 +1,190 @@
+// Package alert delivers the alerts raised by the monitors to stdout, files
+// and webhooks.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Severity ranks alerts.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Alert is something a monitor wants a human to look at.
+type Alert struct {
+	Time     time.Time `json:"time"`
+	Severity Severity  `json:"severity"`
+	// Kind identifies the check that raised the alert, e.g. "unknown-root".
+	Kind        string         `json:"kind"`
+	ChainID     *big.Int       `json:"chainId,omitempty"`
+	Contract    common.Address `json:"contract"`
+	BlockNumber uint64         `json:"blockNumber,omitempty"`
+	TxHash      common.Hash    `json:"txHash,omitempty"`
+	Message     string         `json:"message"`
+	// Details holds check specific data, e.g. the decoded event.
+	Details interface{} `json:"details,omitempty"`
+}
+
+func (a *Alert) String() string {
+	return fmt.Sprintf("[%s] %s: chain %v %s: %s", a.Severity, a.Kind, a.ChainID, a.Contract, a.Message)
+}
+
+// MarshalJSON adds a "text" field holding String(), so that alerts can be
+// posted as they are to Slack-compatible incoming webhooks.
+func (a *Alert) MarshalJSON() ([]byte, error) {
+	type plain Alert
+	return json.Marshal(struct {
+		Text string `json:"text"`
+		*plain
+	}{a.String(), (*plain)(a)})
+}
+
+// Sink delivers alerts.
+type Sink interface {
+	Send(ctx context.Context, a *Alert) error
+}
+
+// Writer writes alerts as text lines, e.g. to stdout.
+type Writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriter returns a sink writing to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (s *Writer) Send(_ context.Context, a *Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := fmt.Fprintf(s.w, "%s %s\n", a.Time.UTC().Format(time.RFC3339), a)
+	return err
+}
+
+// File appends alerts to a file as JSON lines.
+type File struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFile returns a sink appending to the file at path.
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+func (s *File) Send(_ context.Context, a *Alert) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Webhook POSTs alerts as JSON to a URL.
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhook returns a sink posting to url with a 10 second timeout.
+func NewWebhook(url string) *Webhook {
+	return &Webhook{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *Webhook) Send(ctx context.Context, a *Alert) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook %s: %s", s.URL, resp.Status)
+	}
+	return nil
+}
+
+// Multi sends every alert to all of its sinks, even if some fail. If only
+// some of them fail, the error is a *PartialError.
+type Multi []Sink
+
+func (m Multi) Send(ctx context.Context, a *Alert) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Send(ctx, a); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 && len(errs) < len(m) {
+		return &PartialError{Err: errors.Join(errs...)}
+	}
+	return errors.Join(errs...)
+}
+
+// PartialError is returned by Multi when some of its sinks delivered the
+// alert and others failed. Callers should count the alert as sent: sending
+// it again would repeat it on the sinks that have it.
+type PartialError struct {
+	Err error
+}
+
+func (e *PartialError) Error() string {
+	return "alert partly delivered: " + e.Err.Error()
+}
+
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
+
+// Parse returns the sink described by spec: "stdout", "file:PATH" or an
+// http(s) webhook URL.
+func Parse(spec string) (Sink, error) {
+	switch {
+	case spec == "stdout":
+		return NewWriter(os.Stdout), nil
+	case strings.HasPrefix(spec, "file:"):
+		return NewFile(strings.TrimPrefix(spec, "file:")), nil
+	case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+		return NewWebhook(spec), nil
+	default:
+		return nil, fmt.Errorf("unknown alert sink %q, want stdout, file:PATH or a webhook URL", spec)
+	}
+}
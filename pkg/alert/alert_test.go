@@ -0,0 +1,69 @@
+package alert_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/alert"
+)
+
+func TestSinks(t *testing.T) {
+	ctx := context.Background()
+	a := &alert.Alert{Time: time.Unix(1700000000, 0), Severity: alert.SeverityCritical, Kind: "test", Message: "something happened"}
+
+	var posted struct {
+		alert.Alert
+		Text string `json:"text"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	path := filepath.Join(t.TempDir(), "alerts.jsonl")
+	var sinks alert.Multi
+	for _, spec := range []string{server.URL, failing.URL, "file:" + path} {
+		s, err := alert.Parse(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sinks = append(sinks, s)
+	}
+	for i := 0; i < 2; i++ {
+		var partial *alert.PartialError
+		if err := sinks.Send(ctx, a); !errors.As(err, &partial) || !strings.Contains(err.Error(), "500") {
+			t.Fatalf("Send = %v, want the failing webhook's error as a partial delivery", err)
+		}
+	}
+	var partial *alert.PartialError
+	if err := (alert.Multi{sinks[1]}).Send(ctx, a); err == nil || errors.As(err, &partial) {
+		t.Fatalf("Send to failing sinks only = %v, want a plain error", err)
+	}
+	if posted.Kind != a.Kind || posted.Message != a.Message || posted.Text != a.String() {
+		t.Fatalf("posted %+v", posted)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 2 {
+		t.Fatalf("file has %d alerts, want 2:\n%s", len(lines), data)
+	}
+	if _, err := alert.Parse("smtp://example.com"); err == nil {
+		t.Fatal("Parse accepted an unknown sink")
+	}
+}
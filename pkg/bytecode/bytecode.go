@@ -0,0 +1,206 @@
+// Package bytecode checks that deployed contracts run the code compiled from
+// this repository, as embedded in the gethwrappers bindings.
+//
+// The expected runtime code is derived by running a binding's creation code
+// in an in-process EVM. Only CallProxy has an immutable, its target; it is
+// read from the deployed code and passed to the constructor so that the
+// derived code can match byte for byte. The solc metadata at the end of the
+// runtime code, which hashes the sources including comments and paths, is
+// compared separately from the executable part.
+package bytecode
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/inspect"
+)
+
+// Match is the outcome of comparing deployed code with the expected code.
+type Match string
+
+const (
+	// MatchExact means the deployed code is the expected code.
+	MatchExact Match = "exact"
+	// MatchMetadata means the code only differs in its solc metadata: it
+	// executes identically but was compiled from different source files.
+	MatchMetadata Match = "metadata-differs"
+	// MatchNone means the code differs.
+	MatchNone Match = "mismatch"
+	// MatchNoCode means there is no code at the address.
+	MatchNoCode Match = "no-code"
+)
+
+// Compare compares deployed code with expected code.
+func Compare(deployed, expected []byte) Match {
+	if len(deployed) == 0 {
+		return MatchNoCode
+	}
+	if bytes.Equal(deployed, expected) {
+		return MatchExact
+	}
+	deployedBody, _ := SplitMetadata(deployed)
+	expectedBody, _ := SplitMetadata(expected)
+	if bytes.Equal(deployedBody, expectedBody) {
+		return MatchMetadata
+	}
+	return MatchNone
+}
+
+// SplitMetadata splits runtime code into the executable part and the CBOR
+// encoded solc metadata appended to it, whose length is given by the last
+// two bytes. Code without recognisable metadata is returned whole.
+func SplitMetadata(code []byte) (body, metadata []byte) {
+	if len(code) < 2 {
+		return code, nil
+	}
+	n := int(binary.BigEndian.Uint16(code[len(code)-2:])) + 2
+	// A CBOR map of one to three entries: ipfs, solc and maybe experimental.
+	if n > len(code) || n < 3 || code[len(code)-n]&0xf0 != 0xa0 {
+		return code, nil
+	}
+	return code[:len(code)-n], code[len(code)-n:]
+}
+
+// RuntimeCode runs the creation code of md with the ABI encoded constructor
+// arguments and returns the runtime code it deploys.
+func RuntimeCode(md *bind.MetaData, constructorArgs []byte) ([]byte, error) {
+	input := append(common.FromHex(md.Bin), constructorArgs...)
+	code, _, _, err := runtime.Create(input, &runtime.Config{
+		ChainConfig: params.AllDevChainProtocolChanges,
+		Random:      &common.Hash{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("running creation code: %w", err)
+	}
+	return code, nil
+}
+
+// The runtime code of ManyChainMultiSig and RBACTimelock does not depend on
+// the constructor arguments, so it is derived once.
+var (
+	multiSigCode = lazyCode(gethwrappers.ManyChainMultiSigMetaData, func() ([]byte, error) { return nil, nil })
+	timelockCode = lazyCode(gethwrappers.RBACTimelockMetaData, func() ([]byte, error) {
+		abi, err := gethwrappers.RBACTimelockMetaData.GetAbi()
+		if err != nil {
+			return nil, err
+		}
+		return abi.Pack("", new(big.Int), common.Address{}, []common.Address{}, []common.Address{}, []common.Address{}, []common.Address{})
+	})
+)
+
+func lazyCode(md *bind.MetaData, args func() ([]byte, error)) func() ([]byte, error) {
+	return sync.OnceValues(func() ([]byte, error) {
+		packed, err := args()
+		if err != nil {
+			return nil, err
+		}
+		return RuntimeCode(md, packed)
+	})
+}
+
+// ExpectedManyChainMultiSig returns the runtime code of ManyChainMultiSig.
+func ExpectedManyChainMultiSig() ([]byte, error) {
+	return multiSigCode()
+}
+
+// ExpectedRBACTimelock returns the runtime code of RBACTimelock.
+func ExpectedRBACTimelock() ([]byte, error) {
+	return timelockCode()
+}
+
+// ExpectedCallProxy returns the runtime code of a CallProxy for target.
+func ExpectedCallProxy(target common.Address) ([]byte, error) {
+	abi, err := gethwrappers.CallProxyMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	args, err := abi.Pack("", target)
+	if err != nil {
+		return nil, err
+	}
+	return RuntimeCode(gethwrappers.CallProxyMetaData, args)
+}
+
+// Result is the verification outcome for one contract.
+type Result struct {
+	Address      common.Address `json:"address"`
+	Type         string         `json:"type"`
+	Match        Match          `json:"match"`
+	CodeHash     common.Hash    `json:"codeHash"`
+	ExpectedHash common.Hash    `json:"expectedHash"`
+}
+
+// Verify checks the code of every contract of d at the latest block.
+func Verify(ctx context.Context, backend bind.ContractCaller, d *inspect.Deployment) ([]Result, error) {
+	var results []Result
+	check := func(addr common.Address, typ string, expected func(code []byte) ([]byte, error)) error {
+		code, err := backend.CodeAt(ctx, addr, nil)
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", typ, addr, err)
+		}
+		r := Result{Address: addr, Type: typ}
+		if len(code) == 0 {
+			r.Match = MatchNoCode
+			results = append(results, r)
+			return nil
+		}
+		r.CodeHash = crypto.Keccak256Hash(code)
+		want, err := expected(code)
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", typ, addr, err)
+		}
+		if want == nil {
+			r.Match = MatchNone
+		} else {
+			r.ExpectedHash = crypto.Keccak256Hash(want)
+			r.Match = Compare(code, want)
+		}
+		results = append(results, r)
+		return nil
+	}
+	for _, addr := range d.MultiSigs {
+		if err := check(addr, "ManyChainMultiSig", func([]byte) ([]byte, error) { return ExpectedManyChainMultiSig() }); err != nil {
+			return nil, err
+		}
+	}
+	for _, addr := range d.Timelocks {
+		if err := check(addr, "RBACTimelock", func([]byte) ([]byte, error) { return ExpectedRBACTimelock() }); err != nil {
+			return nil, err
+		}
+	}
+	for _, addr := range d.CallProxies {
+		if err := check(addr, "CallProxy", func(code []byte) ([]byte, error) {
+			target, err := inspect.CallProxyTarget(code)
+			if err != nil {
+				// Not even shaped like a CallProxy: nothing to derive.
+				return nil, nil
+			}
+			return ExpectedCallProxy(target)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// AllExact reports whether every result is an exact match.
+func AllExact(results []Result) bool {
+	for _, r := range results {
+		if r.Match != MatchExact {
+			return false
+		}
+	}
+	return true
+}
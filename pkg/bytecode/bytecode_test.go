@@ -0,0 +1,86 @@
+package bytecode_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/bytecode"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/inspect"
+)
+
+func TestVerify(t *testing.T) {
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	d := &inspect.Deployment{
+		MultiSigs:   []common.Address{e.Proposer.Address, e.Canceller.Address, e.Bypasser.Address},
+		Timelocks:   []common.Address{e.TimelockAddress},
+		CallProxies: []common.Address{e.CallProxyAddress},
+	}
+	results, err := bytecode.Verify(context.Background(), e.Backend, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 5 || !bytecode.AllExact(results) {
+		t.Fatalf("results = %+v", results)
+	}
+
+	// An account without code, a multisig where a timelock is expected, a
+	// timelock where a CallProxy is expected and a CallProxy without code.
+	wrong := &inspect.Deployment{
+		MultiSigs:   []common.Address{common.Address{1}},
+		Timelocks:   []common.Address{e.Proposer.Address},
+		CallProxies: []common.Address{e.TimelockAddress, common.Address{2}},
+	}
+	if results, err = bytecode.Verify(context.Background(), e.Backend, wrong); err != nil {
+		t.Fatal(err)
+	}
+	want := []bytecode.Match{bytecode.MatchNoCode, bytecode.MatchNone, bytecode.MatchNone, bytecode.MatchNoCode}
+	if len(results) != len(want) {
+		t.Fatalf("results = %+v", results)
+	}
+	for i, r := range results {
+		if r.Match != want[i] {
+			t.Errorf("%s %s: match %s, want %s", r.Type, r.Address, r.Match, want[i])
+		}
+	}
+}
+
+func TestCompareMetadata(t *testing.T) {
+	code, err := bytecode.ExpectedCallProxy(common.Address{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, metadata := bytecode.SplitMetadata(code)
+	if len(metadata) == 0 || !bytes.Equal(append(append([]byte{}, body...), metadata...), code) {
+		t.Fatalf("no metadata found in %x", code)
+	}
+	if got := bytecode.Compare(code, code); got != bytecode.MatchExact {
+		t.Fatalf("Compare(code, code) = %s", got)
+	}
+
+	// Recompiling from different sources changes the metadata hash only.
+	other := append([]byte{}, code...)
+	other[len(body)+10] ^= 0xff
+	if got := bytecode.Compare(other, code); got != bytecode.MatchMetadata {
+		t.Fatalf("Compare with changed metadata = %s", got)
+	}
+	other = append([]byte{}, code...)
+	other[len(body)-1] ^= 0xff
+	if got := bytecode.Compare(other, code); got != bytecode.MatchNone {
+		t.Fatalf("Compare with changed code = %s", got)
+	}
+
+	// The CallProxy target is an immutable, so proxies for other targets
+	// run different code.
+	otherProxy, err := bytecode.ExpectedCallProxy(common.Address{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := bytecode.Compare(otherProxy, code); got != bytecode.MatchNone {
+		t.Fatalf("Compare of proxies for different targets = %s", got)
+	}
+}
@@ -0,0 +1,363 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/inspect"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+// Backend is what Deploy needs from a node.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	inspect.Backend
+	TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error)
+}
+
+// State records the progress of a deployment. Zero addresses are contracts
+// that have not been deployed yet.
+type State struct {
+	Deployer  common.Address `json:"deployer"`
+	Proposer  common.Address `json:"proposer"`
+	Canceller common.Address `json:"canceller"`
+	Bypasser  common.Address `json:"bypasser"`
+	Timelock  common.Address `json:"timelock"`
+	CallProxy common.Address `json:"callProxy"`
+	// Txs maps each step to the hash of the last transaction sent for it.
+	Txs map[string]common.Hash `json:"txs"`
+	// Done is set once the deployment has been handed over and verified.
+	Done bool `json:"done"`
+}
+
+// Deployment lists the contracts of the state for inspect.Take.
+func (s *State) Deployment() *inspect.Deployment {
+	return &inspect.Deployment{
+		MultiSigs:   []common.Address{s.Proposer, s.Canceller, s.Bypasser},
+		Timelocks:   []common.Address{s.Timelock},
+		CallProxies: []common.Address{s.CallProxy},
+	}
+}
+
+// Store persists the State between runs.
+type Store interface {
+	// Load returns the saved state, or an empty one if nothing was saved.
+	Load() (*State, error)
+	Save(*State) error
+}
+
+// FileStore stores the state as JSON in the file it names.
+type FileStore string
+
+// Load implements Store.
+func (f FileStore) Load() (*State, error) {
+	data, err := os.ReadFile(string(f))
+	if errors.Is(err, os.ErrNotExist) {
+		return &State{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", f, err)
+	}
+	return &s, nil
+}
+
+// Save implements Store.
+func (f FileStore) Save(s *State) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(string(f), append(data, '\n'), 0o644)
+}
+
+// Deploy brings the chain to the deployment described by spec, sending
+// transactions from opts.From, and returns the final state. On failure the
+// returned state shows how far the deployment got.
+//
+// Every step first reads the chain and is skipped if already done, so Deploy
+// can be rerun after a failure with the same store and continues where the
+// previous run stopped. Every transaction is recorded in the store as soon as
+// it has been sent, and the next run waits for it before reading the step's
+// state; only if the node has dropped it is the step sent again.
+//
+// The deployer is the timelock's initial admin and holds the BYPASSER_ROLE
+// until the timelock has accepted ownership of the multisigs
+// (Ownable2Step); it holds no role and owns nothing once Deploy returns.
+func Deploy(ctx context.Context, backend Backend, opts *bind.TransactOpts, spec *Spec, store Store) (*State, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	state, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	if state.Deployer == (common.Address{}) {
+		state.Deployer = opts.From
+	} else if state.Deployer != opts.From {
+		return nil, fmt.Errorf("deployment was started by %s, not %s", state.Deployer, opts.From)
+	}
+	if state.Txs == nil {
+		state.Txs = make(map[string]common.Hash)
+	}
+	d := &deployer{backend: backend, opts: opts, spec: spec, store: store, state: state}
+	return state, d.run(ctx)
+}
+
+type deployer struct {
+	backend Backend
+	opts    *bind.TransactOpts
+	spec    *Spec
+	store   Store
+	state   *State
+}
+
+func (d *deployer) run(ctx context.Context) error {
+	s := d.state
+	multiSigs := []*common.Address{&s.Proposer, &s.Canceller, &s.Bypasser}
+	for i, ms := range d.spec.multiSigs() {
+		if err := d.deployContract(ctx, "deploy "+ms.name, multiSigs[i], func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+			addr, tx, _, err := gethwrappers.DeployManyChainMultiSig(opts, d.backend)
+			return addr, tx, err
+		}); err != nil {
+			return err
+		}
+		if err := d.setConfig(ctx, ms.name, *multiSigs[i], ms.spec); err != nil {
+			return err
+		}
+	}
+
+	minDelay, err := d.spec.minDelaySeconds()
+	if err != nil {
+		return err
+	}
+	if err := d.deployContract(ctx, "deploy timelock", &s.Timelock, func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := gethwrappers.DeployRBACTimelock(opts, d.backend, big.NewInt(minDelay), s.Deployer,
+			[]common.Address{s.Proposer},
+			nil,
+			[]common.Address{s.Proposer, s.Canceller},
+			[]common.Address{s.Bypasser, s.Deployer},
+		)
+		return addr, tx, err
+	}); err != nil {
+		return err
+	}
+	if err := d.deployContract(ctx, "deploy callProxy", &s.CallProxy, func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		addr, tx, _, err := gethwrappers.DeployCallProxy(opts, d.backend, s.Timelock)
+		return addr, tx, err
+	}); err != nil {
+		return err
+	}
+
+	tl, err := gethwrappers.NewRBACTimelock(s.Timelock, d.backend)
+	if err != nil {
+		return err
+	}
+	if err := d.ensureRole(ctx, tl, "grant EXECUTOR_ROLE to callProxy", timelock.ExecutorRole, s.CallProxy, true, tl.GrantRole); err != nil {
+		return err
+	}
+	if err := d.handOver(ctx, tl); err != nil {
+		return err
+	}
+	if err := d.ensureRole(ctx, tl, "grant ADMIN_ROLE to timelock", timelock.AdminRole, s.Timelock, true, tl.GrantRole); err != nil {
+		return err
+	}
+	if err := d.ensureRole(ctx, tl, "revoke BYPASSER_ROLE from deployer", timelock.BypasserRole, s.Deployer, false, tl.RevokeRole); err != nil {
+		return err
+	}
+	if err := d.ensureRole(ctx, tl, "renounce ADMIN_ROLE", timelock.AdminRole, s.Deployer, false, tl.RenounceRole); err != nil {
+		return err
+	}
+
+	if err := Verify(ctx, d.backend, d.spec, s); err != nil {
+		return err
+	}
+	s.Done = true
+	return d.store.Save(s)
+}
+
+// deployContract deploys a contract into *addr unless there already is code
+// at *addr.
+func (d *deployer) deployContract(ctx context.Context, step string, addr *common.Address, deploy func(*bind.TransactOpts) (common.Address, *types.Transaction, error)) error {
+	if err := d.settle(ctx, step); err != nil {
+		return err
+	}
+	if *addr != (common.Address{}) {
+		code, err := d.backend.CodeAt(ctx, *addr, nil)
+		if err != nil {
+			return fmt.Errorf("%s: %w", step, err)
+		}
+		if len(code) > 0 {
+			return nil
+		}
+	}
+	deployed, tx, err := deploy(d.transactOpts(ctx))
+	if err != nil {
+		return fmt.Errorf("%s: %w", step, err)
+	}
+	*addr = deployed
+	return d.record(ctx, step, tx)
+}
+
+func (d *deployer) setConfig(ctx context.Context, name string, addr common.Address, spec *MultiSigSpec) error {
+	step := "setConfig " + name
+	if err := d.settle(ctx, step); err != nil {
+		return err
+	}
+	ms, err := gethwrappers.NewManyChainMultiSig(addr, d.backend)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	config, err := ms.GetConfig(opts)
+	if err != nil {
+		return fmt.Errorf("%s: getConfig: %w", step, err)
+	}
+	if configsEqual(config, spec.config()) {
+		return nil
+	}
+	owner, err := ms.Owner(opts)
+	if err != nil {
+		return fmt.Errorf("%s: owner: %w", step, err)
+	}
+	if owner != d.state.Deployer {
+		return fmt.Errorf("%s: config of %s differs from the spec and it is owned by %s", step, addr, owner)
+	}
+	tx, err := ms.SetConfig(d.transactOpts(ctx), spec.SignerAddresses, spec.SignerGroups, spec.GroupQuorums, spec.GroupParents, false)
+	if err != nil {
+		return fmt.Errorf("%s: %w", step, err)
+	}
+	return d.record(ctx, step, tx)
+}
+
+// ensureRole sends update(role, account) unless account's membership of role
+// already is want.
+func (d *deployer) ensureRole(ctx context.Context, tl *gethwrappers.RBACTimelock, step string, role timelock.Role, account common.Address, want bool,
+	update func(*bind.TransactOpts, [32]byte, common.Address) (*types.Transaction, error)) error {
+	if err := d.settle(ctx, step); err != nil {
+		return err
+	}
+	has, err := tl.HasRole(&bind.CallOpts{Context: ctx}, role.ID, account)
+	if err != nil {
+		return fmt.Errorf("%s: hasRole: %w", step, err)
+	}
+	if has == want {
+		return nil
+	}
+	tx, err := update(d.transactOpts(ctx), role.ID, account)
+	if err != nil {
+		return fmt.Errorf("%s: %w", step, err)
+	}
+	return d.record(ctx, step, tx)
+}
+
+// handOver transfers ownership of the multisigs to the timelock, which then
+// accepts it through the deployer's temporary BYPASSER_ROLE.
+func (d *deployer) handOver(ctx context.Context, tl *gethwrappers.RBACTimelock) error {
+	acceptData, err := mcms.ManyChainMultiSigABI.Pack("acceptOwnership")
+	if err != nil {
+		return err
+	}
+	const acceptStep = "acceptOwnership"
+	if err := d.settle(ctx, acceptStep); err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	var accept []gethwrappers.RBACTimelockCall
+	for i, addr := range []common.Address{d.state.Proposer, d.state.Canceller, d.state.Bypasser} {
+		step := "transferOwnership " + d.spec.multiSigs()[i].name
+		if err := d.settle(ctx, step); err != nil {
+			return err
+		}
+		ms, err := gethwrappers.NewManyChainMultiSig(addr, d.backend)
+		if err != nil {
+			return err
+		}
+		owner, err := ms.Owner(opts)
+		if err != nil {
+			return fmt.Errorf("%s: owner: %w", step, err)
+		}
+		if owner == d.state.Timelock {
+			continue
+		}
+		if owner != d.state.Deployer {
+			return fmt.Errorf("%s: %s is owned by %s", step, addr, owner)
+		}
+		pending, err := ms.PendingOwner(opts)
+		if err != nil {
+			return fmt.Errorf("%s: pendingOwner: %w", step, err)
+		}
+		if pending != d.state.Timelock {
+			tx, err := ms.TransferOwnership(d.transactOpts(ctx), d.state.Timelock)
+			if err != nil {
+				return fmt.Errorf("%s: %w", step, err)
+			}
+			if err := d.record(ctx, step, tx); err != nil {
+				return err
+			}
+		}
+		accept = append(accept, gethwrappers.RBACTimelockCall{Target: addr, Value: new(big.Int), Data: acceptData})
+	}
+	if len(accept) == 0 {
+		return nil
+	}
+	bypasser, err := tl.HasRole(opts, timelock.BypasserRole.ID, d.state.Deployer)
+	if err != nil {
+		return fmt.Errorf("%s: hasRole: %w", acceptStep, err)
+	}
+	if !bypasser {
+		return fmt.Errorf("%s: deployer no longer holds the BYPASSER_ROLE; %d multisigs must accept ownership through a proposal", acceptStep, len(accept))
+	}
+	tx, err := tl.BypasserExecuteBatch(d.transactOpts(ctx), accept)
+	if err != nil {
+		return fmt.Errorf("%s: %w", acceptStep, err)
+	}
+	return d.record(ctx, acceptStep, tx)
+}
+
+// settle waits for the transaction a previous run sent for step, if any, so
+// that the step does not read the chain while it is still pending.
+func (d *deployer) settle(ctx context.Context, step string) error {
+	hash, ok := d.state.Txs[step]
+	if !ok {
+		return nil
+	}
+	if _, err := evm.WaitSent(ctx, d.backend, hash); err != nil && !errors.Is(err, ethereum.NotFound) {
+		return fmt.Errorf("%s: tx %s: %w", step, hash, err)
+	}
+	return nil
+}
+
+// record saves tx under step, waits for it to be mined and fails if it
+// reverted.
+func (d *deployer) record(ctx context.Context, step string, tx *types.Transaction) error {
+	d.state.Txs[step] = tx.Hash()
+	if err := d.store.Save(d.state); err != nil {
+		return err
+	}
+	if _, err := evm.WaitSuccess(ctx, d.backend, tx); err != nil {
+		return fmt.Errorf("%s: %w", step, err)
+	}
+	return nil
+}
+
+func (d *deployer) transactOpts(ctx context.Context) *bind.TransactOpts {
+	opts := *d.opts
+	opts.Context = ctx
+	return &opts
+}
@@ -0,0 +1,175 @@
+package deploy_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/deploy"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+var errConnectionLost = errors.New("connection lost")
+
+// flakyBackend mines every transaction and fails once limit transactions
+// have been sent, unless limit is negative.
+type flakyBackend struct {
+	harness.AutoMiningBackend
+	sent, limit int
+}
+
+func (b *flakyBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if b.limit >= 0 && b.sent >= b.limit {
+		return errConnectionLost
+	}
+	b.sent++
+	return b.AutoMiningBackend.SendTransaction(ctx, tx)
+}
+
+func newSpec(t *testing.T) *deploy.Spec {
+	spec := &deploy.Spec{MinDelay: "3h"}
+	for _, ms := range []*deploy.MultiSigSpec{&spec.Proposer, &spec.Canceller, &spec.Bypasser} {
+		ms.SignerAddresses = harness.SignerAddresses(harness.GenerateSigners(t, 3))
+		ms.SignerGroups = make([]uint8, 3)
+		ms.GroupQuorums[0] = 2
+	}
+	// A subgroup, so that the configs are not all of the same shape.
+	spec.Bypasser.SignerGroups = []uint8{1, 1, 0}
+	spec.Bypasser.GroupQuorums[1] = 1
+	return spec
+}
+
+func TestDeploy(t *testing.T) {
+	ctx := context.Background()
+	spec := newSpec(t)
+	e := harness.NewBare(t)
+	store := deploy.FileStore(filepath.Join(t.TempDir(), "state.json"))
+	backend := &flakyBackend{AutoMiningBackend: e.AutoMining(), limit: -1}
+
+	state, err := deploy.Deploy(ctx, backend, e.Deployer.Opts, spec, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Done {
+		t.Fatal("deployment not marked done")
+	}
+	total := backend.sent
+
+	// Verify checked the role members; check the deployer kept nothing.
+	tl, err := gethwrappers.NewRBACTimelock(state.Timelock, e.Backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, role := range timelock.Roles {
+		has, err := tl.HasRole(&bind.CallOpts{}, role.ID, e.Deployer.Address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if has {
+			t.Fatalf("deployer still holds %s", role.Name)
+		}
+	}
+
+	// Rerunning a finished deployment sends nothing.
+	backend.sent = 0
+	if _, err := deploy.Deploy(ctx, backend, e.Deployer.Opts, spec, store); err != nil {
+		t.Fatal(err)
+	}
+	if backend.sent != 0 {
+		t.Fatalf("rerun sent %d transactions", backend.sent)
+	}
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Done || loaded.Timelock != state.Timelock || loaded.CallProxy != state.CallProxy {
+		t.Fatalf("stored state %+v, want %+v", loaded, state)
+	}
+
+	// A different deployer cannot take over the deployment.
+	other := e.NewAccount()
+	if _, err := deploy.Deploy(ctx, backend, other.Opts, spec, store); err == nil {
+		t.Fatal("deployment resumed by another account")
+	}
+
+	// Someone changing a config behind the deployer's back is caught.
+	spec.Canceller.GroupQuorums[0] = 3
+	var verr *deploy.VerifyError
+	if err := deploy.Verify(ctx, e.Backend, spec, state); !errors.As(err, &verr) || len(verr.Problems) != 1 {
+		t.Fatalf("Verify with a changed spec: got %v", err)
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		for limit := 0; limit < total; limit++ {
+			e := harness.NewBare(t)
+			store := deploy.FileStore(filepath.Join(t.TempDir(), "state.json"))
+			backend := &flakyBackend{AutoMiningBackend: e.AutoMining(), limit: limit}
+			spec := newSpec(t)
+			if _, err := deploy.Deploy(ctx, backend, e.Deployer.Opts, spec, store); !errors.Is(err, errConnectionLost) {
+				t.Fatalf("limit %d: got %v", limit, err)
+			}
+			backend.sent, backend.limit = 0, -1
+			state, err := deploy.Deploy(ctx, backend, e.Deployer.Opts, spec, store)
+			if err != nil {
+				t.Fatalf("limit %d: resuming: %v", limit, err)
+			}
+			if !state.Done || backend.sent != total-limit {
+				t.Fatalf("limit %d: resuming sent %d transactions, want %d", limit, backend.sent, total-limit)
+			}
+		}
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		e := harness.NewBare(t)
+		store := deploy.FileStore(filepath.Join(t.TempDir(), "state.json"))
+		spec := newSpec(t)
+		// Nothing mines the first deployment tx, so the first run gives up
+		// waiting for it.
+		waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		if _, err := deploy.Deploy(waitCtx, e.Backend, e.Deployer.Opts, spec, store); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("got %v", err)
+		}
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			e.Commit()
+		}()
+		backend := &flakyBackend{AutoMiningBackend: e.AutoMining(), limit: -1}
+		state, err := deploy.Deploy(ctx, backend, e.Deployer.Opts, spec, store)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !state.Done || backend.sent != total-1 {
+			t.Fatalf("resuming sent %d transactions, want %d", backend.sent, total-1)
+		}
+	})
+}
+
+func TestSpecValidate(t *testing.T) {
+	for name, mutate := range map[string]func(*deploy.Spec){
+		"bad delay":      func(s *deploy.Spec) { s.MinDelay = "soon" },
+		"fractional":     func(s *deploy.Spec) { s.MinDelay = "1.5s" },
+		"no signers":     func(s *deploy.Spec) { s.Canceller = deploy.MultiSigSpec{} },
+		"quorum too big": func(s *deploy.Spec) { s.Proposer.GroupQuorums[0] = 4 },
+		"unsorted": func(s *deploy.Spec) {
+			a := s.Bypasser.SignerAddresses
+			a[0], a[1] = a[1], a[0]
+		},
+	} {
+		spec := newSpec(t)
+		mutate(spec)
+		if err := spec.Validate(); err == nil {
+			t.Errorf("%s: spec validated", name)
+		}
+	}
+	if err := newSpec(t).Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
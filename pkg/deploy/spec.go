@@ -0,0 +1,109 @@
+// Package deploy deploys the owner-contract topology described in the README
+// from a declarative spec: proposer, canceller and bypasser
+// ManyChainMultiSigs, an RBACTimelock that administers itself and owns the
+// multisigs, and a CallProxy holding the EXECUTOR_ROLE.
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+// Spec is the desired deployment.
+type Spec struct {
+	// MinDelay is the timelock's minimum delay as a Go duration, e.g. "24h".
+	MinDelay  string       `json:"minDelay"`
+	Proposer  MultiSigSpec `json:"proposer"`
+	Canceller MultiSigSpec `json:"canceller"`
+	Bypasser  MultiSigSpec `json:"bypasser"`
+}
+
+// MultiSigSpec holds the setConfig arguments of a ManyChainMultiSig. Its JSON
+// form is the setConfig object printed by generateAddressesAndKeys -json.
+type MultiSigSpec struct {
+	SignerAddresses []common.Address      `json:"signerAddresses"`
+	SignerGroups    []uint8               `json:"signerGroups"`
+	GroupQuorums    [mcms.NumGroups]uint8 `json:"groupQuorums"`
+	GroupParents    [mcms.NumGroups]uint8 `json:"groupParents"`
+}
+
+// LoadSpec reads a JSON encoded spec from path.
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return &spec, nil
+}
+
+// Validate checks the spec without touching the chain.
+func (s *Spec) Validate() error {
+	if _, err := s.minDelaySeconds(); err != nil {
+		return err
+	}
+	for _, ms := range s.multiSigs() {
+		if err := ms.spec.validate(); err != nil {
+			return fmt.Errorf("%s: %w", ms.name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Spec) minDelaySeconds() (int64, error) {
+	d, err := time.ParseDuration(s.MinDelay)
+	if err != nil {
+		return 0, fmt.Errorf("invalid minDelay: %w", err)
+	}
+	if d < 0 || d%time.Second != 0 {
+		return 0, fmt.Errorf("minDelay %s is not a non-negative number of seconds", d)
+	}
+	return int64(d / time.Second), nil
+}
+
+type namedMultiSig struct {
+	name string
+	spec *MultiSigSpec
+}
+
+func (s *Spec) multiSigs() []namedMultiSig {
+	return []namedMultiSig{{"proposer", &s.Proposer}, {"canceller", &s.Canceller}, {"bypasser", &s.Bypasser}}
+}
+
+func (m *MultiSigSpec) validate() error {
+	if len(m.SignerAddresses) == 0 {
+		return fmt.Errorf("no signers")
+	}
+	return mcms.ValidateConfig(m.SignerAddresses, m.SignerGroups, m.GroupQuorums, m.GroupParents)
+}
+
+// config returns the getConfig result after setConfig with m.
+func (m *MultiSigSpec) config() gethwrappers.ManyChainMultiSigConfig {
+	config := gethwrappers.ManyChainMultiSigConfig{GroupQuorums: m.GroupQuorums, GroupParents: m.GroupParents}
+	for i, addr := range m.SignerAddresses {
+		config.Signers = append(config.Signers, gethwrappers.ManyChainMultiSigSigner{Addr: addr, Index: uint8(i), Group: m.SignerGroups[i]})
+	}
+	return config
+}
+
+func configsEqual(a, b gethwrappers.ManyChainMultiSigConfig) bool {
+	if a.GroupQuorums != b.GroupQuorums || a.GroupParents != b.GroupParents || len(a.Signers) != len(b.Signers) {
+		return false
+	}
+	for i := range a.Signers {
+		if a.Signers[i] != b.Signers[i] {
+			return false
+		}
+	}
+	return true
+}
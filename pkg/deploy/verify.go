@@ -0,0 +1,91 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/inspect"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+// VerifyError lists the ways a deployment differs from its spec.
+type VerifyError struct {
+	Problems []string
+}
+
+func (e *VerifyError) Error() string {
+	return "deployment does not match the spec: " + strings.Join(e.Problems, "; ")
+}
+
+// Verify checks that the contracts of s form the topology of spec at the
+// latest block: the timelock owns the multisigs and administers itself, every
+// role has exactly the expected members, and the configs, minimum delay and
+// CallProxy target match. Mismatches are reported as a *VerifyError.
+func Verify(ctx context.Context, backend inspect.Backend, spec *Spec, s *State) error {
+	minDelay, err := spec.minDelaySeconds()
+	if err != nil {
+		return err
+	}
+	snap, err := inspect.Take(ctx, backend, s.Deployment(), nil)
+	if err != nil {
+		return err
+	}
+	var problems []string
+	for i, addr := range []common.Address{s.Proposer, s.Canceller, s.Bypasser} {
+		ms := spec.multiSigs()[i]
+		st := snap.MultiSigs[addr]
+		if st.Owner != s.Timelock {
+			problems = append(problems, fmt.Sprintf("%s %s is owned by %s", ms.name, addr, st.Owner))
+		}
+		if st.PendingOwner != (common.Address{}) {
+			problems = append(problems, fmt.Sprintf("%s %s has pending owner %s", ms.name, addr, st.PendingOwner))
+		}
+		if !configsEqual(st.Config, ms.spec.config()) {
+			problems = append(problems, fmt.Sprintf("%s %s config differs from the spec", ms.name, addr))
+		}
+	}
+
+	tl := snap.Timelocks[s.Timelock]
+	if tl.MinDelay.Int64() != minDelay {
+		problems = append(problems, fmt.Sprintf("timelock minDelay is %v, want %d", tl.MinDelay, minDelay))
+	}
+	wantRoles := map[timelock.Role][]common.Address{
+		timelock.AdminRole:     {s.Timelock},
+		timelock.ProposerRole:  {s.Proposer},
+		timelock.ExecutorRole:  {s.CallProxy},
+		timelock.CancellerRole: {s.Proposer, s.Canceller},
+		timelock.BypasserRole:  {s.Bypasser},
+	}
+	for _, role := range timelock.Roles {
+		if got, want := tl.Roles[role.Name], wantRoles[role]; !sameMembers(got, want) {
+			problems = append(problems, fmt.Sprintf("timelock %s members are %v, want %v", role.Name, got, want))
+		}
+	}
+
+	if target := snap.CallProxies[s.CallProxy].Target; target != s.Timelock {
+		problems = append(problems, fmt.Sprintf("callProxy targets %s, not the timelock", target))
+	}
+	if len(problems) > 0 {
+		return &VerifyError{Problems: problems}
+	}
+	return nil
+}
+
+func sameMembers(a, b []common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[common.Address]bool, len(a))
+	for _, addr := range a {
+		set[addr] = true
+	}
+	for _, addr := range b {
+		if !set[addr] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+// Package evm holds the small chain helpers shared by the owner-contract
+// packages.
+package evm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultLogBatchSize is the default number of blocks requested per
+// eth_getLogs call when scanning history.
+const DefaultLogBatchSize = 10_000
+
+// MustParseABI returns the parsed ABI of md and panics if it is invalid. It
+// is meant for package-level variables over the generated bindings.
+func MustParseABI(md *bind.MetaData) *abi.ABI {
+	parsed, err := md.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// WaitSuccess waits for tx to be mined and fails if it reverted.
+func WaitSuccess(ctx context.Context, backend bind.DeployBackend, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(ctx, backend, tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", tx.Hash())
+	}
+	return receipt, nil
+}
+
+// TxBackend is what WaitSent needs from a node.
+type TxBackend interface {
+	bind.DeployBackend
+	TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error)
+}
+
+// WaitSent waits for a transaction sent earlier, known only by its hash, to
+// be mined and returns its receipt, whatever its status. It returns
+// ethereum.NotFound if the node does not know the transaction, e.g. because
+// it was dropped from the mempool, in which case it is safe to send it again.
+func WaitSent(ctx context.Context, backend TxBackend, hash common.Hash) (*types.Receipt, error) {
+	tx, _, err := backend.TransactionByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return bind.WaitMined(ctx, backend, tx)
+}
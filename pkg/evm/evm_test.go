@@ -0,0 +1,55 @@
+package evm_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+)
+
+func TestWaitSent(t *testing.T) {
+	e := harness.NewBare(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := evm.WaitSent(ctx, e.Backend, common.HexToHash("0x01")); !errors.Is(err, ethereum.NotFound) {
+		t.Fatalf("unknown tx: got %v, want ethereum.NotFound", err)
+	}
+
+	d := e.Deployer
+	nonce, err := e.Backend.PendingNonceAt(ctx, d.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gasPrice, err := e.Backend.SuggestGasPrice(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := d.Opts.Signer(d.Address, types.NewTransaction(nonce, common.HexToAddress("0x1234"), big.NewInt(1), 21_000, gasPrice, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Backend.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	// The tx is pending: WaitSent must wait for it to be mined.
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		e.Commit()
+	}()
+	receipt, err := evm.WaitSent(ctx, e.Backend, tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatal("transfer reverted")
+	}
+}
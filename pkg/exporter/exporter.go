@@ -0,0 +1,328 @@
+// Package exporter exposes the state of owner-contract deployments as
+// Prometheus metrics.
+//
+// An Exporter polls the contracts of every chain it is given through the
+// bindings and updates its gauges, which its Handler serves in the
+// Prometheus text format. Gauges are labelled with the chain id and the
+// contract address, so that one exporter can serve every chain of a
+// manifest.
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/inspect"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+// Backend is the subset of a chain client needed to export metrics.
+type Backend interface {
+	bind.ContractCaller
+	bind.ContractFilterer
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Chain is a chain the exporter polls.
+type Chain struct {
+	ChainID    *big.Int
+	Backend    Backend
+	Deployment *inspect.Deployment
+	// FromBlock is where the event scans start, usually the deployment
+	// block. ConfigSet events and operations scheduled before it are not
+	// seen.
+	FromBlock uint64
+	// BatchSize is the number of blocks per eth_getLogs call, by default
+	// evm.DefaultLogBatchSize.
+	BatchSize uint64
+}
+
+// metrics are the collectors of an Exporter.
+type metrics struct {
+	opCount          *prometheus.GaugeVec
+	rootOpsRemaining *prometheus.GaugeVec
+	validUntil       *prometheus.GaugeVec
+	configSetAge     *prometheus.GaugeVec
+	operations       *prometheus.GaugeVec
+	roleMembers      *prometheus.GaugeVec
+	lastPoll         *prometheus.GaugeVec
+	pollErrors       *prometheus.CounterVec
+}
+
+func newMetrics() *metrics {
+	contract := []string{"chain_id", "contract"}
+	return &metrics{
+		opCount: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mcms_op_count",
+			Help: "Number of ops executed by the ManyChainMultiSig.",
+		}, contract),
+		rootOpsRemaining: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mcms_root_ops_remaining",
+			Help: "Number of ops of the current root left to execute.",
+		}, contract),
+		validUntil: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mcms_root_valid_until_seconds",
+			Help: "Seconds from the latest block until the current root expires, negative once expired.",
+		}, contract),
+		configSetAge: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mcms_config_set_age_seconds",
+			Help: "Seconds from the last ConfigSet event to the latest block.",
+		}, contract),
+		operations: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "timelock_operations",
+			Help: "Number of operations scheduled on the RBACTimelock, by status.",
+		}, append(contract, "status")),
+		roleMembers: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "timelock_role_members",
+			Help: "Number of members of each RBACTimelock role.",
+		}, append(contract, "role")),
+		lastPoll: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "owner_exporter_last_poll_timestamp_seconds",
+			Help: "Unix time of the last successful poll of the chain.",
+		}, []string{"chain_id"}),
+		pollErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "owner_exporter_poll_errors_total",
+			Help: "Number of failed polls of the chain.",
+		}, []string{"chain_id"}),
+	}
+}
+
+func (m *metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.opCount, m.rootOpsRemaining, m.validUntil, m.configSetAge, m.operations, m.roleMembers, m.lastPoll, m.pollErrors}
+}
+
+// Exporter polls the contracts of several chains into Prometheus metrics.
+type Exporter struct {
+	registry *prometheus.Registry
+	metrics  *metrics
+	chains   []*polledChain
+}
+
+type polledChain struct {
+	Chain
+	// configSet is the block time of the last ConfigSet event of each
+	// multiSig, if any was seen.
+	configSet map[common.Address]uint64
+	// open holds the operations of each timelock that are neither done nor
+	// cancelled.
+	open map[common.Address]map[common.Hash]bool
+	// next is the first block not scanned for events yet.
+	next uint64
+}
+
+// New returns an exporter of chains with its own registry.
+func New(chains []Chain) (*Exporter, error) {
+	e := &Exporter{registry: prometheus.NewRegistry(), metrics: newMetrics()}
+	for _, c := range e.metrics.collectors() {
+		if err := e.registry.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range chains {
+		if c.ChainID == nil {
+			return nil, errors.New("chain without chain id")
+		}
+		if c.BatchSize == 0 {
+			c.BatchSize = evm.DefaultLogBatchSize
+		}
+		e.chains = append(e.chains, &polledChain{
+			Chain:     c,
+			configSet: make(map[common.Address]uint64),
+			open:      make(map[common.Address]map[common.Hash]bool),
+			next:      c.FromBlock,
+		})
+	}
+	return e, nil
+}
+
+// Registry returns the registry holding the metrics of e, e.g. to add
+// process metrics to it.
+func (e *Exporter) Registry() *prometheus.Registry {
+	return e.registry
+}
+
+// Handler serves the metrics of e.
+func (e *Exporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{})
+}
+
+// Poll updates the metrics of every chain once. A chain that fails keeps
+// its previous values and counts an error; the errors of all chains are
+// returned together.
+func (e *Exporter) Poll(ctx context.Context) error {
+	var errs []error
+	for _, c := range e.chains {
+		id := c.ChainID.String()
+		if err := e.poll(ctx, c); err != nil {
+			e.metrics.pollErrors.WithLabelValues(id).Inc()
+			errs = append(errs, fmt.Errorf("chain %s: %w", id, err))
+			continue
+		}
+		e.metrics.lastPoll.WithLabelValues(id).SetToCurrentTime()
+	}
+	return errors.Join(errs...)
+}
+
+func (e *Exporter) poll(ctx context.Context, c *polledChain) error {
+	d := &inspect.Deployment{MultiSigs: c.Deployment.MultiSigs, Timelocks: c.Deployment.Timelocks}
+	s, err := inspect.Take(ctx, c.Backend, d, nil)
+	if err != nil {
+		return err
+	}
+	if err := c.scan(ctx, s.BlockNumber); err != nil {
+		return err
+	}
+	chainID := c.ChainID.String()
+	m := e.metrics
+	for addr, st := range s.MultiSigs {
+		labels := prometheus.Labels{"chain_id": chainID, "contract": addr.Hex()}
+		m.opCount.With(labels).Set(float64(st.OpCount))
+		remaining := 0.0
+		if post := st.RootMetadata.PostOpCount.Uint64(); post > st.OpCount {
+			remaining = float64(post - st.OpCount)
+		}
+		m.rootOpsRemaining.With(labels).Set(remaining)
+		m.validUntil.With(labels).Set(float64(int64(st.ValidUntil) - int64(s.BlockTime)))
+		if at, ok := c.configSet[addr]; ok {
+			m.configSetAge.With(labels).Set(float64(s.BlockTime - at))
+		}
+	}
+	callOpts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(s.BlockNumber)}
+	for addr, st := range s.Timelocks {
+		for role, members := range st.Roles {
+			m.roleMembers.WithLabelValues(chainID, addr.Hex(), role).Set(float64(len(members)))
+		}
+		counts, err := c.countOperations(callOpts, addr, s.BlockTime)
+		if err != nil {
+			return fmt.Errorf("timelock %s: %w", addr, err)
+		}
+		for _, status := range []timelock.Status{timelock.StatusPending, timelock.StatusReady} {
+			m.operations.WithLabelValues(chainID, addr.Hex(), string(status)).Set(float64(counts[status]))
+		}
+	}
+	return nil
+}
+
+// scan reads the ConfigSet and CallScheduled events from c.next up to end.
+func (c *polledChain) scan(ctx context.Context, end uint64) error {
+	for start := c.next; start <= end; start += c.BatchSize {
+		stop := start + c.BatchSize - 1
+		if stop > end {
+			stop = end
+		}
+		opts := &bind.FilterOpts{Start: start, End: &stop, Context: ctx}
+		for _, addr := range c.Deployment.MultiSigs {
+			if err := c.scanConfigSet(ctx, opts, addr); err != nil {
+				return fmt.Errorf("fetching ConfigSet logs in blocks [%d, %d]: %w", start, stop, err)
+			}
+		}
+		for _, addr := range c.Deployment.Timelocks {
+			if err := c.scanScheduled(opts, addr); err != nil {
+				return fmt.Errorf("fetching CallScheduled logs in blocks [%d, %d]: %w", start, stop, err)
+			}
+		}
+		c.next = stop + 1
+	}
+	return nil
+}
+
+func (c *polledChain) scanConfigSet(ctx context.Context, opts *bind.FilterOpts, addr common.Address) error {
+	filterer, err := gethwrappers.NewManyChainMultiSigFilterer(addr, c.Backend)
+	if err != nil {
+		return err
+	}
+	it, err := filterer.FilterConfigSet(opts)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	var last *types.Log
+	for it.Next() {
+		last = &it.Event.Raw
+	}
+	if err := it.Error(); err != nil || last == nil {
+		return err
+	}
+	header, err := c.Backend.HeaderByNumber(ctx, new(big.Int).SetUint64(last.BlockNumber))
+	if err != nil {
+		return err
+	}
+	c.configSet[addr] = header.Time
+	return nil
+}
+
+func (c *polledChain) scanScheduled(opts *bind.FilterOpts, addr common.Address) error {
+	filterer, err := gethwrappers.NewRBACTimelockFilterer(addr, c.Backend)
+	if err != nil {
+		return err
+	}
+	it, err := filterer.FilterCallScheduled(opts, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	open := c.open[addr]
+	if open == nil {
+		open = make(map[common.Hash]bool)
+		c.open[addr] = open
+	}
+	for it.Next() {
+		open[it.Event.Id] = true
+	}
+	return it.Error()
+}
+
+// countOperations counts the open operations of the timelock at addr by
+// status at block time now, and forgets those that are done or cancelled.
+func (c *polledChain) countOperations(opts *bind.CallOpts, addr common.Address, now uint64) (map[timelock.Status]int, error) {
+	caller, err := gethwrappers.NewRBACTimelockCaller(addr, c.Backend)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[timelock.Status]int)
+	for id := range c.open[addr] {
+		timestamp, err := caller.GetTimestamp(opts, id)
+		if err != nil {
+			return nil, err
+		}
+		status := timelock.StatusAt(timestamp, now)
+		switch status {
+		case timelock.StatusPending, timelock.StatusReady:
+			counts[status]++
+		default:
+			// Executed, or cancelled. A cancelled operation scheduled
+			// again shows up in a later CallScheduled event.
+			delete(c.open[addr], id)
+		}
+	}
+	return counts, nil
+}
+
+// Run polls every interval until ctx is done. Failed polls are reported to
+// onError, if set, and counted, but do not stop the exporter.
+func (e *Exporter) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := e.Poll(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
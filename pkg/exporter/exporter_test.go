@@ -0,0 +1,151 @@
+package exporter_test
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/exporter"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/inspect"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+// value returns the value of the metric name with labels, failing if there
+// is none.
+func value(t *testing.T, registry *prometheus.Registry, name string, labels map[string]string) float64 {
+	t.Helper()
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if want, ok := labels[l.GetName()]; ok && want != l.GetValue() {
+					continue metrics
+				}
+			}
+			if m.GetGauge() != nil {
+				return m.GetGauge().GetValue()
+			}
+			return m.GetCounter().GetValue()
+		}
+	}
+	t.Fatalf("no metric %s%v", name, labels)
+	return 0
+}
+
+func TestExporter(t *testing.T) {
+	ctx := context.Background()
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	ex, err := exporter.New([]exporter.Chain{{
+		ChainID: harness.ChainID,
+		Backend: e.Backend,
+		Deployment: &inspect.Deployment{
+			MultiSigs: []common.Address{e.Proposer.Address},
+			Timelocks: []common.Address{e.TimelockAddress},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := ex.Registry()
+	chainID := harness.ChainID.String()
+	multiSig := map[string]string{"chain_id": chainID, "contract": e.Proposer.Address.Hex()}
+	operations := func(status timelock.Status) float64 {
+		return value(t, registry, "timelock_operations", map[string]string{"chain_id": chainID, "contract": e.TimelockAddress.Hex(), "status": string(status)})
+	}
+
+	// The first proposal is executed in full; of the second, only the
+	// first of two ops.
+	e.Submit(e.NewProposal(e.Proposer, e.ScheduleOp(&timelock.Batch{Calls: []timelock.Call{
+		{Target: e.TimelockAddress, Data: common.FromHex("0x01")},
+	}})))
+	p := e.NewProposal(e.Proposer,
+		e.ScheduleOp(&timelock.Batch{Calls: []timelock.Call{{Target: e.TimelockAddress, Data: common.FromHex("0x02")}}}),
+		e.ScheduleOp(&timelock.Batch{Calls: []timelock.Call{{Target: e.TimelockAddress, Data: common.FromHex("0x03")}}}),
+	)
+	e.SetRoot(p)
+	args, err := p.ExecuteArgs(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Send(e.Proposer.Contract.Execute(e.Deployer.Opts, args[0].Op, args[0].Proof))
+
+	if err := ex.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := value(t, registry, "mcms_op_count", multiSig); got != 2 {
+		t.Errorf("op count %v, want 2", got)
+	}
+	if got := value(t, registry, "mcms_root_ops_remaining", multiSig); got != 1 {
+		t.Errorf("ops remaining %v, want 1", got)
+	}
+	if got, want := value(t, registry, "mcms_root_valid_until_seconds", multiSig), float64(int64(p.ValidUntil)-e.Now().Unix()); got != want {
+		t.Errorf("seconds until validUntil %v, want %v", got, want)
+	}
+	// The harness sets the config at deployment.
+	if got := value(t, registry, "mcms_config_set_age_seconds", multiSig); got <= 0 {
+		t.Errorf("config set age %v, want more than 0", got)
+	}
+	if got := value(t, registry, "timelock_role_members", map[string]string{"contract": e.TimelockAddress.Hex(), "role": timelock.ExecutorRole.Name}); got != 1 {
+		t.Errorf("executors %v, want 1", got)
+	}
+	if pending, ready := operations(timelock.StatusPending), operations(timelock.StatusReady); pending != 2 || ready != 0 {
+		t.Errorf("pending %v, ready %v; want 2 and 0", pending, ready)
+	}
+
+	e.AdvanceTime(time.Hour)
+	if err := ex.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if pending, ready := operations(timelock.StatusPending), operations(timelock.StatusReady); pending != 0 || ready != 2 {
+		t.Errorf("after the delay pending %v, ready %v; want 0 and 2", pending, ready)
+	}
+
+	srv := httptest.NewServer(ex.Handler())
+	defer srv.Close()
+	resp, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `mcms_op_count{chain_id="` + chainID + `",contract="` + e.Proposer.Address.Hex() + `"} 2`
+	if !strings.Contains(string(body), want) {
+		t.Errorf("metrics do not contain %s:\n%s", want, body)
+	}
+}
+
+func TestPollError(t *testing.T) {
+	e := harness.New(t, harness.Options{})
+	ex, err := exporter.New([]exporter.Chain{{
+		ChainID:    big.NewInt(5),
+		Backend:    e.Backend,
+		Deployment: &inspect.Deployment{MultiSigs: []common.Address{{0x01}}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ex.Poll(context.Background()); err == nil {
+		t.Fatal("polling a contract without code succeeded")
+	}
+	if got := value(t, ex.Registry(), "owner_exporter_poll_errors_total", map[string]string{"chain_id": "5"}); got != 1 {
+		t.Errorf("poll errors %v, want 1", got)
+	}
+}
@@ -0,0 +1,63 @@
+package fake
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+)
+
+// callProxyABI is the parsed ABI of CallProxy.
+var callProxyABI, _ = gethwrappers.CallProxyMetaData.GetAbi()
+
+var _ gethwrappers.CallProxyInterface = (*CallProxy)(nil)
+
+// CallProxy is an in-memory CallProxy. Every call is forwarded to the target,
+// and a revert of the target is the revert of the call.
+type CallProxy struct {
+	*gethwrappers.CallProxyFilterer
+
+	chain   *Chain
+	address common.Address
+	target  common.Address
+}
+
+// DeployCallProxy deploys a CallProxy forwarding to target.
+func DeployCallProxy(auth *bind.TransactOpts, chain *Chain, target common.Address) (common.Address, *types.Transaction, *CallProxy, error) {
+	var p *CallProxy
+	address, tx, err := chain.deploy(auth, func(address common.Address) (contract, error) {
+		filterer, err := gethwrappers.NewCallProxyFilterer(address, chain)
+		if err != nil {
+			return nil, err
+		}
+		p = &CallProxy{CallProxyFilterer: filterer, chain: chain, address: address, target: target}
+		chain.emit(address, callProxyABI, "TargetSet", target)
+		return p, nil
+	})
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, p, nil
+}
+
+// Address returns the address of the contract.
+func (p *CallProxy) Address() common.Address {
+	return p.address
+}
+
+// Fallback sends calldata, which the proxy forwards to its target.
+func (p *CallProxy) Fallback(opts *bind.TransactOpts, calldata []byte) (*types.Transaction, error) {
+	return p.chain.transact(opts, p.address, calldata)
+}
+
+func (p *CallProxy) call(from common.Address, value *big.Int, data []byte) error {
+	return p.chain.call(p.address, p.target, value, data)
+}
+
+// snapshot returns a no-op; the target is immutable.
+func (p *CallProxy) snapshot() func() {
+	return func() {}
+}
@@ -0,0 +1,454 @@
+// Package fake provides in-memory implementations of the contract interfaces
+// in gethwrappers. The fakes keep the contracts' state in Go, enforce the same
+// checks with the same revert reasons, and emit ABI-encoded logs, which makes
+// them suitable for fast unit tests of tooling that would otherwise need a
+// simulated backend.
+//
+// All fakes of one test live on a Chain. Every successful transaction is
+// mined into its own block. Block time only moves when the test calls
+// AdvanceTime, which also mines an empty block.
+//
+// Calls between fakes, e.g. an RBACTimelock executing acceptOwnership on a
+// ManyChainMultiSig or a CallProxy forwarding to its target, are dispatched
+// through the Chain, and a failing transaction leaves no trace. CallOpts are
+// ignored since the fakes keep no history.
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Revert is the revert reason string of a fake contract, e.g.
+// "RBACTimelock: operation is not ready". ManyChainMultiSig's custom errors
+// are the generated gethwrappers types instead, as returned by mcms.Model.
+type Revert string
+
+func (r Revert) Error() string {
+	return string(r)
+}
+
+// contract is implemented by the fakes.
+type contract interface {
+	// call runs calldata sent by from with value attached. It changes state
+	// and emits logs through the Chain.
+	call(from common.Address, value *big.Int, data []byte) error
+	// snapshot returns a function restoring the current state.
+	snapshot() func()
+}
+
+// Chain is the in-memory blockchain the fakes are deployed on. It implements
+// bind.ContractFilterer and bind.DeployBackend over the fakes' logs and
+// transactions, so the generated filterers and bind.WaitMined work unchanged.
+type Chain struct {
+	mu sync.Mutex
+
+	chainID   *big.Int
+	headers   []*types.Header
+	time      uint64
+	nonces    map[common.Address]uint64
+	contracts map[common.Address]contract
+	logs      []types.Log
+	receipts  map[common.Hash]*types.Receipt
+	subs      map[*subscription]bool
+
+	// pending collects the logs of the transaction being run.
+	pending []types.Log
+}
+
+// NewChain returns an empty chain with the given chain id whose genesis block
+// has timestamp start.
+func NewChain(chainID *big.Int, start time.Time) *Chain {
+	c := &Chain{
+		chainID:   new(big.Int).Set(chainID),
+		time:      uint64(start.Unix()),
+		nonces:    make(map[common.Address]uint64),
+		contracts: make(map[common.Address]contract),
+		receipts:  make(map[common.Hash]*types.Receipt),
+		subs:      make(map[*subscription]bool),
+	}
+	c.headers = []*types.Header{{Number: new(big.Int), Time: c.time, Difficulty: new(big.Int)}}
+	return c
+}
+
+// ChainID returns the chain id.
+func (c *Chain) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(c.chainID), nil
+}
+
+// Now returns the block time, i.e. the time of the latest block and of the
+// blocks mined next.
+func (c *Chain) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Unix(int64(c.time), 0)
+}
+
+// AdvanceTime moves the block time forward by d and mines an empty block, so
+// that reads of the latest block see the new time.
+func (c *Chain) AdvanceTime(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.time += uint64(d / time.Second)
+	c.appendHeader(common.Hash{})
+}
+
+// HeaderByNumber returns the header of the given block, or of the latest one
+// if number is nil.
+func (c *Chain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number == nil {
+		return types.CopyHeader(c.headers[len(c.headers)-1]), nil
+	}
+	if !number.IsUint64() || number.Uint64() >= uint64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return types.CopyHeader(c.headers[number.Uint64()]), nil
+}
+
+// CodeAt returns a placeholder for the code of fake contracts, which is all
+// bind.WaitDeployed needs.
+func (c *Chain) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.contracts[account]; ok {
+		return []byte{0xfe}, nil
+	}
+	return nil, nil
+}
+
+// TransactionReceipt returns the receipt of a transaction sent to a fake.
+func (c *Chain) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	receipt, ok := c.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+// FilterLogs returns the logs matching q.
+func (c *Chain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	from, to := uint64(0), uint64(len(c.headers)-1)
+	if q.BlockHash != nil {
+		found := false
+		for _, h := range c.headers {
+			if h.Hash() == *q.BlockHash {
+				from, to, found = h.Number.Uint64(), h.Number.Uint64(), true
+			}
+		}
+		if !found {
+			return nil, ethereum.NotFound
+		}
+	} else {
+		if q.FromBlock != nil {
+			from = q.FromBlock.Uint64()
+		}
+		if q.ToBlock != nil && q.ToBlock.Sign() >= 0 {
+			to = q.ToBlock.Uint64()
+		}
+	}
+	var logs []types.Log
+	for _, l := range c.logs {
+		if l.BlockNumber >= from && l.BlockNumber <= to && matches(q, &l) {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+// SubscribeFilterLogs delivers the logs matching q that are emitted from now
+// on.
+func (c *Chain) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	sub := &subscription{query: q, notify: make(chan struct{}, 1)}
+	c.mu.Lock()
+	c.subs[sub] = true
+	c.mu.Unlock()
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer func() {
+			c.mu.Lock()
+			delete(c.subs, sub)
+			c.mu.Unlock()
+		}()
+		for {
+			select {
+			case <-quit:
+				return nil
+			case <-sub.notify:
+			}
+			c.mu.Lock()
+			queue := sub.queue
+			sub.queue = nil
+			c.mu.Unlock()
+			for _, l := range queue {
+				select {
+				case ch <- l:
+				case <-quit:
+					return nil
+				}
+			}
+		}
+	}), nil
+}
+
+// subscription queues logs for a SubscribeFilterLogs caller, so that mining
+// never blocks on a slow reader.
+type subscription struct {
+	query  ethereum.FilterQuery
+	queue  []types.Log
+	notify chan struct{}
+}
+
+func matches(q ethereum.FilterQuery, l *types.Log) bool {
+	if len(q.Addresses) > 0 {
+		found := false
+		for _, a := range q.Addresses {
+			found = found || a == l.Address
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(q.Topics) > len(l.Topics) {
+		return false
+	}
+	for i, alternatives := range q.Topics {
+		if len(alternatives) == 0 {
+			continue
+		}
+		found := false
+		for _, t := range alternatives {
+			found = found || t == l.Topics[i]
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// deploy registers the contract create returns. The address passed to create
+// is derived from opts.From's nonce like CREATE does, and the deployment is
+// mined with the logs create emits.
+func (c *Chain) deploy(opts *bind.TransactOpts, create func(address common.Address) (contract, error)) (common.Address, *types.Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	address := crypto.CreateAddress(opts.From, c.nonces[opts.From])
+	created, err := create(address)
+	if err != nil {
+		c.pending = nil
+		return common.Address{}, nil, err
+	}
+	tx, err := c.mine(opts, nil, nil)
+	if err != nil {
+		c.pending = nil
+		return common.Address{}, nil, err
+	}
+	c.contracts[address] = created
+	c.receipts[tx.Hash()].ContractAddress = address
+	return address, tx, nil
+}
+
+// transact runs a transaction from opts.From to a fake. If it fails, all
+// fakes are restored and no block is mined.
+func (c *Chain) transact(opts *bind.TransactOpts, to common.Address, data []byte) (*types.Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	restore := c.snapshot()
+	if err := c.call(opts.From, to, opts.Value, data); err != nil {
+		restore()
+		return nil, err
+	}
+	if opts.NoSend {
+		tx, err := c.newTx(opts, &to, data)
+		restore()
+		return tx, err
+	}
+	tx, err := c.mine(opts, &to, data)
+	if err != nil {
+		restore()
+	}
+	return tx, err
+}
+
+// call runs data on the fake at to. Calls to addresses without a fake
+// succeed without effect, like calls to accounts without code.
+func (c *Chain) call(from, to common.Address, value *big.Int, data []byte) error {
+	target, ok := c.contracts[to]
+	if !ok {
+		return nil
+	}
+	if value == nil {
+		value = new(big.Int)
+	}
+	return target.call(from, value, data)
+}
+
+func (c *Chain) snapshot() func() {
+	restores := make([]func(), 0, len(c.contracts))
+	for _, target := range c.contracts {
+		restores = append(restores, target.snapshot())
+	}
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+		c.pending = nil
+	}
+}
+
+// emit appends a log of the named event to the pending transaction. args are
+// the event's inputs in declaration order.
+func (c *Chain) emit(address common.Address, contractABI *abi.ABI, name string, args ...interface{}) {
+	ev := contractABI.Events[name]
+	topics := []common.Hash{ev.ID}
+	var data []interface{}
+	for i, input := range ev.Inputs {
+		if !input.Indexed {
+			data = append(data, args[i])
+			continue
+		}
+		indexed, err := abi.MakeTopics([]interface{}{args[i]})
+		if err != nil {
+			panic(fmt.Sprintf("fake: encoding %s.%s: %v", name, input.Name, err))
+		}
+		topics = append(topics, indexed[0][0])
+	}
+	packed, err := ev.Inputs.NonIndexed().Pack(data...)
+	if err != nil {
+		panic(fmt.Sprintf("fake: encoding %s: %v", name, err))
+	}
+	c.pending = append(c.pending, types.Log{Address: address, Topics: topics, Data: packed})
+}
+
+func (c *Chain) newTx(opts *bind.TransactOpts, to *common.Address, data []byte) (*types.Transaction, error) {
+	nonce := c.nonces[opts.From]
+	if opts.Nonce != nil {
+		nonce = opts.Nonce.Uint64()
+	}
+	value := opts.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	tx := types.NewTx(&types.LegacyTx{Nonce: nonce, To: to, Value: value, GasPrice: new(big.Int), Data: data})
+	if opts.Signer == nil {
+		return tx, nil
+	}
+	return opts.Signer(opts.From, tx)
+}
+
+// mine puts the pending logs into a new block together with the transaction.
+func (c *Chain) mine(opts *bind.TransactOpts, to *common.Address, data []byte) (*types.Transaction, error) {
+	tx, err := c.newTx(opts, to, data)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := c.receipts[tx.Hash()]; ok {
+		return nil, errors.New("fake: transaction already mined")
+	}
+	c.nonces[opts.From] = tx.Nonce() + 1
+
+	header := c.appendHeader(tx.Hash())
+	blockHash := header.Hash()
+
+	receipt := &types.Receipt{
+		Type:        tx.Type(),
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      tx.Hash(),
+		BlockHash:   blockHash,
+		BlockNumber: header.Number,
+	}
+	for i := range c.pending {
+		l := &c.pending[i]
+		l.BlockNumber = header.Number.Uint64()
+		l.BlockHash = blockHash
+		l.TxHash = tx.Hash()
+		l.Index = uint(len(c.logs))
+		c.logs = append(c.logs, *l)
+		receiptLog := *l
+		receipt.Logs = append(receipt.Logs, &receiptLog)
+		for sub := range c.subs {
+			if matches(sub.query, l) {
+				sub.queue = append(sub.queue, *l)
+				select {
+				case sub.notify <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+	c.pending = nil
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	c.receipts[tx.Hash()] = receipt
+	return tx, nil
+}
+
+// appendHeader mines a block at the current block time. txHash stands in for
+// the transaction root, which keeps block hashes unique.
+func (c *Chain) appendHeader(txHash common.Hash) *types.Header {
+	parent := c.headers[len(c.headers)-1]
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Time:       c.time,
+		Difficulty: new(big.Int),
+		TxHash:     txHash,
+	}
+	c.headers = append(c.headers, header)
+	return header
+}
+
+// callData packs a call of method for a transactor method.
+func callData(contractABI *abi.ABI, method string, args ...interface{}) []byte {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		panic(fmt.Sprintf("fake: encoding %s: %v", method, err))
+	}
+	return data
+}
+
+// unpackCall decodes calldata into the method it calls and its arguments.
+// Empty calldata yields a nil method, i.e. a call of the receive function.
+// Calldata matching no method reverts without a reason, as it would on a
+// contract without a fallback function. CallProxy, whose fallback forwards
+// any calldata, does not decode its calls.
+func unpackCall(contractABI *abi.ABI, data []byte) (*abi.Method, []interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil, nil
+	}
+	if len(data) < 4 {
+		return nil, nil, Revert("")
+	}
+	method, err := contractABI.MethodById(data[:4])
+	if err != nil {
+		return nil, nil, Revert("")
+	}
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, Revert("")
+	}
+	return method, values, nil
+}
+
+// copyArgs copies the unpacked arguments of method into the struct args.
+func copyArgs(method *abi.Method, values []interface{}, args interface{}) {
+	if err := method.Inputs.Copy(args, values); err != nil {
+		panic(fmt.Sprintf("fake: decoding %s: %v", method.Name, err))
+	}
+}
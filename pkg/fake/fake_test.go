@@ -0,0 +1,241 @@
+package fake_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/fake"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+const minDelay = time.Hour
+
+type stack struct {
+	chain    *fake.Chain
+	deployer *harness.Account
+	signers  []*harness.Account
+
+	multiSig  *fake.ManyChainMultiSig
+	timelock  *fake.RBACTimelock
+	callProxy *fake.CallProxy
+}
+
+// newStack deploys a proposer multisig, a timelock and a CallProxy executor.
+// The timelock administers itself; the deployer keeps the admin role too and
+// owns the multisig.
+func newStack(t *testing.T) *stack {
+	accounts := harness.GenerateSigners(t, 3)
+	s := &stack{
+		chain:    fake.NewChain(harness.ChainID, time.Unix(1_700_000_000, 0)),
+		deployer: accounts[0],
+		signers:  accounts[1:],
+	}
+	var err error
+	_, _, s.multiSig, err = fake.DeployManyChainMultiSig(s.deployer.Opts, s.chain)
+	check(t, err)
+	_, _, s.timelock, err = fake.DeployRBACTimelock(s.deployer.Opts, s.chain, big.NewInt(int64(minDelay/time.Second)),
+		s.deployer.Address, []common.Address{s.multiSig.Address()}, nil, nil, nil)
+	check(t, err)
+	_, _, s.callProxy, err = fake.DeployCallProxy(s.deployer.Opts, s.chain, s.timelock.Address())
+	check(t, err)
+	_, err = s.timelock.GrantRole(s.deployer.Opts, timelock.ExecutorRole.ID, s.callProxy.Address())
+	check(t, err)
+	_, err = s.timelock.GrantRole(s.deployer.Opts, timelock.AdminRole.ID, s.timelock.Address())
+	check(t, err)
+
+	_, err = s.multiSig.SetConfig(s.deployer.Opts, harness.SignerAddresses(s.signers), []uint8{0, 0},
+		[32]uint8{2}, [32]uint8{}, false)
+	check(t, err)
+	return s
+}
+
+func (s *stack) proposal(t *testing.T, ops ...mcms.Operation) *mcms.Proposal {
+	ctx := context.Background()
+	opCount, err := s.multiSig.GetOpCount(nil)
+	check(t, err)
+	chainID := harness.ChainID
+	p := &mcms.Proposal{
+		ValidUntil: uint32(s.chain.Now().Add(24 * time.Hour).Unix()),
+		Chains:     []mcms.ChainMetadata{{ChainID: chainID, MultiSig: s.multiSig.Address(), PreOpCount: opCount.Uint64()}},
+	}
+	for _, op := range ops {
+		op.ChainID, op.MultiSig = chainID, s.multiSig.Address()
+		p.Ops = append(p.Ops, op)
+	}
+	root, err := p.Root()
+	check(t, err)
+	for _, a := range s.signers {
+		sig, err := signer.SignRoot(ctx, a.Signer, root, p.ValidUntil)
+		check(t, err)
+		_, err = p.AddSignature(signer.FromGethSignature(sig))
+		check(t, err)
+	}
+	return p
+}
+
+func TestProposeScheduleExecute(t *testing.T) {
+	s := newStack(t)
+	ctx := context.Background()
+
+	batch := &timelock.Batch{Calls: []timelock.Call{{
+		Target: s.timelock.Address(),
+		Data:   pack(t, "updateDelay", big.NewInt(7200)),
+	}}}
+	p := s.proposal(t, mcms.Operation{
+		To:   s.timelock.Address(),
+		Data: pack(t, "scheduleBatch", batch.RBACTimelockCalls(), batch.Predecessor, batch.Salt, big.NewInt(int64(minDelay/time.Second))),
+	})
+	setRoot, err := p.SetRootArgs(0)
+	check(t, err)
+	tx, err := s.multiSig.SetRoot(s.deployer.Opts, setRoot.Root, setRoot.ValidUntil, setRoot.Metadata, setRoot.MetadataProof, setRoot.Signatures)
+	check(t, err)
+	receipt, err := bind.WaitMined(ctx, s.chain, tx)
+	check(t, err)
+	newRoot, err := s.multiSig.ParseNewRoot(*receipt.Logs[0])
+	check(t, err)
+	if newRoot.Root != setRoot.Root {
+		t.Fatalf("NewRoot root = %x, want %x", newRoot.Root, setRoot.Root)
+	}
+	_, err = s.multiSig.SetRoot(s.deployer.Opts, setRoot.Root, setRoot.ValidUntil, setRoot.Metadata, setRoot.MetadataProof, setRoot.Signatures)
+	if !errors.Is(err, &gethwrappers.ManyChainMultiSigSignedHashAlreadySeen{}) {
+		t.Fatalf("replayed setRoot: got %v", err)
+	}
+
+	executed := make(chan *gethwrappers.ManyChainMultiSigOpExecuted, 1)
+	sub, err := s.multiSig.WatchOpExecuted(&bind.WatchOpts{}, executed, nil)
+	check(t, err)
+	defer sub.Unsubscribe()
+
+	executeArgs, err := p.ExecuteArgs(0)
+	check(t, err)
+	_, err = s.multiSig.Execute(s.deployer.Opts, executeArgs[0].Op, executeArgs[0].Proof)
+	check(t, err)
+	select {
+	case ev := <-executed:
+		if ev.Nonce.Sign() != 0 || ev.To != s.timelock.Address() {
+			t.Fatalf("OpExecuted = %+v", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no OpExecuted event")
+	}
+
+	id, err := batch.ID()
+	check(t, err)
+	ts, err := s.timelock.GetTimestamp(nil, id)
+	check(t, err)
+	if want := s.chain.Now().Add(minDelay).Unix(); ts.Int64() != want {
+		t.Fatalf("timestamp = %v, want %d", ts, want)
+	}
+	scheduled, err := s.timelock.FilterCallScheduled(&bind.FilterOpts{}, [][32]byte{id}, nil)
+	check(t, err)
+	if !scheduled.Next() || scheduled.Event.Target != s.timelock.Address() {
+		t.Fatal("no CallScheduled event")
+	}
+
+	executeBatch := pack(t, "executeBatch", batch.RBACTimelockCalls(), batch.Predecessor, batch.Salt)
+	anyone := harness.GenerateSigners(t, 1)[0]
+	if _, err := s.callProxy.Fallback(anyone.Opts, executeBatch); !errors.Is(err, fake.ErrNotReady) {
+		t.Fatalf("early execution: got %v", err)
+	}
+	if _, err := s.timelock.ExecuteBatch(anyone.Opts, batch.RBACTimelockCalls(), batch.Predecessor, batch.Salt); err == nil {
+		t.Fatal("executeBatch without the executor role succeeded")
+	}
+	s.chain.AdvanceTime(minDelay)
+	_, err = s.callProxy.Fallback(anyone.Opts, executeBatch)
+	check(t, err)
+	delay, err := s.timelock.GetMinDelay(nil)
+	check(t, err)
+	if delay.Int64() != 7200 {
+		t.Fatalf("minDelay = %v, want 7200", delay)
+	}
+	if done, _ := s.timelock.IsOperationDone(nil, id); !done {
+		t.Fatal("operation not done")
+	}
+}
+
+func TestFailedTransactionsLeaveNoTrace(t *testing.T) {
+	s := newStack(t)
+	head, err := s.chain.HeaderByNumber(context.Background(), nil)
+	check(t, err)
+
+	// The second call reverts, which must undo the first one.
+	calls := []gethwrappers.RBACTimelockCall{
+		{Target: s.timelock.Address(), Value: new(big.Int), Data: pack(t, "updateDelay", big.NewInt(1))},
+		{Target: s.timelock.Address(), Value: new(big.Int), Data: pack(t, "cancel", [32]byte{1})},
+	}
+	if _, err := s.timelock.BypasserExecuteBatch(s.deployer.Opts, calls); !errors.Is(err, fake.ErrUnderlyingReverted) {
+		t.Fatalf("got %v", err)
+	}
+	delay, err := s.timelock.GetMinDelay(nil)
+	check(t, err)
+	if delay.Int64() != int64(minDelay/time.Second) {
+		t.Fatalf("minDelay = %v after a reverted batch", delay)
+	}
+	changes, err := s.timelock.FilterMinDelayChange(&bind.FilterOpts{Start: head.Number.Uint64() + 1})
+	check(t, err)
+	if changes.Next() {
+		t.Fatalf("MinDelayChange logged by a reverted batch: %+v", changes.Event)
+	}
+
+	admins, err := timelock.RoleMembers(nil, s.timelock, timelock.AdminRole)
+	check(t, err)
+	if len(admins) != 2 || admins[0] != s.deployer.Address || admins[1] != s.timelock.Address() {
+		t.Fatalf("admins = %v", admins)
+	}
+}
+
+func TestOwnershipTransfer(t *testing.T) {
+	s := newStack(t)
+	_, err := s.multiSig.TransferOwnership(s.deployer.Opts, s.timelock.Address())
+	check(t, err)
+	if _, err := s.multiSig.AcceptOwnership(s.deployer.Opts); err == nil {
+		t.Fatal("acceptOwnership by the old owner succeeded")
+	}
+	_, err = s.timelock.GrantRole(s.deployer.Opts, timelock.BypasserRole.ID, s.deployer.Address)
+	check(t, err)
+	accept, err := mcms.ManyChainMultiSigABI.Pack("acceptOwnership")
+	check(t, err)
+	tx, err := s.timelock.BypasserExecuteBatch(s.deployer.Opts, []gethwrappers.RBACTimelockCall{
+		{Target: s.multiSig.Address(), Value: new(big.Int), Data: accept},
+	})
+	check(t, err)
+	receipt, err := s.chain.TransactionReceipt(context.Background(), tx.Hash())
+	check(t, err)
+	if receipt.Status != types.ReceiptStatusSuccessful || len(receipt.Logs) != 2 {
+		t.Fatalf("receipt = %+v", receipt)
+	}
+	owner, err := s.multiSig.Owner(nil)
+	check(t, err)
+	if owner != s.timelock.Address() {
+		t.Fatalf("owner = %s", owner)
+	}
+	if _, err := s.multiSig.SetConfig(s.deployer.Opts, harness.SignerAddresses(s.signers), []uint8{0, 0},
+		[32]uint8{1}, [32]uint8{}, false); err == nil {
+		t.Fatal("setConfig by the old owner succeeded")
+	}
+}
+
+func pack(t *testing.T, method string, args ...interface{}) []byte {
+	t.Helper()
+	data, err := timelock.RBACTimelockABI.Pack(method, args...)
+	check(t, err)
+	return data
+}
+
+func check(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
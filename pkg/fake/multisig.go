@@ -0,0 +1,243 @@
+package fake
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+// The reasons Ownable2Step reverts with.
+const (
+	errNotOwner    Revert = "Ownable: caller is not the owner"
+	errNotNewOwner Revert = "Ownable2Step: caller is not the new owner"
+)
+
+var _ gethwrappers.ManyChainMultiSigInterface = (*ManyChainMultiSig)(nil)
+
+// ManyChainMultiSig is an in-memory ManyChainMultiSig. The setConfig, setRoot
+// and execute checks are those of mcms.Model; executed ops are dispatched to
+// the fake at op.To, and a failing call reverts with CallReverted.
+type ManyChainMultiSig struct {
+	*gethwrappers.ManyChainMultiSigFilterer
+
+	chain   *Chain
+	address common.Address
+
+	model        *mcms.Model
+	owner        common.Address
+	pendingOwner common.Address
+}
+
+// DeployManyChainMultiSig deploys a ManyChainMultiSig owned by auth.From.
+func DeployManyChainMultiSig(auth *bind.TransactOpts, chain *Chain) (common.Address, *types.Transaction, *ManyChainMultiSig, error) {
+	var m *ManyChainMultiSig
+	address, tx, err := chain.deploy(auth, func(address common.Address) (contract, error) {
+		filterer, err := gethwrappers.NewManyChainMultiSigFilterer(address, chain)
+		if err != nil {
+			return nil, err
+		}
+		m = &ManyChainMultiSig{
+			ManyChainMultiSigFilterer: filterer,
+			chain:                     chain,
+			address:                   address,
+			model:                     mcms.NewModel(chain.chainID, address),
+		}
+		m.transferOwnership(auth.From)
+		return m, nil
+	})
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, m, nil
+}
+
+// Address returns the address of the contract.
+func (m *ManyChainMultiSig) Address() common.Address {
+	return m.address
+}
+
+// MAXNUMSIGNERS returns MAX_NUM_SIGNERS.
+func (m *ManyChainMultiSig) MAXNUMSIGNERS(opts *bind.CallOpts) (uint8, error) {
+	return mcms.MaxNumSigners, nil
+}
+
+// NUMGROUPS returns NUM_GROUPS.
+func (m *ManyChainMultiSig) NUMGROUPS(opts *bind.CallOpts) (uint8, error) {
+	return mcms.NumGroups, nil
+}
+
+// GetConfig returns the current config.
+func (m *ManyChainMultiSig) GetConfig(opts *bind.CallOpts) (gethwrappers.ManyChainMultiSigConfig, error) {
+	m.chain.mu.Lock()
+	defer m.chain.mu.Unlock()
+	config := m.model.Config
+	config.Signers = append([]gethwrappers.ManyChainMultiSigSigner{}, config.Signers...)
+	return config, nil
+}
+
+// GetOpCount returns the current op count.
+func (m *ManyChainMultiSig) GetOpCount(opts *bind.CallOpts) (*big.Int, error) {
+	m.chain.mu.Lock()
+	defer m.chain.mu.Unlock()
+	return new(big.Int).SetUint64(m.model.OpCount), nil
+}
+
+// GetRoot returns the current root and its expiry.
+func (m *ManyChainMultiSig) GetRoot(opts *bind.CallOpts) (struct {
+	Root       [32]byte
+	ValidUntil uint32
+}, error) {
+	m.chain.mu.Lock()
+	defer m.chain.mu.Unlock()
+	return struct {
+		Root       [32]byte
+		ValidUntil uint32
+	}{m.model.Root, m.model.ValidUntil}, nil
+}
+
+// GetRootMetadata returns the metadata of the current root.
+func (m *ManyChainMultiSig) GetRootMetadata(opts *bind.CallOpts) (gethwrappers.ManyChainMultiSigRootMetadata, error) {
+	m.chain.mu.Lock()
+	defer m.chain.mu.Unlock()
+	return m.model.RootMetadata, nil
+}
+
+// Owner returns the owner.
+func (m *ManyChainMultiSig) Owner(opts *bind.CallOpts) (common.Address, error) {
+	m.chain.mu.Lock()
+	defer m.chain.mu.Unlock()
+	return m.owner, nil
+}
+
+// PendingOwner returns the pending owner.
+func (m *ManyChainMultiSig) PendingOwner(opts *bind.CallOpts) (common.Address, error) {
+	m.chain.mu.Lock()
+	defer m.chain.mu.Unlock()
+	return m.pendingOwner, nil
+}
+
+// AcceptOwnership sends acceptOwnership.
+func (m *ManyChainMultiSig) AcceptOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return m.transact(opts, "acceptOwnership")
+}
+
+// Execute sends execute.
+func (m *ManyChainMultiSig) Execute(opts *bind.TransactOpts, op gethwrappers.ManyChainMultiSigOp, proof [][32]byte) (*types.Transaction, error) {
+	return m.transact(opts, "execute", op, proof)
+}
+
+// RenounceOwnership sends renounceOwnership.
+func (m *ManyChainMultiSig) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return m.transact(opts, "renounceOwnership")
+}
+
+// SetConfig sends setConfig.
+func (m *ManyChainMultiSig) SetConfig(opts *bind.TransactOpts, signerAddresses []common.Address, signerGroups []uint8, groupQuorums [32]uint8, groupParents [32]uint8, clearRoot bool) (*types.Transaction, error) {
+	return m.transact(opts, "setConfig", signerAddresses, signerGroups, groupQuorums, groupParents, clearRoot)
+}
+
+// SetRoot sends setRoot.
+func (m *ManyChainMultiSig) SetRoot(opts *bind.TransactOpts, root [32]byte, validUntil uint32, metadata gethwrappers.ManyChainMultiSigRootMetadata, metadataProof [][32]byte, signatures []gethwrappers.ManyChainMultiSigSignature) (*types.Transaction, error) {
+	return m.transact(opts, "setRoot", root, validUntil, metadata, metadataProof, signatures)
+}
+
+// TransferOwnership sends transferOwnership.
+func (m *ManyChainMultiSig) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return m.transact(opts, "transferOwnership", newOwner)
+}
+
+func (m *ManyChainMultiSig) transact(opts *bind.TransactOpts, method string, args ...interface{}) (*types.Transaction, error) {
+	return m.chain.transact(opts, m.address, callData(mcms.ManyChainMultiSigABI, method, args...))
+}
+
+func (m *ManyChainMultiSig) call(from common.Address, value *big.Int, data []byte) error {
+	method, values, err := unpackCall(mcms.ManyChainMultiSigABI, data)
+	if err != nil || method == nil {
+		return err
+	}
+	switch method.Name {
+	case "setConfig":
+		var args struct {
+			SignerAddresses []common.Address
+			SignerGroups    []uint8
+			GroupQuorums    [32]uint8
+			GroupParents    [32]uint8
+			ClearRoot       bool
+		}
+		copyArgs(method, values, &args)
+		if from != m.owner {
+			return errNotOwner
+		}
+		if err := m.model.SetConfig(args.SignerAddresses, args.SignerGroups, args.GroupQuorums, args.GroupParents, args.ClearRoot); err != nil {
+			return err
+		}
+		m.emit("ConfigSet", m.model.Config, args.ClearRoot)
+	case "setRoot":
+		var args mcms.SetRootArgs
+		copyArgs(method, values, &args)
+		if err := m.model.SetRoot(m.chain.time, &args); err != nil {
+			return err
+		}
+		m.emit("NewRoot", args.Root, args.ValidUntil, args.Metadata)
+	case "execute":
+		var args mcms.ExecuteArgs
+		copyArgs(method, values, &args)
+		if err := m.model.Execute(m.chain.time, &args); err != nil {
+			return err
+		}
+		if err := m.chain.call(m.address, args.Op.To, args.Op.Value, args.Op.Data); err != nil {
+			return &gethwrappers.ManyChainMultiSigCallReverted{}
+		}
+		m.emit("OpExecuted", args.Op.Nonce, args.Op.To, args.Op.Data, args.Op.Value)
+	case "transferOwnership":
+		var args struct{ NewOwner common.Address }
+		copyArgs(method, values, &args)
+		if from != m.owner {
+			return errNotOwner
+		}
+		m.pendingOwner = args.NewOwner
+		m.emit("OwnershipTransferStarted", m.owner, args.NewOwner)
+	case "acceptOwnership":
+		if from != m.pendingOwner {
+			return errNotNewOwner
+		}
+		m.transferOwnership(from)
+	case "renounceOwnership":
+		if from != m.owner {
+			return errNotOwner
+		}
+		m.transferOwnership(common.Address{})
+	}
+	return nil
+}
+
+// transferOwnership is Ownable2Step._transferOwnership.
+func (m *ManyChainMultiSig) transferOwnership(newOwner common.Address) {
+	m.pendingOwner = common.Address{}
+	previous := m.owner
+	m.owner = newOwner
+	m.emit("OwnershipTransferred", previous, newOwner)
+}
+
+func (m *ManyChainMultiSig) emit(event string, args ...interface{}) {
+	m.chain.emit(m.address, mcms.ManyChainMultiSigABI, event, args...)
+}
+
+func (m *ManyChainMultiSig) snapshot() func() {
+	model := *m.model
+	seen := make(map[common.Hash]bool, len(model.SeenSignedHashes))
+	for h := range model.SeenSignedHashes {
+		seen[h] = true
+	}
+	owner, pendingOwner := m.owner, m.pendingOwner
+	return func() {
+		*m.model = model
+		m.model.SeenSignedHashes = seen
+		m.owner, m.pendingOwner = owner, pendingOwner
+	}
+}
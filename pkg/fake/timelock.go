@@ -0,0 +1,528 @@
+package fake
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+// The reasons RBACTimelock reverts with, apart from missing roles.
+const (
+	ErrAlreadyScheduled    Revert = "RBACTimelock: operation already scheduled"
+	ErrInsufficientDelay   Revert = "RBACTimelock: insufficient delay"
+	ErrCannotBeCancelled   Revert = "RBACTimelock: operation cannot be cancelled"
+	ErrUnderlyingReverted  Revert = "RBACTimelock: underlying transaction reverted"
+	ErrNotReady            Revert = "RBACTimelock: operation is not ready"
+	ErrMissingDependency   Revert = "RBACTimelock: missing dependency"
+	ErrSelectorBlocked     Revert = "RBACTimelock: selector is blocked"
+	ErrRenounceForSelfOnly Revert = "AccessControl: can only renounce roles for self"
+)
+
+// doneTimestamp is _DONE_TIMESTAMP.
+var doneTimestamp = big.NewInt(1)
+
+// The interfaces RBACTimelock reports in supportsInterface.
+var timelockInterfaces = map[[4]byte]bool{
+	{0x01, 0xff, 0xc9, 0xa7}: true, // IERC165
+	{0x79, 0x65, 0xdb, 0x0b}: true, // IAccessControl
+	{0x5a, 0x05, 0x18, 0x0f}: true, // IAccessControlEnumerable
+	{0x4e, 0x23, 0x12, 0xe0}: true, // IERC1155Receiver
+}
+
+var _ gethwrappers.RBACTimelockInterface = (*RBACTimelock)(nil)
+
+// RBACTimelock is an in-memory RBACTimelock. Executed calls are dispatched to
+// the fakes at their targets.
+type RBACTimelock struct {
+	*gethwrappers.RBACTimelockFilterer
+
+	chain   *Chain
+	address common.Address
+
+	minDelay   *big.Int
+	timestamps map[common.Hash]*big.Int
+	// members and blocked keep EnumerableSet's order.
+	members    map[common.Hash][]common.Address
+	roleAdmins map[common.Hash]common.Hash
+	blocked    [][4]byte
+}
+
+// DeployRBACTimelock deploys an RBACTimelock with the constructor arguments
+// of the contract.
+func DeployRBACTimelock(auth *bind.TransactOpts, chain *Chain, minDelay *big.Int, admin common.Address, proposers []common.Address, executors []common.Address, cancellers []common.Address, bypassers []common.Address) (common.Address, *types.Transaction, *RBACTimelock, error) {
+	var tl *RBACTimelock
+	address, tx, err := chain.deploy(auth, func(address common.Address) (contract, error) {
+		filterer, err := gethwrappers.NewRBACTimelockFilterer(address, chain)
+		if err != nil {
+			return nil, err
+		}
+		tl = &RBACTimelock{
+			RBACTimelockFilterer: filterer,
+			chain:                chain,
+			address:              address,
+			minDelay:             new(big.Int).Set(minDelay),
+			timestamps:           make(map[common.Hash]*big.Int),
+			members:              make(map[common.Hash][]common.Address),
+			roleAdmins:           make(map[common.Hash]common.Hash),
+		}
+		for _, role := range timelock.Roles {
+			tl.roleAdmins[role.ID] = timelock.AdminRole.ID
+			tl.emit("RoleAdminChanged", role.ID, common.Hash{}, timelock.AdminRole.ID)
+		}
+		tl.grantRole(timelock.AdminRole.ID, admin, auth.From)
+		for _, grant := range []struct {
+			role     timelock.Role
+			accounts []common.Address
+		}{
+			{timelock.ProposerRole, proposers},
+			{timelock.ExecutorRole, executors},
+			{timelock.CancellerRole, cancellers},
+			{timelock.BypasserRole, bypassers},
+		} {
+			for _, account := range grant.accounts {
+				tl.grantRole(grant.role.ID, account, auth.From)
+			}
+		}
+		tl.emit("MinDelayChange", new(big.Int), minDelay)
+		return tl, nil
+	})
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, tl, nil
+}
+
+// Address returns the address of the contract.
+func (tl *RBACTimelock) Address() common.Address {
+	return tl.address
+}
+
+// ADMINROLE returns ADMIN_ROLE.
+func (tl *RBACTimelock) ADMINROLE(opts *bind.CallOpts) ([32]byte, error) {
+	return timelock.AdminRole.ID, nil
+}
+
+// BYPASSERROLE returns BYPASSER_ROLE.
+func (tl *RBACTimelock) BYPASSERROLE(opts *bind.CallOpts) ([32]byte, error) {
+	return timelock.BypasserRole.ID, nil
+}
+
+// CANCELLERROLE returns CANCELLER_ROLE.
+func (tl *RBACTimelock) CANCELLERROLE(opts *bind.CallOpts) ([32]byte, error) {
+	return timelock.CancellerRole.ID, nil
+}
+
+// DEFAULTADMINROLE returns DEFAULT_ADMIN_ROLE.
+func (tl *RBACTimelock) DEFAULTADMINROLE(opts *bind.CallOpts) ([32]byte, error) {
+	return [32]byte{}, nil
+}
+
+// EXECUTORROLE returns EXECUTOR_ROLE.
+func (tl *RBACTimelock) EXECUTORROLE(opts *bind.CallOpts) ([32]byte, error) {
+	return timelock.ExecutorRole.ID, nil
+}
+
+// PROPOSERROLE returns PROPOSER_ROLE.
+func (tl *RBACTimelock) PROPOSERROLE(opts *bind.CallOpts) ([32]byte, error) {
+	return timelock.ProposerRole.ID, nil
+}
+
+// GetBlockedFunctionSelectorAt returns the blocked selector at index.
+func (tl *RBACTimelock) GetBlockedFunctionSelectorAt(opts *bind.CallOpts, index *big.Int) ([4]byte, error) {
+	tl.chain.mu.Lock()
+	defer tl.chain.mu.Unlock()
+	if !index.IsUint64() || index.Uint64() >= uint64(len(tl.blocked)) {
+		return [4]byte{}, Revert("")
+	}
+	return tl.blocked[index.Uint64()], nil
+}
+
+// GetBlockedFunctionSelectorCount returns the number of blocked selectors.
+func (tl *RBACTimelock) GetBlockedFunctionSelectorCount(opts *bind.CallOpts) (*big.Int, error) {
+	tl.chain.mu.Lock()
+	defer tl.chain.mu.Unlock()
+	return big.NewInt(int64(len(tl.blocked))), nil
+}
+
+// GetMinDelay returns the minimum delay.
+func (tl *RBACTimelock) GetMinDelay(opts *bind.CallOpts) (*big.Int, error) {
+	tl.chain.mu.Lock()
+	defer tl.chain.mu.Unlock()
+	return new(big.Int).Set(tl.minDelay), nil
+}
+
+// GetRoleAdmin returns the admin role of role.
+func (tl *RBACTimelock) GetRoleAdmin(opts *bind.CallOpts, role [32]byte) ([32]byte, error) {
+	tl.chain.mu.Lock()
+	defer tl.chain.mu.Unlock()
+	return tl.roleAdmins[role], nil
+}
+
+// GetRoleMember returns the member of role at index.
+func (tl *RBACTimelock) GetRoleMember(opts *bind.CallOpts, role [32]byte, index *big.Int) (common.Address, error) {
+	tl.chain.mu.Lock()
+	defer tl.chain.mu.Unlock()
+	members := tl.members[role]
+	if !index.IsUint64() || index.Uint64() >= uint64(len(members)) {
+		return common.Address{}, Revert("")
+	}
+	return members[index.Uint64()], nil
+}
+
+// GetRoleMemberCount returns the number of members of role.
+func (tl *RBACTimelock) GetRoleMemberCount(opts *bind.CallOpts, role [32]byte) (*big.Int, error) {
+	tl.chain.mu.Lock()
+	defer tl.chain.mu.Unlock()
+	return big.NewInt(int64(len(tl.members[role]))), nil
+}
+
+// GetTimestamp returns the timestamp of operation id.
+func (tl *RBACTimelock) GetTimestamp(opts *bind.CallOpts, id [32]byte) (*big.Int, error) {
+	tl.chain.mu.Lock()
+	defer tl.chain.mu.Unlock()
+	return new(big.Int).Set(tl.timestamp(id)), nil
+}
+
+// HasRole reports whether account has role.
+func (tl *RBACTimelock) HasRole(opts *bind.CallOpts, role [32]byte, account common.Address) (bool, error) {
+	tl.chain.mu.Lock()
+	defer tl.chain.mu.Unlock()
+	return tl.hasRole(role, account), nil
+}
+
+// HashOperationBatch returns the id of an operation.
+func (tl *RBACTimelock) HashOperationBatch(opts *bind.CallOpts, calls []gethwrappers.RBACTimelockCall, predecessor [32]byte, salt [32]byte) ([32]byte, error) {
+	return hashOperationBatch(calls, predecessor, salt), nil
+}
+
+// IsOperation reports whether id was scheduled and not cancelled.
+func (tl *RBACTimelock) IsOperation(opts *bind.CallOpts, id [32]byte) (bool, error) {
+	tl.chain.mu.Lock()
+	defer tl.chain.mu.Unlock()
+	return tl.timestamp(id).Sign() > 0, nil
+}
+
+// IsOperationDone reports whether id was executed.
+func (tl *RBACTimelock) IsOperationDone(opts *bind.CallOpts, id [32]byte) (bool, error) {
+	tl.chain.mu.Lock()
+	defer tl.chain.mu.Unlock()
+	return tl.timestamp(id).Cmp(doneTimestamp) == 0, nil
+}
+
+// IsOperationPending reports whether id is scheduled but not executed.
+func (tl *RBACTimelock) IsOperationPending(opts *bind.CallOpts, id [32]byte) (bool, error) {
+	tl.chain.mu.Lock()
+	defer tl.chain.mu.Unlock()
+	return tl.isPending(id), nil
+}
+
+// IsOperationReady reports whether id can be executed.
+func (tl *RBACTimelock) IsOperationReady(opts *bind.CallOpts, id [32]byte) (bool, error) {
+	tl.chain.mu.Lock()
+	defer tl.chain.mu.Unlock()
+	return tl.isReady(id), nil
+}
+
+// SupportsInterface implements ERC165.
+func (tl *RBACTimelock) SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error) {
+	return timelockInterfaces[interfaceId], nil
+}
+
+// BlockFunctionSelector sends blockFunctionSelector.
+func (tl *RBACTimelock) BlockFunctionSelector(opts *bind.TransactOpts, selector [4]byte) (*types.Transaction, error) {
+	return tl.transact(opts, "blockFunctionSelector", selector)
+}
+
+// BypasserExecuteBatch sends bypasserExecuteBatch.
+func (tl *RBACTimelock) BypasserExecuteBatch(opts *bind.TransactOpts, calls []gethwrappers.RBACTimelockCall) (*types.Transaction, error) {
+	return tl.transact(opts, "bypasserExecuteBatch", calls)
+}
+
+// Cancel sends cancel.
+func (tl *RBACTimelock) Cancel(opts *bind.TransactOpts, id [32]byte) (*types.Transaction, error) {
+	return tl.transact(opts, "cancel", id)
+}
+
+// ExecuteBatch sends executeBatch.
+func (tl *RBACTimelock) ExecuteBatch(opts *bind.TransactOpts, calls []gethwrappers.RBACTimelockCall, predecessor [32]byte, salt [32]byte) (*types.Transaction, error) {
+	return tl.transact(opts, "executeBatch", calls, predecessor, salt)
+}
+
+// GrantRole sends grantRole.
+func (tl *RBACTimelock) GrantRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	return tl.transact(opts, "grantRole", role, account)
+}
+
+// RenounceRole sends renounceRole.
+func (tl *RBACTimelock) RenounceRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	return tl.transact(opts, "renounceRole", role, account)
+}
+
+// RevokeRole sends revokeRole.
+func (tl *RBACTimelock) RevokeRole(opts *bind.TransactOpts, role [32]byte, account common.Address) (*types.Transaction, error) {
+	return tl.transact(opts, "revokeRole", role, account)
+}
+
+// ScheduleBatch sends scheduleBatch.
+func (tl *RBACTimelock) ScheduleBatch(opts *bind.TransactOpts, calls []gethwrappers.RBACTimelockCall, predecessor [32]byte, salt [32]byte, delay *big.Int) (*types.Transaction, error) {
+	return tl.transact(opts, "scheduleBatch", calls, predecessor, salt, delay)
+}
+
+// UnblockFunctionSelector sends unblockFunctionSelector.
+func (tl *RBACTimelock) UnblockFunctionSelector(opts *bind.TransactOpts, selector [4]byte) (*types.Transaction, error) {
+	return tl.transact(opts, "unblockFunctionSelector", selector)
+}
+
+// UpdateDelay sends updateDelay.
+func (tl *RBACTimelock) UpdateDelay(opts *bind.TransactOpts, newDelay *big.Int) (*types.Transaction, error) {
+	return tl.transact(opts, "updateDelay", newDelay)
+}
+
+func (tl *RBACTimelock) transact(opts *bind.TransactOpts, method string, args ...interface{}) (*types.Transaction, error) {
+	return tl.chain.transact(opts, tl.address, callData(timelock.RBACTimelockABI, method, args...))
+}
+
+func (tl *RBACTimelock) call(from common.Address, value *big.Int, data []byte) error {
+	method, values, err := unpackCall(timelock.RBACTimelockABI, data)
+	if err != nil || method == nil {
+		return err
+	}
+	switch method.Name {
+	case "scheduleBatch":
+		var args struct {
+			Calls       []gethwrappers.RBACTimelockCall
+			Predecessor [32]byte
+			Salt        [32]byte
+			Delay       *big.Int
+		}
+		copyArgs(method, values, &args)
+		if err := tl.checkRoleOrAdmin(timelock.ProposerRole.ID, from); err != nil {
+			return err
+		}
+		id := hashOperationBatch(args.Calls, args.Predecessor, args.Salt)
+		if tl.timestamp(id).Sign() > 0 {
+			return ErrAlreadyScheduled
+		}
+		if args.Delay.Cmp(tl.minDelay) < 0 {
+			return ErrInsufficientDelay
+		}
+		tl.timestamps[id] = new(big.Int).Add(new(big.Int).SetUint64(tl.chain.time), args.Delay)
+		for i, c := range args.Calls {
+			if len(c.Data) >= 4 && tl.blockedIndex([4]byte(c.Data[:4])) >= 0 {
+				return ErrSelectorBlocked
+			}
+			tl.emit("CallScheduled", id, big.NewInt(int64(i)), c.Target, c.Value, c.Data, args.Predecessor, args.Salt, args.Delay)
+		}
+	case "cancel":
+		var args struct{ Id [32]byte }
+		copyArgs(method, values, &args)
+		if err := tl.checkRoleOrAdmin(timelock.CancellerRole.ID, from); err != nil {
+			return err
+		}
+		if !tl.isPending(args.Id) {
+			return ErrCannotBeCancelled
+		}
+		delete(tl.timestamps, args.Id)
+		tl.emit("Cancelled", args.Id)
+	case "executeBatch":
+		var args struct {
+			Calls       []gethwrappers.RBACTimelockCall
+			Predecessor [32]byte
+			Salt        [32]byte
+		}
+		copyArgs(method, values, &args)
+		if err := tl.checkRoleOrAdmin(timelock.ExecutorRole.ID, from); err != nil {
+			return err
+		}
+		id := hashOperationBatch(args.Calls, args.Predecessor, args.Salt)
+		if !tl.isReady(id) {
+			return ErrNotReady
+		}
+		if args.Predecessor != [32]byte{} && tl.timestamp(args.Predecessor).Cmp(doneTimestamp) != 0 {
+			return ErrMissingDependency
+		}
+		for i, c := range args.Calls {
+			if err := tl.chain.call(tl.address, c.Target, c.Value, c.Data); err != nil {
+				return ErrUnderlyingReverted
+			}
+			tl.emit("CallExecuted", id, big.NewInt(int64(i)), c.Target, c.Value, c.Data)
+		}
+		// A call may have executed the operation itself.
+		if !tl.isReady(id) {
+			return ErrNotReady
+		}
+		tl.timestamps[id] = doneTimestamp
+	case "bypasserExecuteBatch":
+		var args struct {
+			Calls []gethwrappers.RBACTimelockCall
+		}
+		copyArgs(method, values, &args)
+		if err := tl.checkRoleOrAdmin(timelock.BypasserRole.ID, from); err != nil {
+			return err
+		}
+		for i, c := range args.Calls {
+			if err := tl.chain.call(tl.address, c.Target, c.Value, c.Data); err != nil {
+				return ErrUnderlyingReverted
+			}
+			tl.emit("BypasserCallExecuted", big.NewInt(int64(i)), c.Target, c.Value, c.Data)
+		}
+	case "updateDelay":
+		var args struct{ NewDelay *big.Int }
+		copyArgs(method, values, &args)
+		if err := tl.checkRole(timelock.AdminRole.ID, from); err != nil {
+			return err
+		}
+		tl.emit("MinDelayChange", tl.minDelay, args.NewDelay)
+		tl.minDelay = args.NewDelay
+	case "blockFunctionSelector", "unblockFunctionSelector":
+		var args struct{ Selector [4]byte }
+		copyArgs(method, values, &args)
+		if err := tl.checkRole(timelock.AdminRole.ID, from); err != nil {
+			return err
+		}
+		i := tl.blockedIndex(args.Selector)
+		if method.Name == "blockFunctionSelector" && i < 0 {
+			tl.blocked = append(tl.blocked, args.Selector)
+			tl.emit("FunctionSelectorBlocked", args.Selector)
+		}
+		if method.Name == "unblockFunctionSelector" && i >= 0 {
+			tl.blocked[i] = tl.blocked[len(tl.blocked)-1]
+			tl.blocked = tl.blocked[:len(tl.blocked)-1]
+			tl.emit("FunctionSelectorUnblocked", args.Selector)
+		}
+	case "grantRole", "revokeRole", "renounceRole":
+		var args struct {
+			Role    [32]byte
+			Account common.Address
+		}
+		copyArgs(method, values, &args)
+		switch method.Name {
+		case "grantRole":
+			if err := tl.checkRole(tl.roleAdmins[args.Role], from); err != nil {
+				return err
+			}
+			tl.grantRole(args.Role, args.Account, from)
+		case "revokeRole":
+			if err := tl.checkRole(tl.roleAdmins[args.Role], from); err != nil {
+				return err
+			}
+			tl.revokeRole(args.Role, args.Account, from)
+		case "renounceRole":
+			if args.Account != from {
+				return ErrRenounceForSelfOnly
+			}
+			tl.revokeRole(args.Role, args.Account, from)
+		}
+	}
+	// The ERC721/ERC1155 receiver hooks only return their selectors.
+	return nil
+}
+
+func (tl *RBACTimelock) timestamp(id common.Hash) *big.Int {
+	if ts, ok := tl.timestamps[id]; ok {
+		return ts
+	}
+	return new(big.Int)
+}
+
+func (tl *RBACTimelock) isPending(id common.Hash) bool {
+	return tl.timestamp(id).Cmp(doneTimestamp) > 0
+}
+
+func (tl *RBACTimelock) isReady(id common.Hash) bool {
+	ts := tl.timestamp(id)
+	return ts.Cmp(doneTimestamp) > 0 && ts.Cmp(new(big.Int).SetUint64(tl.chain.time)) <= 0
+}
+
+func (tl *RBACTimelock) blockedIndex(selector [4]byte) int {
+	for i, s := range tl.blocked {
+		if s == selector {
+			return i
+		}
+	}
+	return -1
+}
+
+func (tl *RBACTimelock) hasRole(role common.Hash, account common.Address) bool {
+	for _, member := range tl.members[role] {
+		if member == account {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRole is AccessControl._checkRole.
+func (tl *RBACTimelock) checkRole(role common.Hash, account common.Address) error {
+	if tl.hasRole(role, account) {
+		return nil
+	}
+	return Revert(fmt.Sprintf("AccessControl: account %s is missing role %s", strings.ToLower(account.Hex()), hexutil.Encode(role[:])))
+}
+
+// checkRoleOrAdmin is the onlyRoleOrAdminRole modifier.
+func (tl *RBACTimelock) checkRoleOrAdmin(role common.Hash, account common.Address) error {
+	if tl.hasRole(timelock.AdminRole.ID, account) {
+		return nil
+	}
+	return tl.checkRole(role, account)
+}
+
+func (tl *RBACTimelock) grantRole(role common.Hash, account, sender common.Address) {
+	if tl.hasRole(role, account) {
+		return
+	}
+	tl.members[role] = append(tl.members[role], account)
+	tl.emit("RoleGranted", role, account, sender)
+}
+
+func (tl *RBACTimelock) revokeRole(role common.Hash, account, sender common.Address) {
+	members := tl.members[role]
+	for i, member := range members {
+		if member != account {
+			continue
+		}
+		members[i] = members[len(members)-1]
+		tl.members[role] = members[:len(members)-1]
+		tl.emit("RoleRevoked", role, account, sender)
+		return
+	}
+}
+
+func (tl *RBACTimelock) emit(event string, args ...interface{}) {
+	tl.chain.emit(tl.address, timelock.RBACTimelockABI, event, args...)
+}
+
+func (tl *RBACTimelock) snapshot() func() {
+	minDelay := tl.minDelay
+	timestamps := make(map[common.Hash]*big.Int, len(tl.timestamps))
+	for id, ts := range tl.timestamps {
+		timestamps[id] = ts
+	}
+	members := make(map[common.Hash][]common.Address, len(tl.members))
+	for role, m := range tl.members {
+		members[role] = append([]common.Address(nil), m...)
+	}
+	blocked := append([][4]byte(nil), tl.blocked...)
+	return func() {
+		tl.minDelay, tl.timestamps, tl.members, tl.blocked = minDelay, timestamps, members, blocked
+	}
+}
+
+// hashOperationBatch is RBACTimelock.hashOperationBatch.
+func hashOperationBatch(calls []gethwrappers.RBACTimelockCall, predecessor, salt [32]byte) common.Hash {
+	encoded, err := timelock.RBACTimelockABI.Methods["hashOperationBatch"].Inputs.Pack(calls, predecessor, salt)
+	if err != nil {
+		panic(fmt.Sprintf("fake: encoding operation: %v", err))
+	}
+	return crypto.Keccak256Hash(encoded)
+}
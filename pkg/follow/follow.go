@@ -0,0 +1,296 @@
+// Package follow follows the logs of owner contracts across reorgs.
+//
+// The Watch methods of the gethwrappers bindings deliver logs as soon as a
+// node sees them, so a watcher acting on them can act on a block that is
+// later reorged out. A Follower instead polls for logs, delivers them only
+// once their block is Confirmations deep and remembers the hashes of the
+// blocks it delivered. When one of those blocks leaves the canonical chain,
+// its logs are delivered again with Removed set, as retractions, followed by
+// the logs of the new canonical blocks. Consumers that undo a log on
+// retraction thus always end up with the canonical history.
+//
+// Delivered logs decode with the Parse methods of the bindings, e.g.
+// ManyChainMultiSigFilterer.ParseNewRoot; the Raw field of the result keeps
+// the Removed flag.
+//
+// A Watch runs a Follower per chain for the monitors and keeps each chain's
+// logs until its Handler has handled them.
+package follow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+)
+
+// DefaultMaxReorgDepth is the default number of blocks behind the latest
+// delivered block a reorg is detected and handled.
+const DefaultMaxReorgDepth = 256
+
+// ErrReorgTooDeep is returned when every block the follower remembers has
+// left the canonical chain, so the fork point is unknown.
+var ErrReorgTooDeep = errors.New("reorg deeper than the blocks remembered")
+
+// Backend is the subset of a chain client needed to follow logs.
+type Backend interface {
+	ethereum.LogFilterer
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Config selects the logs to follow.
+type Config struct {
+	Addresses []common.Address
+	// Topics filters the logs as in eth_getLogs; see EventTopics.
+	Topics    [][]common.Hash
+	FromBlock uint64
+	// Confirmations is how deep a block must be before its logs are
+	// delivered: 0 delivers the logs of the head block, which are the most
+	// likely to be retracted. Chains differ; pick the depth per chain.
+	Confirmations uint64
+	// MaxReorgDepth is how many blocks of history are remembered to find
+	// the fork point of a reorg, DefaultMaxReorgDepth by default.
+	MaxReorgDepth uint64
+	// BatchSize is the number of blocks per eth_getLogs call, by default
+	// evm.DefaultLogBatchSize.
+	BatchSize uint64
+}
+
+// EventTopics returns the Topics filter matching any of the named events of
+// parsed, e.g. EventTopics(mcms.ManyChainMultiSigABI, "NewRoot").
+func EventTopics(parsed *abi.ABI, names ...string) ([][]common.Hash, error) {
+	ids := make([]common.Hash, len(names))
+	for i, name := range names {
+		ev, ok := parsed.Events[name]
+		if !ok {
+			return nil, fmt.Errorf("no event %s", name)
+		}
+		ids[i] = ev.ID
+	}
+	return [][]common.Hash{ids}, nil
+}
+
+// block is a delivered block: its hash and the logs delivered from it. The
+// last block of every polled range is remembered even without logs, so that
+// a reorg of the tip is noticed.
+type block struct {
+	number uint64
+	hash   common.Hash
+	logs   []types.Log
+}
+
+// Follower follows the logs selected by its Config. It is not safe for
+// concurrent use.
+type Follower struct {
+	backend Backend
+	cfg     Config
+	next    uint64
+	// delivered holds the remembered blocks in chain order.
+	delivered []block
+	// pruned is set once blocks were forgotten, so that a reorg past the
+	// remembered ones can no longer be rewound to FromBlock.
+	pruned bool
+}
+
+// New returns a follower starting at cfg.FromBlock.
+func New(backend Backend, cfg Config) *Follower {
+	if cfg.MaxReorgDepth == 0 {
+		cfg.MaxReorgDepth = DefaultMaxReorgDepth
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = evm.DefaultLogBatchSize
+	}
+	return &Follower{backend: backend, cfg: cfg, next: cfg.FromBlock}
+}
+
+// Next returns the first block whose logs have not been delivered yet.
+func (f *Follower) Next() uint64 {
+	return f.next
+}
+
+// Poll returns the logs that became confirmed since the last call, in
+// chain order, preceded by the retractions of delivered logs whose blocks
+// were reorged out, latest first. If Poll fails part way, it returns the
+// logs it delivered before failing along with the error; they are not
+// delivered again.
+func (f *Follower) Poll(ctx context.Context) ([]types.Log, error) {
+	retracted, err := f.rewind(ctx)
+	if err != nil {
+		return nil, err
+	}
+	head, err := f.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return retracted, err
+	}
+	if head.Number.Uint64() < f.cfg.Confirmations {
+		return retracted, nil
+	}
+	target := head.Number.Uint64() - f.cfg.Confirmations
+
+	logs := retracted
+	for f.next <= target {
+		end := f.next + f.cfg.BatchSize - 1
+		if end > target {
+			end = target
+		}
+		blocks, err := f.fetch(ctx, f.next, end)
+		if err != nil {
+			f.prune()
+			return logs, err
+		}
+		if blocks == nil {
+			// The range changed while it was read; the next poll
+			// sorts it out.
+			break
+		}
+		for _, b := range blocks {
+			logs = append(logs, b.logs...)
+		}
+		f.delivered = append(f.delivered, blocks...)
+		f.next = end + 1
+	}
+	f.prune()
+	return logs, nil
+}
+
+// rewind finds the latest remembered block that is still canonical, drops
+// the ones after it and returns their logs as retractions.
+func (f *Follower) rewind(ctx context.Context) ([]types.Log, error) {
+	i := len(f.delivered) - 1
+	for ; i >= 0; i-- {
+		b := f.delivered[i]
+		header, err := f.backend.HeaderByNumber(ctx, new(big.Int).SetUint64(b.number))
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+		if header != nil && header.Hash() == b.hash {
+			break
+		}
+	}
+	if i == len(f.delivered)-1 {
+		return nil, nil
+	}
+	if i < 0 && f.pruned {
+		return nil, ErrReorgTooDeep
+	}
+	var retracted []types.Log
+	for j := len(f.delivered) - 1; j > i; j-- {
+		logs := f.delivered[j].logs
+		for k := len(logs) - 1; k >= 0; k-- {
+			l := logs[k]
+			l.Removed = true
+			retracted = append(retracted, l)
+		}
+	}
+	if i < 0 {
+		f.next = f.cfg.FromBlock
+	} else {
+		f.next = f.delivered[i].number + 1
+	}
+	f.delivered = f.delivered[:i+1]
+	return retracted, nil
+}
+
+// fetch reads the logs of blocks [start, end] and the hashes of their
+// blocks. It returns nil if the chain changed while it was read: the hash of
+// end is read before and after the logs, and each log must belong to the
+// canonical block of its number.
+func (f *Follower) fetch(ctx context.Context, start, end uint64) ([]block, error) {
+	endHeader, err := f.backend.HeaderByNumber(ctx, new(big.Int).SetUint64(end))
+	if err != nil {
+		return nil, err
+	}
+	logs, err := f.backend.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(start),
+		ToBlock:   new(big.Int).SetUint64(end),
+		Addresses: f.cfg.Addresses,
+		Topics:    f.cfg.Topics,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching logs in blocks [%d, %d]: %w", start, end, err)
+	}
+	var blocks []block
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+		if n := len(blocks); n == 0 || blocks[n-1].number != l.BlockNumber {
+			blocks = append(blocks, block{number: l.BlockNumber, hash: l.BlockHash})
+		}
+		b := &blocks[len(blocks)-1]
+		if l.BlockHash != b.hash {
+			return nil, nil
+		}
+		b.logs = append(b.logs, l)
+	}
+	if n := len(blocks); n == 0 || blocks[n-1].number != end {
+		blocks = append(blocks, block{number: end, hash: endHeader.Hash()})
+	} else if blocks[n-1].hash != endHeader.Hash() {
+		return nil, nil
+	}
+	for i := range blocks {
+		b := &blocks[i]
+		header, err := f.backend.HeaderByNumber(ctx, new(big.Int).SetUint64(b.number))
+		if err != nil {
+			return nil, err
+		}
+		if header.Hash() != b.hash {
+			return nil, nil
+		}
+	}
+	return blocks, nil
+}
+
+// prune forgets the blocks older than MaxReorgDepth behind the latest
+// delivered block, keeping at least one.
+func (f *Follower) prune() {
+	if len(f.delivered) == 0 {
+		return
+	}
+	latest := f.delivered[len(f.delivered)-1].number
+	if latest < f.cfg.MaxReorgDepth {
+		return
+	}
+	floor := latest - f.cfg.MaxReorgDepth
+	i := 0
+	for i < len(f.delivered)-1 && f.delivered[i].number < floor {
+		i++
+	}
+	if i > 0 {
+		f.delivered = append([]block(nil), f.delivered[i:]...)
+		f.pruned = true
+	}
+}
+
+// Run polls every interval and sends the logs to sink until ctx is done or
+// polling fails.
+func (f *Follower) Run(ctx context.Context, interval time.Duration, sink chan<- types.Log) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		logs, err := f.Poll(ctx)
+		for _, l := range logs {
+			select {
+			case sink <- l:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package follow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/follow"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+// setup deploys a ManyChainMultiSig and returns a follower of its
+// OwnershipTransferStarted events.
+func setup(t *testing.T, confirmations uint64) (*harness.Env, *gethwrappers.ManyChainMultiSig, *follow.Follower) {
+	t.Helper()
+	e := harness.NewBare(t)
+	addr, _, ms, err := gethwrappers.DeployManyChainMultiSig(e.Deployer.Opts, e.Backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Commit()
+	topics, err := follow.EventTopics(mcms.ManyChainMultiSigABI, "OwnershipTransferStarted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := follow.New(e.Backend, follow.Config{Addresses: []common.Address{addr}, Topics: topics, Confirmations: confirmations})
+	return e, ms, f
+}
+
+func poll(t *testing.T, f *follow.Follower) []types.Log {
+	t.Helper()
+	logs, err := f.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return logs
+}
+
+// reorg replaces the blocks after parent with n empty blocks.
+func reorg(t *testing.T, e *harness.Env, parent common.Hash, n int) {
+	t.Helper()
+	if err := e.Backend.Fork(context.Background(), parent); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		e.Commit()
+	}
+}
+
+func TestRetractAndRedeliver(t *testing.T) {
+	e, ms, f := setup(t, 0)
+	if logs := poll(t, f); len(logs) != 0 {
+		t.Fatalf("logs before any transfer: %+v", logs)
+	}
+	// NewAccount mines a block, so the account is made before the fork point.
+	first := e.NewAccount().Address
+	parent := e.Backend.Blockchain().CurrentBlock().Hash()
+	receipt := e.Send(ms.TransferOwnership(e.Deployer.Opts, first))
+	logs := poll(t, f)
+	if len(logs) != 1 || logs[0].Removed || logs[0].TxHash != receipt.TxHash {
+		t.Fatalf("logs = %+v", logs)
+	}
+
+	reorg(t, e, parent, 2)
+	logs = poll(t, f)
+	if len(logs) != 1 || !logs[0].Removed || logs[0].TxHash != receipt.TxHash {
+		t.Fatalf("logs after reorg = %+v, want the retraction of %s", logs, receipt.TxHash)
+	}
+	if logs := poll(t, f); len(logs) != 0 {
+		t.Fatalf("logs retracted twice: %+v", logs)
+	}
+
+	second := e.NewAccount().Address
+	e.Send(ms.TransferOwnership(e.Deployer.Opts, second))
+	logs = poll(t, f)
+	if len(logs) != 1 || logs[0].Removed {
+		t.Fatalf("logs on the new chain = %+v", logs)
+	}
+	filterer, err := gethwrappers.NewManyChainMultiSigFilterer(logs[0].Address, e.Backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	started, err := filterer.ParseOwnershipTransferStarted(logs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if started.NewOwner != second {
+		t.Fatalf("new owner %s, want %s", started.NewOwner, second)
+	}
+}
+
+func TestConfirmations(t *testing.T) {
+	e, ms, f := setup(t, 2)
+	parent := e.Backend.Blockchain().CurrentBlock().Hash()
+	e.Send(ms.TransferOwnership(e.Deployer.Opts, e.NewAccount().Address))
+	e.Commit()
+	if logs := poll(t, f); len(logs) != 0 {
+		t.Fatalf("unconfirmed logs delivered: %+v", logs)
+	}
+
+	// The transfer is reorged out before it is confirmed, so it is never
+	// delivered.
+	reorg(t, e, parent, 5)
+	if logs := poll(t, f); len(logs) != 0 {
+		t.Fatalf("logs after reorg = %+v", logs)
+	}
+
+	receipt := e.Send(ms.TransferOwnership(e.Deployer.Opts, e.NewAccount().Address))
+	e.Commit()
+	if logs := poll(t, f); len(logs) != 0 {
+		t.Fatalf("log delivered after 1 confirmation: %+v", logs)
+	}
+	e.Commit()
+	logs := poll(t, f)
+	if len(logs) != 1 || logs[0].TxHash != receipt.TxHash || logs[0].Removed {
+		t.Fatalf("logs = %+v", logs)
+	}
+}
+
+// failingLogs fails eth_getLogs for blocks from failFrom on.
+type failingLogs struct {
+	follow.Backend
+	failFrom uint64
+}
+
+func (b *failingLogs) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if q.FromBlock.Uint64() >= b.failFrom {
+		return nil, errors.New("connection lost")
+	}
+	return b.Backend.FilterLogs(ctx, q)
+}
+
+func TestPartialPoll(t *testing.T) {
+	e, ms, _ := setup(t, 0)
+	receipt := e.Send(ms.TransferOwnership(e.Deployer.Opts, e.NewAccount().Address))
+	topics, err := follow.EventTopics(mcms.ManyChainMultiSigABI, "OwnershipTransferStarted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &failingLogs{Backend: e.Backend, failFrom: receipt.BlockNumber.Uint64() + 1}
+	f := follow.New(backend, follow.Config{Addresses: []common.Address{receipt.Logs[0].Address}, Topics: topics, BatchSize: 1})
+	e.Commit()
+
+	// The batch holding the transfer is delivered along with the error of
+	// the next one.
+	logs, err := f.Poll(context.Background())
+	if err == nil || len(logs) != 1 || logs[0].TxHash != receipt.TxHash {
+		t.Fatalf("logs = %+v, err = %v", logs, err)
+	}
+	backend.failFrom = ^uint64(0)
+	if logs := poll(t, f); len(logs) != 0 {
+		t.Fatalf("logs delivered twice: %+v", logs)
+	}
+}
+
+func TestWatchKeepsUnhandledLogs(t *testing.T) {
+	e, ms, _ := setup(t, 0)
+	topics, err := follow.EventTopics(mcms.ManyChainMultiSigABI, "OwnershipTransferStarted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := e.Send(ms.TransferOwnership(e.Deployer.Opts, e.NewAccount().Address))
+	var handled []common.Hash
+	fail := true
+	w := follow.NewWatch(topics, []follow.Chain{{ChainID: harness.ChainID, Backend: e.Backend, Contracts: []common.Address{first.Logs[0].Address}}},
+		func(_ context.Context, _ *follow.Chain, logs []types.Log) (int, error) {
+			if fail {
+				return 0, errors.New("sink down")
+			}
+			for _, l := range logs {
+				handled = append(handled, l.TxHash)
+			}
+			return len(logs), nil
+		})
+	ctx := context.Background()
+	if err := w.Poll(ctx); err == nil {
+		t.Fatal("handler error not returned")
+	}
+
+	// The failed log comes first once the handler recovers.
+	second := e.Send(ms.TransferOwnership(e.Deployer.Opts, e.NewAccount().Address))
+	fail = false
+	for i := 0; i < 2; i++ {
+		if err := w.Poll(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(handled) != 2 || handled[0] != first.TxHash || handled[1] != second.TxHash {
+		t.Fatalf("handled %v, want %s then %s", handled, first.TxHash, second.TxHash)
+	}
+}
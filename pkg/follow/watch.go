@@ -0,0 +1,107 @@
+package follow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Chain is a chain followed by a Watch.
+type Chain struct {
+	ChainID *big.Int
+	Backend Backend
+	// Contracts are the contracts whose logs are followed.
+	Contracts []common.Address
+	FromBlock uint64
+	// Confirmations is passed on to the chain's Follower.
+	Confirmations uint64
+}
+
+// Handler handles the logs of chain c, in order. It returns how many of them
+// it handled before failing; the others are passed to it again, followed by
+// newer logs, on the next poll of the chain.
+type Handler func(ctx context.Context, c *Chain, logs []types.Log) (int, error)
+
+// Watch follows the same events on several chains and passes their logs to a
+// Handler. Chains are independent: a chain whose node or handler fails is
+// retried on the next poll without holding up the others, and its follower
+// does not move on until the logs it already delivered are handled.
+type Watch struct {
+	handle Handler
+	chains []*watchedChain
+}
+
+type watchedChain struct {
+	Chain
+	follower *Follower
+	// pending holds the delivered logs that are not handled yet.
+	pending []types.Log
+}
+
+// NewWatch returns a watch of the logs matching topics on chains.
+func NewWatch(topics [][]common.Hash, chains []Chain, handle Handler) *Watch {
+	w := &Watch{handle: handle}
+	for _, c := range chains {
+		w.chains = append(w.chains, &watchedChain{
+			Chain: c,
+			follower: New(c.Backend, Config{
+				Addresses:     c.Contracts,
+				Topics:        topics,
+				FromBlock:     c.FromBlock,
+				Confirmations: c.Confirmations,
+			}),
+		})
+	}
+	return w
+}
+
+// Poll polls every chain once. The errors of all chains are returned
+// together.
+func (w *Watch) Poll(ctx context.Context) error {
+	var errs []error
+	for _, c := range w.chains {
+		if err := c.poll(ctx, w.handle); err != nil {
+			errs = append(errs, fmt.Errorf("chain %v: %w", c.ChainID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *watchedChain) poll(ctx context.Context, handle Handler) error {
+	var pollErr error
+	if len(c.pending) == 0 {
+		c.pending, pollErr = c.follower.Poll(ctx)
+	}
+	if len(c.pending) == 0 {
+		return pollErr
+	}
+	n, err := handle(ctx, &c.Chain, c.pending)
+	if n == len(c.pending) {
+		c.pending = nil
+	} else {
+		c.pending = append([]types.Log(nil), c.pending[n:]...)
+	}
+	return errors.Join(pollErr, err)
+}
+
+// PollEvery calls poll every interval until ctx is done, passing its errors
+// to onError, which may be nil.
+func PollEvery(ctx context.Context, interval time.Duration, poll func(context.Context) error, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := poll(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
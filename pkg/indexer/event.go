@@ -0,0 +1,117 @@
+// Package indexer copies the events of owner contracts into a local
+// key-value store, so that historical questions ("which roots were set on
+// this chain last quarter?") can be answered without scanning logs again.
+//
+// Every event of the ManyChainMultiSig, RBACTimelock and CallProxy bindings
+// is indexed. Progress is checkpointed per contract, and each block range is
+// written atomically together with its checkpoints, so an interrupted sync
+// resumes where it stopped.
+package indexer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/manifest"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+// abis maps the manifest contract types to the ABIs their events are
+// decoded with.
+var abis = map[string]*abi.ABI{
+	manifest.TypeManyChainMultiSig: mcms.ManyChainMultiSigABI,
+	manifest.TypeRBACTimelock:      timelock.RBACTimelockABI,
+	manifest.TypeCallProxy:         evm.MustParseABI(gethwrappers.CallProxyMetaData),
+}
+
+// Event is an indexed contract event.
+type Event struct {
+	ChainID      *big.Int       `json:"chainId"`
+	Contract     common.Address `json:"contract"`
+	ContractType string         `json:"contractType"`
+	// Name is the Solidity event name, e.g. "NewRoot" or "CallScheduled".
+	Name        string      `json:"name"`
+	BlockNumber uint64      `json:"blockNumber"`
+	BlockHash   common.Hash `json:"blockHash"`
+	BlockTime   uint64      `json:"blockTime"`
+	TxHash      common.Hash `json:"txHash"`
+	LogIndex    uint        `json:"logIndex"`
+	// OpID is the operation id of the RBACTimelock events that carry one:
+	// CallScheduled, CallExecuted and Cancelled.
+	OpID *common.Hash `json:"opId,omitempty"`
+	// Nonce is the op nonce of ManyChainMultiSig OpExecuted events.
+	Nonce *uint64 `json:"nonce,omitempty"`
+	// Args holds the decoded event arguments, indexed ones included, as a
+	// JSON object keyed by argument name.
+	Args json.RawMessage `json:"args"`
+}
+
+// decodeLog decodes a log emitted by a contract of type typ.
+func decodeLog(chainID *big.Int, typ string, l types.Log, blockTime uint64) (*Event, error) {
+	parsed, ok := abis[typ]
+	if !ok {
+		return nil, fmt.Errorf("unknown contract type %q", typ)
+	}
+	if len(l.Topics) == 0 {
+		return nil, fmt.Errorf("anonymous log %d in tx %s", l.Index, l.TxHash)
+	}
+	ev, err := parsed.EventByID(l.Topics[0])
+	if err != nil {
+		return nil, fmt.Errorf("log %d in tx %s: no %s event with topic %s", l.Index, l.TxHash, typ, l.Topics[0])
+	}
+	args := make(map[string]interface{})
+	if err := bind.NewBoundContract(l.Address, *parsed, nil, nil, nil).UnpackLogIntoMap(args, ev.Name, l); err != nil {
+		return nil, fmt.Errorf("decoding %s log %d in tx %s: %w", ev.Name, l.Index, l.TxHash, err)
+	}
+	e := &Event{
+		ChainID:      chainID,
+		Contract:     l.Address,
+		ContractType: typ,
+		Name:         ev.Name,
+		BlockNumber:  l.BlockNumber,
+		BlockHash:    l.BlockHash,
+		BlockTime:    blockTime,
+		TxHash:       l.TxHash,
+		LogIndex:     l.Index,
+	}
+	if id, ok := args["id"].([32]byte); ok {
+		opID := common.Hash(id)
+		e.OpID = &opID
+	}
+	if nonce, ok := args["nonce"].(*big.Int); ok && ev.Name == "OpExecuted" {
+		n := nonce.Uint64()
+		e.Nonce = &n
+	}
+	for name, arg := range args {
+		args[name] = formatArg(arg)
+	}
+	if e.Args, err = json.Marshal(args); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// formatArg renders fixed-size byte arrays and byte slices as hex rather
+// than as JSON arrays of numbers.
+func formatArg(arg interface{}) interface{} {
+	switch v := arg.(type) {
+	case [32]byte:
+		return common.Hash(v)
+	case [4]byte:
+		return hexutil.Bytes(v[:])
+	case []byte:
+		return hexutil.Bytes(v)
+	default:
+		return v
+	}
+}
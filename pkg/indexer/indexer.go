@@ -0,0 +1,207 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/inspect"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/manifest"
+)
+
+// Backend is the subset of a chain client needed to index events.
+type Backend interface {
+	ethereum.LogFilterer
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Config selects what an Indexer indexes.
+type Config struct {
+	ChainID    *big.Int
+	Deployment *inspect.Deployment
+	// FromBlock is where contracts without a checkpoint start, usually the
+	// deployment block.
+	FromBlock uint64
+	// BatchSize is the number of blocks per eth_getLogs call, by default
+	// evm.DefaultLogBatchSize.
+	BatchSize uint64
+	// Confirmations keeps indexing that many blocks behind the head, so that
+	// blocks which may still be reorged out are not indexed.
+	Confirmations uint64
+}
+
+// Indexer copies the events of a deployment on one chain into a Store.
+type Indexer struct {
+	backend   Backend
+	store     *Store
+	cfg       Config
+	contracts map[common.Address]string
+	addresses []common.Address
+}
+
+// New returns an indexer for the contracts of cfg.Deployment.
+func New(backend Backend, store *Store, cfg Config) (*Indexer, error) {
+	if cfg.ChainID == nil {
+		return nil, errors.New("no chain id")
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = evm.DefaultLogBatchSize
+	}
+	ix := &Indexer{backend: backend, store: store, cfg: cfg, contracts: make(map[common.Address]string)}
+	add := func(addrs []common.Address, typ string) {
+		for _, a := range addrs {
+			if _, ok := ix.contracts[a]; !ok {
+				ix.contracts[a] = typ
+				ix.addresses = append(ix.addresses, a)
+			}
+		}
+	}
+	if cfg.Deployment != nil {
+		add(cfg.Deployment.MultiSigs, manifest.TypeManyChainMultiSig)
+		add(cfg.Deployment.Timelocks, manifest.TypeRBACTimelock)
+		add(cfg.Deployment.CallProxies, manifest.TypeCallProxy)
+	}
+	if len(ix.addresses) == 0 {
+		return nil, errors.New("no contracts to index")
+	}
+	return ix, nil
+}
+
+// Sync indexes every contract from its checkpoint, or from FromBlock if it
+// has none, up to Confirmations blocks behind the head. It returns the last
+// block indexed for all contracts. Contracts added to the configuration
+// later are backfilled from FromBlock on the next sync.
+func (ix *Indexer) Sync(ctx context.Context) (uint64, error) {
+	head, err := ix.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if head.Number.Uint64() < ix.cfg.Confirmations {
+		return 0, nil
+	}
+	target := head.Number.Uint64() - ix.cfg.Confirmations
+
+	next := make(map[common.Address]uint64, len(ix.addresses))
+	for _, a := range ix.addresses {
+		block, ok, err := ix.store.Checkpoint(ix.cfg.ChainID, a)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			block = ix.cfg.FromBlock
+		}
+		next[a] = block
+	}
+	for {
+		start := uint64(0)
+		for i, a := range ix.addresses {
+			if i == 0 || next[a] < start {
+				start = next[a]
+			}
+		}
+		if start > target {
+			return target, nil
+		}
+		end := start + ix.cfg.BatchSize - 1
+		if end > target {
+			end = target
+		}
+		// Contracts whose checkpoint lies inside the window are
+		// re-indexed from start; events are keyed by position, so
+		// that only rewrites what is already there.
+		var addrs []common.Address
+		for _, a := range ix.addresses {
+			if next[a] <= end {
+				addrs = append(addrs, a)
+			}
+		}
+		if err := ix.index(ctx, addrs, start, end, true); err != nil {
+			return 0, err
+		}
+		for _, a := range addrs {
+			next[a] = end + 1
+		}
+	}
+}
+
+// Backfill indexes blocks [from, to] for every contract without moving the
+// checkpoints, e.g. to fill a range that was skipped or to repair one.
+func (ix *Indexer) Backfill(ctx context.Context, from, to uint64) error {
+	for start := from; start <= to; start += ix.cfg.BatchSize {
+		end := start + ix.cfg.BatchSize - 1
+		if end > to {
+			end = to
+		}
+		if err := ix.index(ctx, ix.addresses, start, end, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run syncs every interval until ctx is done.
+func (ix *Indexer) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := ix.Sync(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// index fetches the logs of addrs in blocks [start, end] and writes them in
+// one batch, together with the checkpoints if checkpoint is set.
+func (ix *Indexer) index(ctx context.Context, addrs []common.Address, start, end uint64, checkpoint bool) error {
+	logs, err := ix.backend.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(start),
+		ToBlock:   new(big.Int).SetUint64(end),
+		Addresses: addrs,
+	})
+	if err != nil {
+		return fmt.Errorf("fetching logs in blocks [%d, %d]: %w", start, end, err)
+	}
+	batch := ix.store.db.NewBatch()
+	blockTimes := make(map[uint64]uint64)
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+		blockTime, ok := blockTimes[l.BlockNumber]
+		if !ok {
+			header, err := ix.backend.HeaderByNumber(ctx, new(big.Int).SetUint64(l.BlockNumber))
+			if err != nil {
+				return err
+			}
+			blockTime = header.Time
+			blockTimes[l.BlockNumber] = blockTime
+		}
+		e, err := decodeLog(ix.cfg.ChainID, ix.contracts[l.Address], l, blockTime)
+		if err != nil {
+			return err
+		}
+		if err := putEvent(batch, e); err != nil {
+			return err
+		}
+	}
+	if checkpoint {
+		for _, a := range addrs {
+			if err := putCheckpoint(batch, ix.cfg.ChainID, a, end+1); err != nil {
+				return err
+			}
+		}
+	}
+	return batch.Write()
+}
@@ -0,0 +1,174 @@
+package indexer_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/indexer"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/inspect"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+func TestIndexer(t *testing.T) {
+	ctx := context.Background()
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	e.AdvanceTime(time.Hour)
+	scheduledAfter := e.Now()
+
+	data, err := timelock.RBACTimelockABI.Pack("updateDelay", big.NewInt(7200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &timelock.Batch{Calls: []timelock.Call{{Target: e.TimelockAddress, Data: data}}}
+	e.Submit(e.NewProposal(e.Proposer, e.ScheduleOp(b)))
+	e.AdvanceTime(time.Hour)
+	e.Send(timelock.ExecuteBatch(e.Deployer.Opts, e.Backend, e.CallProxyAddress, b))
+
+	store := indexer.NewStore(memorydb.New())
+	cfg := indexer.Config{
+		ChainID: harness.ChainID,
+		Deployment: &inspect.Deployment{
+			MultiSigs:   []common.Address{e.Proposer.Address},
+			Timelocks:   []common.Address{e.TimelockAddress},
+			CallProxies: []common.Address{e.CallProxyAddress},
+		},
+		BatchSize: 3,
+	}
+	ix, err := indexer.New(e.Backend, store, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := ix.Sync(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := e.Backend.Blockchain().CurrentBlock().Number.Uint64(); head != want {
+		t.Fatalf("synced to %d, want %d", head, want)
+	}
+	all, err := store.Query(indexer.Query{ChainID: harness.ChainID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := b.ID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byOp, err := store.Query(indexer.Query{ChainID: harness.ChainID, OpID: &id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := eventNames(byOp); len(names) != 2 || names[0] != "CallScheduled" || names[1] != "CallExecuted" {
+		t.Fatalf("events of operation %s: %v", id, names)
+	}
+
+	nonce := uint64(0)
+	byNonce, err := store.Query(indexer.Query{ChainID: harness.ChainID, Nonce: &nonce})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byNonce) != 1 || byNonce[0].Name != "OpExecuted" || byNonce[0].Contract != e.Proposer.Address {
+		t.Fatalf("events of nonce 0: %+v", byNonce)
+	}
+	var args struct {
+		To common.Address `json:"to"`
+	}
+	if err := json.Unmarshal(byNonce[0].Args, &args); err != nil || args.To != e.TimelockAddress {
+		t.Fatalf("OpExecuted args = %s", byNonce[0].Args)
+	}
+
+	recent, err := store.Query(indexer.Query{ChainID: harness.ChainID, From: scheduledAfter})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"NewRoot", "CallScheduled", "OpExecuted", "MinDelayChange", "CallExecuted"}
+	if names := eventNames(recent); !equal(names, want) {
+		t.Fatalf("events since %s: %v, want %v", scheduledAfter, names, want)
+	}
+	roots, err := store.Query(indexer.Query{ChainID: harness.ChainID, Contracts: []common.Address{e.Proposer.Address}, Names: []string{"NewRoot"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roots) != 1 {
+		t.Fatalf("roots = %+v", roots)
+	}
+
+	// A second sync writes nothing new, and a contract added to the
+	// configuration is backfilled from FromBlock.
+	if _, err := ix.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Deployment.MultiSigs = append(cfg.Deployment.MultiSigs, e.Canceller.Address)
+	if ix, err = indexer.New(e.Backend, store, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ix.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	after, err := store.Query(indexer.Query{ChainID: harness.ChainID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	canceller, err := store.Query(indexer.Query{ChainID: harness.ChainID, Contracts: []common.Address{e.Canceller.Address}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(canceller) == 0 || len(after) != len(all)+len(canceller) {
+		t.Fatalf("%d events after adding the canceller with %d events, had %d", len(after), len(canceller), len(all))
+	}
+	next, ok, err := store.Checkpoint(harness.ChainID, e.Canceller.Address)
+	if err != nil || !ok || next != head+1 {
+		t.Fatalf("canceller checkpoint = %d, %t, %v; want %d", next, ok, err, head+1)
+	}
+}
+
+func TestConfirmations(t *testing.T) {
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	store := indexer.NewStore(memorydb.New())
+	ix, err := indexer.New(e.Backend, store, indexer.Config{
+		ChainID:       harness.ChainID,
+		Deployment:    &inspect.Deployment{Timelocks: []common.Address{e.TimelockAddress}},
+		Confirmations: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	synced, err := ix.Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := e.Backend.Blockchain().CurrentBlock().Number.Uint64() - 2; synced != want {
+		t.Fatalf("synced to %d, want %d", synced, want)
+	}
+	next, _, err := store.Checkpoint(harness.ChainID, e.TimelockAddress)
+	if err != nil || next != synced+1 {
+		t.Fatalf("checkpoint = %d, %v; want %d", next, err, synced+1)
+	}
+}
+
+func eventNames(events []*indexer.Event) []string {
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = e.Name
+	}
+	return names
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,268 @@
+package indexer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+)
+
+// Key layout. Every key starts with a one-byte table prefix and the chain
+// id; numbers are big-endian so that keys sort in chain order.
+//
+//	e chain block logIndex             -> event JSON
+//	c chain contract block logIndex    -> (empty) events by contract
+//	o chain opID block logIndex        -> (empty) events by operation id
+//	n chain nonce block logIndex       -> (empty) events by op nonce
+//	p chain contract                   -> next block to index for contract
+const (
+	prefixEvent      = 'e'
+	prefixContract   = 'c'
+	prefixOpID       = 'o'
+	prefixNonce      = 'n'
+	prefixCheckpoint = 'p'
+)
+
+// positionLength is the length of the block number and log index suffix
+// shared by the event key and the index keys.
+const positionLength = 8 + 4
+
+// Store holds indexed events in an embedded key-value database.
+type Store struct {
+	db ethdb.KeyValueStore
+}
+
+// NewStore returns a store backed by db, e.g. an in-memory
+// memorydb.Database in tests.
+func NewStore(db ethdb.KeyValueStore) *Store {
+	return &Store{db: db}
+}
+
+// Open opens or creates a LevelDB store in the directory at path.
+func Open(path string) (*Store, error) {
+	db, err := leveldb.New(path, 16, 16, "", false)
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(db), nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func key(table byte, chainID *big.Int, parts ...[]byte) []byte {
+	k := []byte{table}
+	k = binary.BigEndian.AppendUint64(k, chainID.Uint64())
+	for _, p := range parts {
+		k = append(k, p...)
+	}
+	return k
+}
+
+func position(block uint64, logIndex uint) []byte {
+	p := binary.BigEndian.AppendUint64(nil, block)
+	return binary.BigEndian.AppendUint32(p, uint32(logIndex))
+}
+
+func uint64Bytes(n uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, n)
+}
+
+// putEvent adds e and its index entries to batch. Writing the same event
+// twice leaves the store unchanged.
+func putEvent(batch ethdb.Batch, e *Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	pos := position(e.BlockNumber, e.LogIndex)
+	if err := batch.Put(key(prefixEvent, e.ChainID, pos), data); err != nil {
+		return err
+	}
+	if err := batch.Put(key(prefixContract, e.ChainID, e.Contract[:], pos), nil); err != nil {
+		return err
+	}
+	if e.OpID != nil {
+		if err := batch.Put(key(prefixOpID, e.ChainID, e.OpID[:], pos), nil); err != nil {
+			return err
+		}
+	}
+	if e.Nonce != nil {
+		if err := batch.Put(key(prefixNonce, e.ChainID, uint64Bytes(*e.Nonce), pos), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func putCheckpoint(batch ethdb.Batch, chainID *big.Int, contract common.Address, next uint64) error {
+	return batch.Put(key(prefixCheckpoint, chainID, contract[:]), uint64Bytes(next))
+}
+
+// Checkpoint returns the next block to index for contract on a chain, or
+// false if the contract has not been indexed yet.
+func (s *Store) Checkpoint(chainID *big.Int, contract common.Address) (uint64, bool, error) {
+	k := key(prefixCheckpoint, chainID, contract[:])
+	if ok, err := s.db.Has(k); err != nil || !ok {
+		return 0, false, err
+	}
+	data, err := s.db.Get(k)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(data) != 8 {
+		return 0, false, errors.New("corrupt checkpoint")
+	}
+	return binary.BigEndian.Uint64(data), true, nil
+}
+
+// Query selects events. Zero fields match everything.
+type Query struct {
+	// ChainID is required.
+	ChainID   *big.Int
+	Contracts []common.Address
+	// Names are event names, e.g. "NewRoot".
+	Names []string
+	OpID  *common.Hash
+	Nonce *uint64
+	// From and To bound the block time; To is exclusive.
+	From, To time.Time
+	// Limit caps the number of events returned, the earliest first.
+	Limit int
+}
+
+func (q *Query) match(e *Event) bool {
+	if len(q.Contracts) > 0 && !containsAddress(q.Contracts, e.Contract) {
+		return false
+	}
+	if len(q.Names) > 0 && !containsString(q.Names, e.Name) {
+		return false
+	}
+	if q.OpID != nil && (e.OpID == nil || *e.OpID != *q.OpID) {
+		return false
+	}
+	if q.Nonce != nil && (e.Nonce == nil || *e.Nonce != *q.Nonce) {
+		return false
+	}
+	if !q.From.IsZero() && e.BlockTime < uint64(q.From.Unix()) {
+		return false
+	}
+	if !q.To.IsZero() && e.BlockTime >= uint64(q.To.Unix()) {
+		return false
+	}
+	return true
+}
+
+// Query returns the events matching q in chain order. It walks the most
+// selective index the query allows and filters on the remaining fields.
+func (s *Store) Query(q Query) ([]*Event, error) {
+	if q.ChainID == nil {
+		return nil, errors.New("query without chain id")
+	}
+	var prefixes [][]byte
+	switch {
+	case q.OpID != nil:
+		prefixes = [][]byte{key(prefixOpID, q.ChainID, q.OpID[:])}
+	case q.Nonce != nil:
+		prefixes = [][]byte{key(prefixNonce, q.ChainID, uint64Bytes(*q.Nonce))}
+	case len(q.Contracts) > 0:
+		for _, c := range q.Contracts {
+			prefixes = append(prefixes, key(prefixContract, q.ChainID, c[:]))
+		}
+	default:
+		return s.scan(q)
+	}
+
+	var positions [][]byte
+	for _, prefix := range prefixes {
+		it := s.db.NewIterator(prefix, nil)
+		for it.Next() {
+			positions = append(positions, common.CopyBytes(it.Key()[len(prefix):]))
+		}
+		it.Release()
+		if err := it.Error(); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(positions, func(i, j int) bool { return bytes.Compare(positions[i], positions[j]) < 0 })
+
+	var events []*Event
+	for _, pos := range positions {
+		if len(pos) != positionLength {
+			return nil, errors.New("corrupt index key")
+		}
+		data, err := s.db.Get(key(prefixEvent, q.ChainID, pos))
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeEvent(data)
+		if err != nil {
+			return nil, err
+		}
+		if q.match(e) {
+			events = append(events, e)
+			if q.Limit > 0 && len(events) == q.Limit {
+				break
+			}
+		}
+	}
+	return events, nil
+}
+
+// scan walks all events of the chain, stopping at the end of the time range.
+func (s *Store) scan(q Query) ([]*Event, error) {
+	it := s.db.NewIterator(key(prefixEvent, q.ChainID), nil)
+	defer it.Release()
+	var events []*Event
+	for it.Next() {
+		e, err := decodeEvent(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		if !q.To.IsZero() && e.BlockTime >= uint64(q.To.Unix()) {
+			break
+		}
+		if q.match(e) {
+			events = append(events, e)
+			if q.Limit > 0 && len(events) == q.Limit {
+				break
+			}
+		}
+	}
+	return events, it.Error()
+}
+
+func decodeEvent(data []byte) (*Event, error) {
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func containsAddress(list []common.Address, a common.Address) bool {
+	for _, x := range list {
+		if x == a {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package inspect
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// callProxyPrefix is the start of the CallProxy runtime code, up to the
+// PUSH32 that loads the i_target immutable.
+var callProxyPrefix = common.FromHex("0x60806040527f")
+
+// CallProxyTarget extracts the i_target immutable from the runtime code of a
+// CallProxy. CallProxy has no getter for its target; the compiler inlines the
+// immutable as the first PUSH32 of the runtime code instead.
+func CallProxyTarget(code []byte) (common.Address, error) {
+	end := len(callProxyPrefix) + common.HashLength
+	if len(code) < end || !bytes.HasPrefix(code, callProxyPrefix) {
+		return common.Address{}, errors.New("code is not a CallProxy")
+	}
+	word := code[len(callProxyPrefix):end]
+	if !bytes.Equal(word[:common.HashLength-common.AddressLength], make([]byte, common.HashLength-common.AddressLength)) {
+		return common.Address{}, errors.New("code is not a CallProxy")
+	}
+	return common.BytesToAddress(word), nil
+}
@@ -0,0 +1,84 @@
+package inspect
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Change is a difference between two snapshots. Path is a JSON path into the
+// snapshot, e.g. "multiSigs.0xAbC...config.Signers[2].Addr". Old is unset for
+// added values and New for removed ones.
+type Change struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// Diff returns the changes between snapshots a and b, sorted by path. The
+// block fields are not compared.
+func Diff(a, b *Snapshot) ([]Change, error) {
+	flatA, err := flattenState(a)
+	if err != nil {
+		return nil, err
+	}
+	flatB, err := flattenState(b)
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+	for path, old := range flatA {
+		if updated, ok := flatB[path]; !ok {
+			changes = append(changes, Change{Path: path, Old: old})
+		} else if fmt.Sprint(old) != fmt.Sprint(updated) {
+			changes = append(changes, Change{Path: path, Old: old, New: updated})
+		}
+	}
+	for path, added := range flatB {
+		if _, ok := flatA[path]; !ok {
+			changes = append(changes, Change{Path: path, New: added})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// flattenState maps the JSON path of every leaf value of the contract state
+// in s to the value.
+func flattenState(s *Snapshot) (map[string]interface{}, error) {
+	state := struct {
+		MultiSigs   interface{} `json:"multiSigs"`
+		Timelocks   interface{} `json:"timelocks"`
+		CallProxies interface{} `json:"callProxies"`
+	}{s.MultiSigs, s.Timelocks, s.CallProxies}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	flat := make(map[string]interface{})
+	flatten("", tree, flat)
+	return flat, nil
+}
+
+func flatten(path string, v interface{}, flat map[string]interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if path == "" {
+				flatten(key, child, flat)
+			} else {
+				flatten(path+"."+key, child, flat)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			flatten(fmt.Sprintf("%s[%d]", path, i), child, flat)
+		}
+	default:
+		flat[path] = v
+	}
+}
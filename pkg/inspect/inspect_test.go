@@ -0,0 +1,69 @@
+package inspect
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+func TestSnapshotDiff(t *testing.T) {
+	ctx := context.Background()
+	e := harness.New(t, harness.Options{})
+	d := &Deployment{
+		MultiSigs:   []common.Address{e.Proposer.Address},
+		Timelocks:   []common.Address{e.TimelockAddress},
+		CallProxies: []common.Address{e.CallProxyAddress},
+	}
+
+	before, err := Take(ctx, e.Backend, d, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := before.CallProxies[e.CallProxyAddress].Target; got != e.TimelockAddress {
+		t.Fatalf("CallProxy target = %s, want %s", got, e.TimelockAddress)
+	}
+	if got := before.MultiSigs[e.Proposer.Address].Owner; got != e.TimelockAddress {
+		t.Fatalf("proposer owner = %s, want %s", got, e.TimelockAddress)
+	}
+
+	newOwner := common.HexToAddress("0x1234")
+	transfer, err := mcms.ManyChainMultiSigABI.Pack("transferOwnership", newOwner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch := &timelock.Batch{Calls: []timelock.Call{
+		{Target: e.Proposer.Address, Data: transfer},
+		{Target: e.TimelockAddress, Data: e.TimelockOp("grantRole", timelock.ExecutorRole.ID, newOwner).Data},
+		{Target: e.TimelockAddress, Data: e.TimelockOp("blockFunctionSelector", [4]byte{1, 2, 3, 4}).Data},
+	}}
+	e.Submit(e.NewProposal(e.Bypasser, e.TimelockOp("bypasserExecuteBatch", batch.RBACTimelockCalls())))
+
+	after, err := Take(ctx, e.Backend, d, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes, err := Diff(before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, c := range changes {
+		paths = append(paths, c.Path)
+	}
+	multiSig := strings.ToLower(e.Proposer.Address.Hex())
+	tl := strings.ToLower(e.TimelockAddress.Hex())
+	want := []string{
+		"multiSigs." + multiSig + ".pendingOwner",
+		"timelocks." + tl + ".blockedSelectors[0]",
+		"timelocks." + tl + ".roles.EXECUTOR_ROLE[1]",
+	}
+	if strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Fatalf("changed paths = %v, want %v", paths, want)
+	}
+}
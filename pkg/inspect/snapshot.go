@@ -0,0 +1,184 @@
+// Package inspect takes read-only snapshots of the state of an owner-contract
+// deployment and diffs them.
+package inspect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+// Backend is the subset of a chain client needed to take a snapshot. If it
+// also has a ChainID method, as ethclient.Client does, the snapshot records
+// the chain id.
+type Backend interface {
+	bind.ContractCaller
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Deployment lists the contracts of a deployment.
+type Deployment struct {
+	MultiSigs   []common.Address `json:"multiSigs"`
+	Timelocks   []common.Address `json:"timelocks"`
+	CallProxies []common.Address `json:"callProxies"`
+}
+
+// LoadDeployment reads a deployment file.
+func LoadDeployment(path string) (*Deployment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var d Deployment
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return &d, nil
+}
+
+// Snapshot is the state of a deployment at one block. Contracts are keyed by
+// address so that snapshots of the same deployment diff cleanly.
+type Snapshot struct {
+	ChainID     *big.Int                           `json:"chainId,omitempty"`
+	BlockNumber uint64                             `json:"blockNumber"`
+	BlockHash   common.Hash                        `json:"blockHash"`
+	BlockTime   uint64                             `json:"blockTime"`
+	MultiSigs   map[common.Address]*MultiSigState  `json:"multiSigs"`
+	Timelocks   map[common.Address]*TimelockState  `json:"timelocks"`
+	CallProxies map[common.Address]*CallProxyState `json:"callProxies"`
+}
+
+// MultiSigState is the state of a ManyChainMultiSig.
+type MultiSigState struct {
+	Owner        common.Address                             `json:"owner"`
+	PendingOwner common.Address                             `json:"pendingOwner"`
+	Config       gethwrappers.ManyChainMultiSigConfig       `json:"config"`
+	Root         common.Hash                                `json:"root"`
+	ValidUntil   uint32                                     `json:"validUntil"`
+	RootMetadata gethwrappers.ManyChainMultiSigRootMetadata `json:"rootMetadata"`
+	OpCount      uint64                                     `json:"opCount"`
+}
+
+// TimelockState is the state of an RBACTimelock.
+type TimelockState struct {
+	MinDelay         *big.Int                    `json:"minDelay"`
+	Roles            map[string][]common.Address `json:"roles"`
+	BlockedSelectors []hexutil.Bytes             `json:"blockedSelectors"`
+}
+
+// CallProxyState is the state of a CallProxy.
+type CallProxyState struct {
+	Target common.Address `json:"target"`
+}
+
+// Take snapshots d at blockNumber, or at the latest block if blockNumber is
+// nil. Snapshots of past blocks need an archive node.
+func Take(ctx context.Context, backend Backend, d *Deployment, blockNumber *big.Int) (*Snapshot, error) {
+	head, err := backend.HeaderByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	s := &Snapshot{
+		BlockNumber: head.Number.Uint64(),
+		BlockHash:   head.Hash(),
+		BlockTime:   head.Time,
+		MultiSigs:   make(map[common.Address]*MultiSigState),
+		Timelocks:   make(map[common.Address]*TimelockState),
+		CallProxies: make(map[common.Address]*CallProxyState),
+	}
+	if c, ok := backend.(interface {
+		ChainID(ctx context.Context) (*big.Int, error)
+	}); ok {
+		if s.ChainID, err = c.ChainID(ctx); err != nil {
+			return nil, err
+		}
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: head.Number}
+	for _, addr := range d.MultiSigs {
+		if s.MultiSigs[addr], err = multiSigState(opts, backend, addr); err != nil {
+			return nil, fmt.Errorf("multiSig %s: %w", addr, err)
+		}
+	}
+	for _, addr := range d.Timelocks {
+		if s.Timelocks[addr], err = timelockState(opts, backend, addr); err != nil {
+			return nil, fmt.Errorf("timelock %s: %w", addr, err)
+		}
+	}
+	for _, addr := range d.CallProxies {
+		code, err := backend.CodeAt(ctx, addr, head.Number)
+		if err != nil {
+			return nil, fmt.Errorf("callProxy %s: %w", addr, err)
+		}
+		target, err := CallProxyTarget(code)
+		if err != nil {
+			return nil, fmt.Errorf("callProxy %s: %w", addr, err)
+		}
+		s.CallProxies[addr] = &CallProxyState{Target: target}
+	}
+	return s, nil
+}
+
+func multiSigState(opts *bind.CallOpts, backend bind.ContractCaller, addr common.Address) (*MultiSigState, error) {
+	caller, err := gethwrappers.NewManyChainMultiSigCaller(addr, backend)
+	if err != nil {
+		return nil, err
+	}
+	var st MultiSigState
+	if st.Owner, err = caller.Owner(opts); err != nil {
+		return nil, err
+	}
+	if st.PendingOwner, err = caller.PendingOwner(opts); err != nil {
+		return nil, err
+	}
+	if st.Config, err = caller.GetConfig(opts); err != nil {
+		return nil, err
+	}
+	root, err := caller.GetRoot(opts)
+	if err != nil {
+		return nil, err
+	}
+	st.Root, st.ValidUntil = root.Root, root.ValidUntil
+	if st.RootMetadata, err = caller.GetRootMetadata(opts); err != nil {
+		return nil, err
+	}
+	opCount, err := caller.GetOpCount(opts)
+	if err != nil {
+		return nil, err
+	}
+	st.OpCount = opCount.Uint64()
+	return &st, nil
+}
+
+func timelockState(opts *bind.CallOpts, backend bind.ContractCaller, addr common.Address) (*TimelockState, error) {
+	caller, err := gethwrappers.NewRBACTimelockCaller(addr, backend)
+	if err != nil {
+		return nil, err
+	}
+	st := TimelockState{Roles: make(map[string][]common.Address), BlockedSelectors: []hexutil.Bytes{}}
+	if st.MinDelay, err = caller.GetMinDelay(opts); err != nil {
+		return nil, err
+	}
+	for _, role := range timelock.Roles {
+		if st.Roles[role.Name], err = timelock.RoleMembers(opts, caller, role); err != nil {
+			return nil, err
+		}
+	}
+	selectors, err := timelock.BlockedSelectors(opts, caller)
+	if err != nil {
+		return nil, err
+	}
+	for _, sel := range selectors {
+		st.BlockedSelectors = append(st.BlockedSelectors, sel[:])
+	}
+	return &st, nil
+}
@@ -0,0 +1,235 @@
+// Package manifest is the address book of owner-contract deployments: for
+// every chain, which contract plays which role in the topology and how it was
+// deployed. Tools look addresses up here instead of having them pasted in.
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/inspect"
+)
+
+// Role is the part a contract plays in the topology described in the README.
+type Role string
+
+// The roles of a deployment.
+const (
+	RoleProposer  Role = "proposer"
+	RoleCanceller Role = "canceller"
+	RoleBypasser  Role = "bypasser"
+	RoleTimelock  Role = "timelock"
+	RoleCallProxy Role = "callProxy"
+)
+
+// Roles lists every role in deployment order.
+var Roles = []Role{RoleProposer, RoleCanceller, RoleBypasser, RoleTimelock, RoleCallProxy}
+
+// The contract types, named after their bindings in gethwrappers.
+const (
+	TypeManyChainMultiSig = "ManyChainMultiSig"
+	TypeRBACTimelock      = "RBACTimelock"
+	TypeCallProxy         = "CallProxy"
+)
+
+// ContractType returns the type of the contract deployed in role, or "" for
+// an unknown role.
+func (r Role) ContractType() string {
+	switch r {
+	case RoleProposer, RoleCanceller, RoleBypasser:
+		return TypeManyChainMultiSig
+	case RoleTimelock:
+		return TypeRBACTimelock
+	case RoleCallProxy:
+		return TypeCallProxy
+	}
+	return ""
+}
+
+// Manifest lists the deployments on every chain.
+type Manifest struct {
+	Chains []*Chain `json:"chains"`
+}
+
+// Chain is the deployment on one chain.
+type Chain struct {
+	ChainID   *big.Int    `json:"chainId"`
+	Name      string      `json:"name,omitempty"`
+	Contracts []*Contract `json:"contracts"`
+}
+
+// Contract is one deployed contract.
+type Contract struct {
+	Role    Role           `json:"role"`
+	Type    string         `json:"type"`
+	Address common.Address `json:"address"`
+	// DeployTx and Block locate the deployment transaction.
+	DeployTx common.Hash `json:"deployTx"`
+	Block    uint64      `json:"block"`
+	// CodeHash is the keccak256 hash of the runtime code.
+	CodeHash common.Hash `json:"codeHash"`
+	// ConstructorArgs are the ABI encoded constructor arguments.
+	ConstructorArgs hexutil.Bytes `json:"constructorArgs"`
+	// WrapperVersion identifies the binding that deployed the contract; see
+	// WrapperVersion.
+	WrapperVersion string `json:"wrapperVersion"`
+}
+
+// Load reads a manifest from path.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Save validates m and writes it to path, chains sorted by id and contracts
+// in deployment order.
+func (m *Manifest) Save(path string) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	sort.Slice(m.Chains, func(i, j int) bool { return m.Chains[i].ChainID.Cmp(m.Chains[j].ChainID) < 0 })
+	for _, c := range m.Chains {
+		sort.SliceStable(c.Contracts, func(i, j int) bool { return roleIndex(c.Contracts[i].Role) < roleIndex(c.Contracts[j].Role) })
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Validate checks that every chain appears once with a positive id and every
+// role at most once per chain, with the matching contract type and a
+// non-zero address.
+func (m *Manifest) Validate() error {
+	seen := make(map[string]bool)
+	for i, c := range m.Chains {
+		if c.ChainID == nil || c.ChainID.Sign() <= 0 {
+			return fmt.Errorf("chains[%d]: invalid chain id", i)
+		}
+		if seen[c.ChainID.String()] {
+			return fmt.Errorf("chains[%d]: duplicate entry for chain %v", i, c.ChainID)
+		}
+		seen[c.ChainID.String()] = true
+		roles := make(map[Role]bool)
+		for j, contract := range c.Contracts {
+			typ := contract.Role.ContractType()
+			switch {
+			case typ == "":
+				return fmt.Errorf("chain %v: contracts[%d]: unknown role %q", c.ChainID, j, contract.Role)
+			case contract.Type != typ:
+				return fmt.Errorf("chain %v: %s is a %s, not a %s", c.ChainID, contract.Role, typ, contract.Type)
+			case contract.Address == (common.Address{}):
+				return fmt.Errorf("chain %v: %s has no address", c.ChainID, contract.Role)
+			case roles[contract.Role]:
+				return fmt.Errorf("chain %v: duplicate %s", c.ChainID, contract.Role)
+			}
+			roles[contract.Role] = true
+		}
+	}
+	return nil
+}
+
+// Chain returns the deployment on chainID, or nil.
+func (m *Manifest) Chain(chainID *big.Int) *Chain {
+	if chainID == nil {
+		return nil
+	}
+	for _, c := range m.Chains {
+		if c.ChainID.Cmp(chainID) == 0 {
+			return c
+		}
+	}
+	return nil
+}
+
+// Address returns the address of the contract in role on chainID.
+func (m *Manifest) Address(chainID *big.Int, role Role) (common.Address, error) {
+	c := m.Chain(chainID)
+	if c == nil {
+		return common.Address{}, fmt.Errorf("manifest has no chain %v", chainID)
+	}
+	contract := c.Contract(role)
+	if contract == nil {
+		return common.Address{}, fmt.Errorf("manifest has no %s on chain %v", role, chainID)
+	}
+	return contract.Address, nil
+}
+
+// Set adds contract to the deployment on chainID, replacing the contract
+// with the same role if there is one.
+func (m *Manifest) Set(chainID *big.Int, contract *Contract) {
+	c := m.Chain(chainID)
+	if c == nil {
+		c = &Chain{ChainID: new(big.Int).Set(chainID)}
+		m.Chains = append(m.Chains, c)
+	}
+	for i, existing := range c.Contracts {
+		if existing.Role == contract.Role {
+			c.Contracts[i] = contract
+			return
+		}
+	}
+	c.Contracts = append(c.Contracts, contract)
+}
+
+// Contract returns the contract in role, or nil.
+func (c *Chain) Contract(role Role) *Contract {
+	for _, contract := range c.Contracts {
+		if contract.Role == role {
+			return contract
+		}
+	}
+	return nil
+}
+
+// Deployment lists the contracts of c for inspect.Take.
+func (c *Chain) Deployment() *inspect.Deployment {
+	d := &inspect.Deployment{}
+	for _, contract := range c.Contracts {
+		switch contract.Type {
+		case TypeManyChainMultiSig:
+			d.MultiSigs = append(d.MultiSigs, contract.Address)
+		case TypeRBACTimelock:
+			d.Timelocks = append(d.Timelocks, contract.Address)
+		case TypeCallProxy:
+			d.CallProxies = append(d.CallProxies, contract.Address)
+		}
+	}
+	return d
+}
+
+// ByAddress returns the contract at addr, or nil.
+func (c *Chain) ByAddress(addr common.Address) *Contract {
+	for _, contract := range c.Contracts {
+		if contract.Address == addr {
+			return contract
+		}
+	}
+	return nil
+}
+
+func roleIndex(r Role) int {
+	for i, role := range Roles {
+		if role == r {
+			return i
+		}
+	}
+	return len(Roles)
+}
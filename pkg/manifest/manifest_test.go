@@ -0,0 +1,143 @@
+package manifest_test
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/deploy"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/manifest"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+func TestFromDeployState(t *testing.T) {
+	ctx := context.Background()
+	e := harness.NewBare(t)
+	spec := &deploy.Spec{MinDelay: "1h"}
+	for _, ms := range []*deploy.MultiSigSpec{&spec.Proposer, &spec.Canceller, &spec.Bypasser} {
+		ms.SignerAddresses = harness.SignerAddresses(harness.GenerateSigners(t, 1))
+		ms.SignerGroups = []uint8{0}
+		ms.GroupQuorums[0] = 1
+	}
+	dir := t.TempDir()
+	state, err := deploy.Deploy(ctx, e.AutoMining(), e.Deployer.Opts, spec, deploy.FileStore(filepath.Join(dir, "state.json")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &manifest.Manifest{}
+	if err := m.FromDeployState(ctx, e.Backend, harness.ChainID, state); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "manifest.json")
+	if err := m.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if m, err = manifest.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	chain := m.Chain(harness.ChainID)
+	if chain == nil || len(chain.Contracts) != len(manifest.Roles) {
+		t.Fatalf("chain = %+v", chain)
+	}
+	if err := chain.VerifyCode(ctx, e.Backend); err != nil {
+		t.Fatal(err)
+	}
+
+	timelock, err := m.Address(harness.ChainID, manifest.RoleTimelock)
+	if err != nil || timelock != state.Timelock {
+		t.Fatalf("timelock = %s, %v; want %s", timelock, err, state.Timelock)
+	}
+	proxy := chain.Contract(manifest.RoleCallProxy)
+	wantArgs := common.LeftPadBytes(state.Timelock[:], 32)
+	if string(proxy.ConstructorArgs) != string(wantArgs) || proxy.WrapperVersion != manifest.WrapperVersion(manifest.TypeCallProxy) {
+		t.Fatalf("callProxy = %+v", proxy)
+	}
+	if d := chain.Deployment(); len(d.MultiSigs) != 3 || d.Timelocks[0] != state.Timelock || d.CallProxies[0] != state.CallProxy {
+		t.Fatalf("deployment = %+v", d)
+	}
+	if _, err := m.Address(big.NewInt(1), manifest.RoleTimelock); err == nil {
+		t.Fatal("lookup on an unknown chain succeeded")
+	}
+
+	// A contract whose code differs from the recorded hash is reported.
+	otherAddr, _, _, err := gethwrappers.DeployCallProxy(e.Deployer.Opts, e.Backend, common.Address{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Commit()
+	proxy.Address = otherAddr
+	if err := chain.VerifyCode(ctx, e.Backend); err == nil || !strings.Contains(err.Error(), "code hash") {
+		t.Fatalf("VerifyCode of a different contract: got %v", err)
+	}
+	proxy.Address = common.Address{2}
+	if err := chain.VerifyCode(ctx, e.Backend); err == nil || !strings.Contains(err.Error(), "no code") {
+		t.Fatalf("VerifyCode of an EOA: got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	contract := func(role manifest.Role, addr byte) *manifest.Contract {
+		return &manifest.Contract{Role: role, Type: role.ContractType(), Address: common.Address{addr}}
+	}
+	for name, m := range map[string]*manifest.Manifest{
+		"no chain id":    {Chains: []*manifest.Chain{{}}},
+		"duplicate":      {Chains: []*manifest.Chain{{ChainID: big.NewInt(1)}, {ChainID: big.NewInt(1)}}},
+		"unknown role":   {Chains: []*manifest.Chain{{ChainID: big.NewInt(1), Contracts: []*manifest.Contract{contract("owner", 1)}}}},
+		"no address":     {Chains: []*manifest.Chain{{ChainID: big.NewInt(1), Contracts: []*manifest.Contract{contract(manifest.RoleTimelock, 0)}}}},
+		"duplicate role": {Chains: []*manifest.Chain{{ChainID: big.NewInt(1), Contracts: []*manifest.Contract{contract(manifest.RoleTimelock, 1), contract(manifest.RoleTimelock, 2)}}}},
+		"wrong type": {Chains: []*manifest.Chain{{ChainID: big.NewInt(1), Contracts: []*manifest.Contract{
+			{Role: manifest.RoleCallProxy, Type: manifest.TypeRBACTimelock, Address: common.Address{1}},
+		}}}},
+	} {
+		if err := m.Validate(); err == nil {
+			t.Errorf("%s: manifest validated", name)
+		}
+	}
+}
+
+func TestResolveProposalSpec(t *testing.T) {
+	m := &manifest.Manifest{}
+	for i, role := range []manifest.Role{manifest.RoleProposer, manifest.RoleBypasser} {
+		m.Set(big.NewInt(1), &manifest.Contract{Role: role, Type: role.ContractType(), Address: common.Address{byte(i + 1)}})
+	}
+	m.Set(big.NewInt(2), &manifest.Contract{Role: manifest.RoleProposer, Type: manifest.TypeManyChainMultiSig, Address: common.Address{3}})
+
+	spec := &mcms.ProposalSpec{
+		Chains: []mcms.ChainSpec{
+			{ChainID: big.NewInt(1), MultiSigRole: "bypasser"},
+			{ChainID: big.NewInt(2), MultiSigRole: "proposer"},
+		},
+		Ops: []mcms.Operation{{ChainID: big.NewInt(1)}, {ChainID: big.NewInt(2)}},
+	}
+	if err := m.ResolveProposalSpec(spec); err != nil {
+		t.Fatal(err)
+	}
+	if spec.Chains[0].MultiSig != (common.Address{2}) || spec.Chains[1].MultiSig != (common.Address{3}) {
+		t.Fatalf("chains = %+v", spec.Chains)
+	}
+	if spec.Ops[0].MultiSig != (common.Address{2}) || spec.Ops[1].MultiSig != (common.Address{3}) {
+		t.Fatalf("ops = %+v", spec.Ops)
+	}
+
+	for name, spec := range map[string]*mcms.ProposalSpec{
+		"not a multisig": {Chains: []mcms.ChainSpec{{ChainID: big.NewInt(1), MultiSigRole: "timelock"}}},
+		"no chainId":     {Chains: []mcms.ChainSpec{{MultiSigRole: "proposer"}}},
+		"missing":        {Chains: []mcms.ChainSpec{{ChainID: big.NewInt(2), MultiSigRole: "bypasser"}}},
+		"conflict":       {Chains: []mcms.ChainSpec{{ChainID: big.NewInt(1), MultiSigRole: "proposer", MultiSig: common.Address{9}}}},
+		"ambiguous op": {
+			Chains: []mcms.ChainSpec{{ChainID: big.NewInt(1), MultiSigRole: "proposer"}, {ChainID: big.NewInt(1), MultiSigRole: "bypasser"}},
+			Ops:    []mcms.Operation{{ChainID: big.NewInt(1)}},
+		},
+	} {
+		if err := m.ResolveProposalSpec(spec); err == nil {
+			t.Errorf("%s: resolved", name)
+		}
+	}
+}
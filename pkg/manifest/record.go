@@ -0,0 +1,128 @@
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/deploy"
+)
+
+// Backend is what recording and verifying contracts needs from a node.
+type Backend interface {
+	CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+}
+
+var metaData = map[string]*bind.MetaData{
+	TypeManyChainMultiSig: gethwrappers.ManyChainMultiSigMetaData,
+	TypeRBACTimelock:      gethwrappers.RBACTimelockMetaData,
+	TypeCallProxy:         gethwrappers.CallProxyMetaData,
+}
+
+// WrapperVersion identifies the binding of typ in gethwrappers by the first
+// eight bytes of the keccak256 hash of its creation code, so that a manifest
+// entry shows whether the contract was deployed from the current bindings.
+func WrapperVersion(typ string) string {
+	md, ok := metaData[typ]
+	if !ok {
+		return ""
+	}
+	return common.Bytes2Hex(crypto.Keccak256(common.FromHex(md.Bin))[:8])
+}
+
+// NewContract builds the manifest entry of the contract deployed in role by
+// deployTx, which must have been sent with the current bindings.
+func NewContract(ctx context.Context, backend Backend, role Role, deployTx common.Hash) (*Contract, error) {
+	typ := role.ContractType()
+	if typ == "" {
+		return nil, fmt.Errorf("unknown role %q", role)
+	}
+	tx, pending, err := backend.TransactionByHash(ctx, deployTx)
+	if err != nil {
+		return nil, fmt.Errorf("%s deploy tx %s: %w", role, deployTx, err)
+	}
+	if pending {
+		return nil, fmt.Errorf("%s deploy tx %s is pending", role, deployTx)
+	}
+	receipt, err := backend.TransactionReceipt(ctx, deployTx)
+	if err != nil {
+		return nil, fmt.Errorf("%s deploy tx %s: %w", role, deployTx, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful || receipt.ContractAddress == (common.Address{}) {
+		return nil, fmt.Errorf("%s deploy tx %s did not deploy a contract", role, deployTx)
+	}
+	bin := common.FromHex(metaData[typ].Bin)
+	if tx.To() != nil || !bytes.HasPrefix(tx.Data(), bin) {
+		return nil, fmt.Errorf("%s deploy tx %s was not sent with the current %s binding", role, deployTx, typ)
+	}
+	code, err := backend.CodeAt(ctx, receipt.ContractAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Contract{
+		Role:            role,
+		Type:            typ,
+		Address:         receipt.ContractAddress,
+		DeployTx:        deployTx,
+		Block:           receipt.BlockNumber.Uint64(),
+		CodeHash:        crypto.Keccak256Hash(code),
+		ConstructorArgs: tx.Data()[len(bin):],
+		WrapperVersion:  WrapperVersion(typ),
+	}, nil
+}
+
+// deployRoles maps the deploy steps of pkg/deploy to roles.
+var deployRoles = map[Role]string{
+	RoleProposer:  "deploy proposer",
+	RoleCanceller: "deploy canceller",
+	RoleBypasser:  "deploy bypasser",
+	RoleTimelock:  "deploy timelock",
+	RoleCallProxy: "deploy callProxy",
+}
+
+// FromDeployState records a deployment finished by deploy.Deploy on
+// chainID in m.
+func (m *Manifest) FromDeployState(ctx context.Context, backend Backend, chainID *big.Int, s *deploy.State) error {
+	if !s.Done {
+		return fmt.Errorf("deployment on chain %v is not done", chainID)
+	}
+	for _, role := range Roles {
+		tx, ok := s.Txs[deployRoles[role]]
+		if !ok {
+			return fmt.Errorf("deployment state has no %s deploy tx", role)
+		}
+		contract, err := NewContract(ctx, backend, role, tx)
+		if err != nil {
+			return err
+		}
+		m.Set(chainID, contract)
+	}
+	return nil
+}
+
+// VerifyCode checks that every contract of c is deployed with the recorded
+// code hash.
+func (c *Chain) VerifyCode(ctx context.Context, backend bind.ContractCaller) error {
+	for _, contract := range c.Contracts {
+		code, err := backend.CodeAt(ctx, contract.Address, nil)
+		if err != nil {
+			return fmt.Errorf("chain %v: %s: %w", c.ChainID, contract.Role, err)
+		}
+		if len(code) == 0 {
+			return fmt.Errorf("chain %v: %s %s has no code", c.ChainID, contract.Role, contract.Address)
+		}
+		if hash := crypto.Keccak256Hash(code); hash != contract.CodeHash {
+			return fmt.Errorf("chain %v: %s %s has code hash %s, manifest records %s", c.ChainID, contract.Role, contract.Address, hash, contract.CodeHash)
+		}
+	}
+	return nil
+}
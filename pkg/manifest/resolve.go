@@ -0,0 +1,53 @@
+package manifest
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+// ResolveProposalSpec fills in the multiSig of every chain of spec that names
+// its multisig by MultiSigRole, and of the ops on that chain that leave their
+// multiSig empty.
+func (m *Manifest) ResolveProposalSpec(spec *mcms.ProposalSpec) error {
+	for i := range spec.Chains {
+		c := &spec.Chains[i]
+		if c.MultiSigRole == "" {
+			continue
+		}
+		if c.ChainID == nil {
+			return fmt.Errorf("chains[%d]: no chainId", i)
+		}
+		role := Role(c.MultiSigRole)
+		if role.ContractType() != TypeManyChainMultiSig {
+			return fmt.Errorf("chains[%d]: %q is not a multisig role", i, c.MultiSigRole)
+		}
+		addr, err := m.Address(c.ChainID, role)
+		if err != nil {
+			return fmt.Errorf("chains[%d]: %w", i, err)
+		}
+		if c.MultiSig != (common.Address{}) && c.MultiSig != addr {
+			return fmt.Errorf("chains[%d]: multiSig %s is not the %s %s", i, c.MultiSig, role, addr)
+		}
+		c.MultiSig = addr
+	}
+	for i := range spec.Ops {
+		op := &spec.Ops[i]
+		if op.MultiSig != (common.Address{}) {
+			continue
+		}
+		var matches []common.Address
+		for _, c := range spec.Chains {
+			if c.MultiSigRole != "" && c.ChainID != nil && op.ChainID != nil && c.ChainID.Cmp(op.ChainID) == 0 {
+				matches = append(matches, c.MultiSig)
+			}
+		}
+		if len(matches) != 1 {
+			return fmt.Errorf("ops[%d]: multiSig not given and chain %v has %d multisigs named by role", i, op.ChainID, len(matches))
+		}
+		op.MultiSig = matches[0]
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package mcms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+)
+
+// The air-gapped signing workflow moves a proposal to an offline machine and
+// a signature back using two files:
+//
+//  1. the online aggregator writes a SigningRequest (NewSigningRequest),
+//  2. the offline tool re-derives everything from the embedded proposal
+//     (SigningRequest.Verify), shows the op summary to the signer and signs
+//     (SigningRequest.Sign), producing a SignatureBundle,
+//  3. the aggregator imports the bundle into its copy of the proposal
+//     (ImportSignatureBundle), which checks it against the proposal.
+//
+// Nothing in a request is trusted by the offline side: the root, signing hash
+// and summary are only displayed after being recomputed from the ops.
+
+// BundleVersion is the version of the request and signature bundle formats.
+const BundleVersion = 1
+
+// OpSummary is a human readable description of a single op.
+type OpSummary struct {
+	ChainID  *big.Int       `json:"chainId"`
+	MultiSig common.Address `json:"multiSig"`
+	Nonce    uint64         `json:"nonce"`
+	To       common.Address `json:"to"`
+	Value    *big.Int       `json:"value"`
+	Call     string         `json:"call"`
+}
+
+// SigningRequest is the file handed to an offline signer.
+type SigningRequest struct {
+	Version      int         `json:"version"`
+	ProposalHash common.Hash `json:"proposalHash"`
+	Root         common.Hash `json:"root"`
+	ValidUntil   uint32      `json:"validUntil"`
+	// SigningHash is keccak256(abi.encode(root, validUntil)).
+	SigningHash common.Hash `json:"signingHash"`
+	Summary     []OpSummary `json:"summary"`
+	// Proposal is the full proposal, without signatures, so that the offline
+	// side can rebuild the Merkle root itself.
+	Proposal Proposal `json:"proposal"`
+}
+
+// SignatureBundle is the file returned by an offline signer.
+type SignatureBundle struct {
+	Version      int            `json:"version"`
+	ProposalHash common.Hash    `json:"proposalHash"`
+	Root         common.Hash    `json:"root"`
+	ValidUntil   uint32         `json:"validUntil"`
+	Signer       common.Address `json:"signer"`
+	Signature    hexutil.Bytes  `json:"signature"`
+}
+
+// Summarize returns a human readable summary of every op in the proposal.
+func (p *Proposal) Summarize() ([]OpSummary, error) {
+	ops, err := p.MCMSOps()
+	if err != nil {
+		return nil, err
+	}
+	summary := make([]OpSummary, len(ops))
+	for i, op := range ops {
+		summary[i] = OpSummary{
+			ChainID:  op.ChainId,
+			MultiSig: op.MultiSig,
+			Nonce:    op.Nonce.Uint64(),
+			To:       op.To,
+			Value:    op.Value,
+			Call:     DescribeCall(op.Data),
+		}
+	}
+	return summary, nil
+}
+
+// NewSigningRequest builds the signing request for p.
+func NewSigningRequest(p *Proposal) (*SigningRequest, error) {
+	unsigned := *p
+	unsigned.Signatures = nil
+	return buildSigningRequest(&unsigned)
+}
+
+func buildSigningRequest(p *Proposal) (*SigningRequest, error) {
+	proposalHash, err := p.Hash()
+	if err != nil {
+		return nil, err
+	}
+	root, err := p.Root()
+	if err != nil {
+		return nil, err
+	}
+	summary, err := p.Summarize()
+	if err != nil {
+		return nil, err
+	}
+	return &SigningRequest{
+		Version:      BundleVersion,
+		ProposalHash: proposalHash,
+		Root:         root,
+		ValidUntil:   p.ValidUntil,
+		SigningHash:  signer.RootHash(root, p.ValidUntil),
+		Summary:      summary,
+		Proposal:     *p,
+	}, nil
+}
+
+// LoadSigningRequest reads a signing request from path.
+func LoadSigningRequest(path string) (*SigningRequest, error) {
+	var r SigningRequest
+	if err := readJSON(path, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Save writes the signing request to path.
+func (r *SigningRequest) Save(path string) error {
+	return writeJSON(path, r)
+}
+
+// Verify recomputes the proposal hash, Merkle root, signing hash and summary
+// from the embedded proposal and checks that they match the request.
+func (r *SigningRequest) Verify() error {
+	if r.Version != BundleVersion {
+		return fmt.Errorf("unsupported bundle version %d", r.Version)
+	}
+	if len(r.Proposal.Signatures) != 0 {
+		return errors.New("signing request must not carry signatures")
+	}
+	expected, err := buildSigningRequest(&r.Proposal)
+	if err != nil {
+		return fmt.Errorf("invalid proposal: %w", err)
+	}
+	switch {
+	case expected.ProposalHash != r.ProposalHash:
+		return fmt.Errorf("proposal hash mismatch: request says %s, proposal hashes to %s", r.ProposalHash, expected.ProposalHash)
+	case expected.Root != r.Root:
+		return fmt.Errorf("root mismatch: request says %s, ops build %s", r.Root, expected.Root)
+	case expected.ValidUntil != r.ValidUntil:
+		return fmt.Errorf("validUntil mismatch: request says %d, proposal says %d", r.ValidUntil, expected.ValidUntil)
+	case expected.SigningHash != r.SigningHash:
+		return fmt.Errorf("signing hash mismatch: request says %s, expected %s", r.SigningHash, expected.SigningHash)
+	case len(expected.Summary) != len(r.Summary):
+		return fmt.Errorf("summary lists %d ops, proposal has %d", len(r.Summary), len(expected.Summary))
+	}
+	for i := range expected.Summary {
+		if !sameSummary(expected.Summary[i], r.Summary[i]) {
+			return fmt.Errorf("summary[%d] does not match the proposal's ops", i)
+		}
+	}
+	return nil
+}
+
+// Sign verifies the request and signs its root with s.
+func (r *SigningRequest) Sign(ctx context.Context, s signer.Signer) (*SignatureBundle, error) {
+	if err := r.Verify(); err != nil {
+		return nil, err
+	}
+	sig, err := signer.SignRoot(ctx, s, r.Root, r.ValidUntil)
+	if err != nil {
+		return nil, err
+	}
+	return &SignatureBundle{
+		Version:      BundleVersion,
+		ProposalHash: r.ProposalHash,
+		Root:         r.Root,
+		ValidUntil:   r.ValidUntil,
+		Signer:       s.Address(),
+		Signature:    signer.FromGethSignature(sig),
+	}, nil
+}
+
+// LoadSignatureBundle reads a signature bundle from path.
+func LoadSignatureBundle(path string) (*SignatureBundle, error) {
+	var b SignatureBundle
+	if err := readJSON(path, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Save writes the signature bundle to path.
+func (b *SignatureBundle) Save(path string) error {
+	return writeJSON(path, b)
+}
+
+// ImportSignatureBundle checks that b was produced for p by the signer it
+// claims and adds its signature to p.
+func ImportSignatureBundle(p *Proposal, b *SignatureBundle) error {
+	if b.Version != BundleVersion {
+		return fmt.Errorf("unsupported bundle version %d", b.Version)
+	}
+	proposalHash, err := p.Hash()
+	if err != nil {
+		return err
+	}
+	if b.ProposalHash != proposalHash {
+		return fmt.Errorf("bundle is for proposal %s, not %s", b.ProposalHash, proposalHash)
+	}
+	root, err := p.Root()
+	if err != nil {
+		return err
+	}
+	if b.Root != root || b.ValidUntil != p.ValidUntil {
+		return fmt.Errorf("bundle signs (%s, %d), proposal has (%s, %d)", b.Root, b.ValidUntil, root, p.ValidUntil)
+	}
+	recovered, err := signer.Recover(signer.RootHash(root, p.ValidUntil), b.Signature)
+	if err != nil {
+		return err
+	}
+	if recovered != b.Signer {
+		return fmt.Errorf("signature recovers to %s, bundle claims %s", recovered, b.Signer)
+	}
+	_, err = p.AddSignature(b.Signature)
+	return err
+}
+
+func sameSummary(a, b OpSummary) bool {
+	return bigEqual(a.ChainID, b.ChainID) && a.MultiSig == b.MultiSig && a.Nonce == b.Nonce &&
+		a.To == b.To && bigEqual(a.Value, b.Value) && a.Call == b.Call
+}
+
+func bigEqual(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
@@ -0,0 +1,138 @@
+package mcms
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+)
+
+func testProposal() *Proposal {
+	multiSig := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	return &Proposal{
+		Description: "test",
+		ValidUntil:  2000000000,
+		Chains: []ChainMetadata{
+			{ChainID: big.NewInt(1), MultiSig: multiSig, PreOpCount: 3},
+			{ChainID: big.NewInt(10), MultiSig: multiSig},
+		},
+		Ops: []Operation{
+			{ChainID: big.NewInt(1), MultiSig: multiSig, To: common.HexToAddress("0x01"), Data: []byte{0x12, 0x34, 0x56, 0x78}},
+			{ChainID: big.NewInt(10), MultiSig: multiSig, To: common.HexToAddress("0x02"), Value: big.NewInt(5)},
+			{ChainID: big.NewInt(1), MultiSig: multiSig, To: common.HexToAddress("0x03")},
+		},
+	}
+}
+
+func newTestSigner(t *testing.T) signer.Signer {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer.NewKeySigner(key)
+}
+
+func TestAirGappedRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	online := testProposal()
+
+	request, err := NewSigningRequest(online)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := request.Save(filepath.Join(dir, "request.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	// offline side
+	offlineRequest, err := LoadSigningRequest(filepath.Join(dir, "request.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := offlineRequest.Summary[2].Nonce; got != 4 {
+		t.Fatalf("nonce of third op = %d, want 4", got)
+	}
+	s := newTestSigner(t)
+	bundle, err := offlineRequest.Sign(context.Background(), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bundle.Save(filepath.Join(dir, "signature.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	// online side
+	imported, err := LoadSignatureBundle(filepath.Join(dir, "signature.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ImportSignatureBundle(online, imported); err != nil {
+		t.Fatal(err)
+	}
+	if err := ImportSignatureBundle(online, imported); err == nil {
+		t.Fatal("expected duplicate import to fail")
+	}
+	sigs, err := online.SetRootSignatures()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sigs) != 1 {
+		t.Fatalf("got %d signatures, want 1", len(sigs))
+	}
+}
+
+func TestSigningRequestDetectsTampering(t *testing.T) {
+	tamper := map[string]func(r *SigningRequest){
+		"op target":  func(r *SigningRequest) { r.Proposal.Ops[0].To = common.HexToAddress("0xbad") },
+		"root":       func(r *SigningRequest) { r.Root = common.HexToHash("0xbad") },
+		"summary":    func(r *SigningRequest) { r.Summary[1].Call = "harmless()" },
+		"validUntil": func(r *SigningRequest) { r.ValidUntil++ },
+	}
+	for name, fn := range tamper {
+		t.Run(name, func(t *testing.T) {
+			request := mustRequest(t)
+			fn(request)
+			if _, err := request.Sign(context.Background(), newTestSigner(t)); err == nil {
+				t.Fatal("expected tampered request to be rejected")
+			}
+		})
+	}
+}
+
+func TestImportRejectsForeignBundle(t *testing.T) {
+	other := testProposal()
+	other.ValidUntil++
+	request, err := NewSigningRequest(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := request.Sign(context.Background(), newTestSigner(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ImportSignatureBundle(testProposal(), bundle); err == nil {
+		t.Fatal("expected bundle for another proposal to be rejected")
+	}
+
+	bundle, err = mustRequest(t).Sign(context.Background(), newTestSigner(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle.Signer = common.HexToAddress("0x1")
+	if err := ImportSignatureBundle(testProposal(), bundle); err == nil {
+		t.Fatal("expected bundle with wrong signer to be rejected")
+	}
+}
+
+func mustRequest(t *testing.T) *SigningRequest {
+	request, err := NewSigningRequest(testProposal())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return request
+}
@@ -0,0 +1,69 @@
+package mcms
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/merkle"
+)
+
+// SetRootArgs are the arguments of a setRoot call.
+type SetRootArgs struct {
+	Root          [32]byte
+	ValidUntil    uint32
+	Metadata      gethwrappers.ManyChainMultiSigRootMetadata
+	MetadataProof [][32]byte
+	Signatures    []gethwrappers.ManyChainMultiSigSignature
+}
+
+// ExecuteArgs are the arguments of an execute call.
+type ExecuteArgs struct {
+	Op    gethwrappers.ManyChainMultiSigOp
+	Proof [][32]byte
+}
+
+// SetRootArgs returns the setRoot arguments for Chains[chainIndex], using the
+// collected signatures.
+func (p *Proposal) SetRootArgs(chainIndex int) (*SetRootArgs, error) {
+	if chainIndex < 0 || chainIndex >= len(p.Chains) {
+		return nil, fmt.Errorf("proposal has no chain with index %d", chainIndex)
+	}
+	tree, err := p.MerkleTree()
+	if err != nil {
+		return nil, err
+	}
+	sigs, err := p.SetRootSignatures()
+	if err != nil {
+		return nil, err
+	}
+	return &SetRootArgs{
+		Root:          tree.Root,
+		ValidUntil:    p.ValidUntil,
+		Metadata:      p.RootMetadata(chainIndex),
+		MetadataProof: merkle.ToBytes32(tree.MetadataProofs[chainIndex]),
+		Signatures:    sigs,
+	}, nil
+}
+
+// ExecuteArgs returns the execute arguments for every op of
+// Chains[chainIndex], in nonce order.
+func (p *Proposal) ExecuteArgs(chainIndex int) ([]ExecuteArgs, error) {
+	if chainIndex < 0 || chainIndex >= len(p.Chains) {
+		return nil, fmt.Errorf("proposal has no chain with index %d", chainIndex)
+	}
+	tree, err := p.MerkleTree()
+	if err != nil {
+		return nil, err
+	}
+	ops, err := p.MCMSOps()
+	if err != nil {
+		return nil, err
+	}
+	c := p.Chains[chainIndex]
+	indices := p.OpIndices(c.ChainID, c.MultiSig)
+	args := make([]ExecuteArgs, len(indices))
+	for i, opIndex := range indices {
+		args[i] = ExecuteArgs{Op: ops[opIndex], Proof: merkle.ToBytes32(tree.OpProofs[opIndex])}
+	}
+	return args, nil
+}
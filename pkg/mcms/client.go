@@ -0,0 +1,272 @@
+package mcms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+)
+
+var (
+	// ErrRootNotSet is returned by ExecuteNext if the proposal's root is not
+	// the current root of the ManyChainMultiSig.
+	ErrRootNotSet = errors.New("proposal root is not set")
+	// ErrNoPendingOps is returned by ExecuteNext once every op of the
+	// proposal for the ManyChainMultiSig has been executed.
+	ErrNoPendingOps = errors.New("no ops left to execute")
+	// ErrSuperseded is returned by ExecuteNext if another root replaced the
+	// root of the proposal before all of its ops were executed, or if ops of
+	// another root used its nonces.
+	ErrSuperseded = errors.New("proposal superseded")
+)
+
+// ReceiptBackend is what a Client needs besides the contract: receipts and
+// the latest block number.
+type ReceiptBackend interface {
+	bind.DeployBackend
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// ClientBackend is what NewClient needs from a node.
+type ClientBackend interface {
+	bind.ContractBackend
+	ReceiptBackend
+}
+
+// Client sends proposals to one ManyChainMultiSig and waits for the results.
+// Transactions are sent with the TransactOpts the client was created with;
+// their Context is replaced by the one passed to each method.
+type Client struct {
+	ChainID *big.Int
+	Address common.Address
+	// FromBlock is the first block searched for OpExecuted events when
+	// ExecuteNext checks whether a proposal was superseded.
+	FromBlock uint64
+
+	contract gethwrappers.ManyChainMultiSigInterface
+	backend  ReceiptBackend
+	opts     *bind.TransactOpts
+}
+
+// NewClient returns a client for the ManyChainMultiSig at address on the
+// chain with the given id.
+func NewClient(chainID *big.Int, address common.Address, backend ClientBackend, opts *bind.TransactOpts) (*Client, error) {
+	contract, err := gethwrappers.NewManyChainMultiSig(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientFromContract(chainID, address, contract, backend, opts), nil
+}
+
+// NewClientFromContract returns a client using contract, which may be a fake.
+// Receipts are fetched from backend.
+func NewClientFromContract(chainID *big.Int, address common.Address, contract gethwrappers.ManyChainMultiSigInterface, backend ReceiptBackend, opts *bind.TransactOpts) *Client {
+	return &Client{ChainID: chainID, Address: address, contract: contract, backend: backend, opts: opts}
+}
+
+// State is the root and config state of a ManyChainMultiSig.
+type State struct {
+	Root         common.Hash                                `json:"root"`
+	ValidUntil   uint32                                     `json:"validUntil"`
+	OpCount      uint64                                     `json:"opCount"`
+	RootMetadata gethwrappers.ManyChainMultiSigRootMetadata `json:"rootMetadata"`
+	Config       gethwrappers.ManyChainMultiSigConfig       `json:"config"`
+}
+
+// PendingOps returns the number of ops of the current root that have not
+// been executed yet.
+func (s *State) PendingOps() uint64 {
+	if post := s.RootMetadata.PostOpCount.Uint64(); post > s.OpCount {
+		return post - s.OpCount
+	}
+	return 0
+}
+
+// State reads the current state of the ManyChainMultiSig.
+func (c *Client) State(ctx context.Context) (*State, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	root, err := c.contract.GetRoot(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getRoot: %w", err)
+	}
+	opCount, err := c.contract.GetOpCount(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getOpCount: %w", err)
+	}
+	metadata, err := c.contract.GetRootMetadata(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getRootMetadata: %w", err)
+	}
+	config, err := c.contract.GetConfig(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getConfig: %w", err)
+	}
+	return &State{
+		Root:         root.Root,
+		ValidUntil:   root.ValidUntil,
+		OpCount:      opCount.Uint64(),
+		RootMetadata: metadata,
+		Config:       config,
+	}, nil
+}
+
+// SetRoot calls setRoot with the signatures collected in p and returns the
+// NewRoot event of the mined transaction.
+func (c *Client) SetRoot(ctx context.Context, p *Proposal) (*gethwrappers.ManyChainMultiSigNewRoot, error) {
+	chainIndex, err := c.chainIndex(p)
+	if err != nil {
+		return nil, err
+	}
+	args, err := p.SetRootArgs(chainIndex)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.contract.SetRoot(c.transactOpts(ctx), args.Root, args.ValidUntil, args.Metadata, args.MetadataProof, args.Signatures)
+	if err != nil {
+		return nil, fmt.Errorf("setRoot: %w", err)
+	}
+	receipt, err := c.wait(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("setRoot: %w", err)
+	}
+	return c.ParseNewRoot(receipt)
+}
+
+// ExecuteNext executes the op of p whose nonce is the current op count and
+// returns the OpExecuted event of the mined transaction. It returns
+// ErrNoPendingOps once all ops of p have been executed, and ErrSuperseded if
+// another root holds the multisig after using some of the nonces of p, or
+// if ops of another root used them.
+func (c *Client) ExecuteNext(ctx context.Context, p *Proposal) (*gethwrappers.ManyChainMultiSigOpExecuted, error) {
+	chainIndex, err := c.chainIndex(p)
+	if err != nil {
+		return nil, err
+	}
+	root, err := p.Root()
+	if err != nil {
+		return nil, err
+	}
+	state, err := c.State(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if state.Root != root || state.ValidUntil != p.ValidUntil {
+		if state.OpCount <= p.Chains[chainIndex].PreOpCount {
+			return nil, ErrRootNotSet
+		}
+		// The root of p can no longer be set: setRoot requires the op
+		// count to be its preOpCount.
+		if err := c.checkExecuted(ctx, p, state.OpCount); err != nil {
+			return nil, err
+		}
+		if left := p.PostOpCount(chainIndex) - min(state.OpCount, p.PostOpCount(chainIndex)); left > 0 {
+			return nil, fmt.Errorf("%w: the current root is %s with %d ops of the proposal left", ErrSuperseded, common.Hash(state.Root), left)
+		}
+		return nil, ErrNoPendingOps
+	}
+	executeArgs, err := p.ExecuteArgs(chainIndex)
+	if err != nil {
+		return nil, err
+	}
+	for _, args := range executeArgs {
+		if args.Op.Nonce.Uint64() != state.OpCount {
+			continue
+		}
+		tx, err := c.contract.Execute(c.transactOpts(ctx), args.Op, args.Proof)
+		if err != nil {
+			return nil, fmt.Errorf("execute nonce %d: %w", state.OpCount, err)
+		}
+		receipt, err := c.wait(ctx, tx)
+		if err != nil {
+			return nil, fmt.Errorf("execute nonce %d: %w", state.OpCount, err)
+		}
+		return c.ParseOpExecuted(receipt)
+	}
+	return nil, ErrNoPendingOps
+}
+
+// checkExecuted checks that the nonces of p below opCount were used by the
+// ops of p: it returns ErrSuperseded if the OpExecuted event of one of them
+// is missing or of another op. Events are looked up from c.FromBlock on.
+func (c *Client) checkExecuted(ctx context.Context, p *Proposal, opCount uint64) error {
+	chainIndex, err := c.chainIndex(p)
+	if err != nil {
+		return err
+	}
+	pre, post := p.Chains[chainIndex].PreOpCount, p.PostOpCount(chainIndex)
+	if opCount <= pre {
+		return nil
+	}
+	executed := min(opCount, post) - pre
+	head, err := c.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	scan := newExecutedScan(p, p.OpIndices(c.ChainID, c.Address), pre, executed)
+	if err := scan.scanBlocks(ctx, c.contract, c.FromBlock, head.Number.Uint64()); err != nil {
+		return err
+	}
+	switch {
+	case scan.foreign != "":
+		return fmt.Errorf("%w: %s", ErrSuperseded, scan.foreign)
+	case uint64(len(scan.ops)) < executed:
+		return fmt.Errorf("%w: %d of the %d used nonces of the proposal were not used by its ops", ErrSuperseded, executed-uint64(len(scan.ops)), executed)
+	}
+	return nil
+}
+
+// ParseNewRoot decodes the NewRoot event of the ManyChainMultiSig from a
+// receipt.
+func (c *Client) ParseNewRoot(receipt *types.Receipt) (*gethwrappers.ManyChainMultiSigNewRoot, error) {
+	l, err := c.findLog(receipt, "NewRoot")
+	if err != nil {
+		return nil, err
+	}
+	return c.contract.ParseNewRoot(*l)
+}
+
+// ParseOpExecuted decodes the OpExecuted event of the ManyChainMultiSig from
+// a receipt.
+func (c *Client) ParseOpExecuted(receipt *types.Receipt) (*gethwrappers.ManyChainMultiSigOpExecuted, error) {
+	l, err := c.findLog(receipt, "OpExecuted")
+	if err != nil {
+		return nil, err
+	}
+	return c.contract.ParseOpExecuted(*l)
+}
+
+func (c *Client) findLog(receipt *types.Receipt, event string) (*types.Log, error) {
+	id := ManyChainMultiSigABI.Events[event].ID
+	for _, l := range receipt.Logs {
+		if l.Address == c.Address && len(l.Topics) > 0 && l.Topics[0] == id {
+			return l, nil
+		}
+	}
+	return nil, fmt.Errorf("transaction %s emitted no %s event", receipt.TxHash, event)
+}
+
+func (c *Client) chainIndex(p *Proposal) (int, error) {
+	i := p.ChainIndex(c.ChainID, c.Address)
+	if i < 0 {
+		return 0, fmt.Errorf("proposal does not cover %s", chainKey(c.ChainID, c.Address))
+	}
+	return i, nil
+}
+
+func (c *Client) transactOpts(ctx context.Context) *bind.TransactOpts {
+	opts := *c.opts
+	opts.Context = ctx
+	return &opts
+}
+
+// wait waits for tx to be mined and fails if it reverted.
+func (c *Client) wait(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	return evm.WaitSuccess(ctx, c.backend, tx)
+}
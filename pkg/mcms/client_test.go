@@ -0,0 +1,150 @@
+package mcms_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/fake"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("simulated", func(t *testing.T) {
+		e := harness.NewBare(t)
+		ms := e.DeployMultiSig(3, 2)
+		client, err := mcms.NewClient(harness.ChainID, ms.Address, e.AutoMining(), e.Deployer.Opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testClient(t, client, ms.Signers[:2], e.Now())
+	})
+	t.Run("fake", func(t *testing.T) {
+		accounts := harness.GenerateSigners(t, 3)
+		deployer, signers := accounts[0], accounts[1:]
+		chain := fake.NewChain(harness.ChainID, time.Unix(1_700_000_000, 0))
+		address, _, ms, err := fake.DeployManyChainMultiSig(deployer.Opts, chain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ms.SetConfig(deployer.Opts, harness.SignerAddresses(signers), []uint8{0, 0}, [32]uint8{2}, [32]uint8{}, false); err != nil {
+			t.Fatal(err)
+		}
+		testClient(t, mcms.NewClientFromContract(harness.ChainID, address, ms, chain, deployer.Opts), signers, chain.Now())
+	})
+}
+
+// testClient runs a two-op proposal through client. signers must reach the
+// quorum of the multisig, which must have no root set.
+func testClient(t *testing.T, client *mcms.Client, signers []*harness.Account, now time.Time) {
+	ctx := context.Background()
+	p := &mcms.Proposal{
+		ValidUntil: uint32(now.Add(time.Hour).Unix()),
+		Chains:     []mcms.ChainMetadata{{ChainID: client.ChainID, MultiSig: client.Address}},
+	}
+	for i := 0; i < 2; i++ {
+		// Calls to accounts without code succeed.
+		p.Ops = append(p.Ops, mcms.Operation{
+			ChainID:  client.ChainID,
+			MultiSig: client.Address,
+			To:       common.BigToAddress(big.NewInt(int64(0x1000 + i))),
+			Data:     []byte{byte(i)},
+		})
+	}
+	sign := func(p *mcms.Proposal) common.Hash {
+		root, err := p.Root()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range signers {
+			sig, err := signer.SignRoot(ctx, s.Signer, root, p.ValidUntil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := p.AddSignature(signer.FromGethSignature(sig)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return root
+	}
+	root := sign(p)
+
+	if _, err := client.ExecuteNext(ctx, p); !errors.Is(err, mcms.ErrRootNotSet) {
+		t.Fatalf("ExecuteNext before SetRoot: got %v", err)
+	}
+	newRoot, err := client.SetRoot(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newRoot.Root != root || newRoot.ValidUntil != p.ValidUntil || newRoot.Metadata.PostOpCount.Uint64() != 2 {
+		t.Fatalf("NewRoot = %+v", newRoot)
+	}
+	state, err := client.State(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Root != root || state.OpCount != 0 || state.PendingOps() != 2 || len(state.Config.Signers) < len(signers) {
+		t.Fatalf("state = %+v", state)
+	}
+
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		executed, err := client.ExecuteNext(ctx, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if executed.Nonce.Uint64() != nonce || executed.To != p.Ops[nonce].To {
+			t.Fatalf("OpExecuted = %+v, want nonce %d", executed, nonce)
+		}
+	}
+	if _, err := client.ExecuteNext(ctx, p); !errors.Is(err, mcms.ErrNoPendingOps) {
+		t.Fatalf("ExecuteNext after the last op: got %v", err)
+	}
+	if state, err = client.State(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if state.OpCount != 2 || state.PendingOps() != 0 {
+		t.Fatalf("state = %+v", state)
+	}
+
+	// Once the root of p is replaced, p is still done, while a proposal
+	// whose ops differ for the same nonces was superseded by p.
+	rival := &mcms.Proposal{ValidUntil: p.ValidUntil, Chains: p.Chains, Ops: append([]mcms.Operation(nil), p.Ops...)}
+	rival.Ops[1].Data = []byte{9}
+	next := &mcms.Proposal{
+		ValidUntil: p.ValidUntil,
+		Chains:     []mcms.ChainMetadata{{ChainID: client.ChainID, MultiSig: client.Address, PreOpCount: 2}},
+		Ops:        p.Ops[:1],
+	}
+	sign(next)
+	if _, err := client.SetRoot(ctx, next); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ExecuteNext(ctx, p); !errors.Is(err, mcms.ErrNoPendingOps) {
+		t.Fatalf("ExecuteNext after the root was replaced: got %v", err)
+	}
+	if _, err := client.ExecuteNext(ctx, rival); !errors.Is(err, mcms.ErrSuperseded) {
+		t.Fatalf("ExecuteNext of a superseded proposal: got %v", err)
+	}
+
+	// next, a one-op root, uses the first nonce of a two-op proposal that
+	// can then never be set.
+	if _, err := client.ExecuteNext(ctx, next); err != nil {
+		t.Fatal(err)
+	}
+	late := &mcms.Proposal{ValidUntil: p.ValidUntil, Chains: next.Chains, Ops: []mcms.Operation{p.Ops[1], p.Ops[0]}}
+	if _, err := client.ExecuteNext(ctx, late); !errors.Is(err, mcms.ErrSuperseded) {
+		t.Fatalf("ExecuteNext of a proposal whose first nonce was used: got %v", err)
+	}
+
+	other := *p
+	other.Chains = []mcms.ChainMetadata{{ChainID: big.NewInt(1), MultiSig: client.Address}}
+	if _, err := client.SetRoot(ctx, &other); err == nil {
+		t.Fatal("SetRoot of a proposal for another chain succeeded")
+	}
+}
@@ -0,0 +1,51 @@
+package mcms
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+)
+
+const (
+	// NumGroups is ManyChainMultiSig.NUM_GROUPS.
+	NumGroups = 32
+	// MaxNumSigners is ManyChainMultiSig.MAX_NUM_SIGNERS.
+	MaxNumSigners = 200
+)
+
+// SignerGroup returns the group of addr in config, or false if addr is not a
+// signer.
+func SignerGroup(config gethwrappers.ManyChainMultiSigConfig, addr common.Address) (uint8, bool) {
+	for _, s := range config.Signers {
+		if s.Addr == addr {
+			return s.Group, true
+		}
+	}
+	return 0, false
+}
+
+// QuorumReached reports whether signatures from signers make the root group
+// of config successful, counting votes up the group tree the same way setRoot
+// does. Addresses that are not signers and repeated addresses are ignored.
+func QuorumReached(config gethwrappers.ManyChainMultiSigConfig, signers []common.Address) bool {
+	if config.GroupQuorums[0] == 0 {
+		return false
+	}
+	var counts [NumGroups]uint8
+	seen := make(map[common.Address]bool)
+	for _, addr := range signers {
+		group, ok := SignerGroup(config, addr)
+		if !ok || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		for {
+			counts[group]++
+			if counts[group] != config.GroupQuorums[group] || group == 0 {
+				break
+			}
+			group = config.GroupParents[group]
+		}
+	}
+	return counts[0] >= config.GroupQuorums[0]
+}
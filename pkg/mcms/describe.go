@@ -0,0 +1,112 @@
+package mcms
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+)
+
+// knownABIs are the ABIs used to decode op call data for humans. Ops issued by
+// a ManyChainMultiSig almost always target the owner contracts themselves.
+var knownABIs = []*abi.ABI{
+	evm.MustParseABI(gethwrappers.RBACTimelockMetaData),
+	ManyChainMultiSigABI,
+}
+
+// DescribeCall returns a human readable rendering of call data, e.g.
+// "scheduleBatch(calls=[...], predecessor=0x..., salt=0x..., delay=86400)".
+// Call data that matches none of the owner contract ABIs is rendered as its
+// selector and length.
+func DescribeCall(data []byte) string {
+	return describeCall(knownABIs, data)
+}
+
+// CallDecoder describes call data like DescribeCall, trying additional ABIs,
+// e.g. of the contracts the owner contracts own, before the owner contract
+// ones.
+type CallDecoder struct {
+	abis []*abi.ABI
+}
+
+// NewCallDecoder returns a decoder trying abis in order, then the owner
+// contract ABIs.
+func NewCallDecoder(abis ...*abi.ABI) *CallDecoder {
+	return &CallDecoder{abis: append(append([]*abi.ABI{}, abis...), knownABIs...)}
+}
+
+// Describe returns a human readable rendering of data.
+func (d *CallDecoder) Describe(data []byte) string {
+	return describeCall(d.abis, data)
+}
+
+// LoadABI reads a JSON ABI, either a bare array or a compiler artifact with
+// an "abi" field as written by forge and hardhat.
+func LoadABI(path string) (*abi.ABI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", path, err)
+		}
+		data = artifact.ABI
+	}
+	parsed, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return &parsed, nil
+}
+
+func describeCall(abis []*abi.ABI, data []byte) string {
+	if len(data) == 0 {
+		return "(no data)"
+	}
+	if len(data) < 4 {
+		return fmt.Sprintf("(malformed call data %s)", hexutil.Encode(data))
+	}
+	for _, parsed := range abis {
+		method, err := parsed.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+		args, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			continue
+		}
+		parts := make([]string, len(args))
+		for i, arg := range args {
+			parts[i] = fmt.Sprintf("%s=%v", method.Inputs[i].Name, formatArg(arg))
+		}
+		return fmt.Sprintf("%s(%s)", method.RawName, strings.Join(parts, ", "))
+	}
+	return fmt.Sprintf("unknown %s (%d bytes)", hexutil.Encode(data[:4]), len(data))
+}
+
+func formatArg(arg interface{}) interface{} {
+	switch v := arg.(type) {
+	case [32]byte:
+		return hexutil.Encode(v[:])
+	case [4]byte:
+		return hexutil.Encode(v[:])
+	case []byte:
+		return hexutil.Encode(v)
+	case *big.Int:
+		return v.String()
+	default:
+		return fmt.Sprintf("%+v", v)
+	}
+}
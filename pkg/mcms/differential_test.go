@@ -0,0 +1,331 @@
+package mcms_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/merkle"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+)
+
+// The fuzz tests in this file run the same random setConfig, setRoot and
+// execute calls against mcms.Model and a deployed ManyChainMultiSig, and
+// require both to accept or reject each call with the same error. Every call
+// is derived from the fuzzed seed, so a failing input reproduces exactly.
+
+const fuzzSeeds = 32
+
+// differential is a deployed ManyChainMultiSig and its model.
+type differential struct {
+	t        *testing.T
+	rnd      *rand.Rand
+	env      *harness.Env
+	contract *gethwrappers.ManyChainMultiSig
+	model    *mcms.Model
+	// signers are candidate signers, sorted by address.
+	signers []*harness.Account
+}
+
+func newDifferential(t *testing.T, seed int64) *differential {
+	env := harness.NewBare(t)
+	addr, _, contract, err := gethwrappers.DeployManyChainMultiSig(env.Deployer.Opts, env.Backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.Commit()
+	return &differential{
+		t:        t,
+		rnd:      rand.New(rand.NewSource(seed)),
+		env:      env,
+		contract: contract,
+		model:    mcms.NewModel(harness.ChainID, addr),
+		signers:  harness.GenerateSigners(t, 8),
+	}
+}
+
+// compare requires the contract call and the model to agree. It returns
+// whether the call succeeded.
+func (d *differential) compare(call string, tx *types.Transaction, sendErr error, modelErr error) bool {
+	d.t.Helper()
+	var got error
+	if sendErr != nil {
+		got = revertReason(d.t, sendErr)
+	} else {
+		d.env.Commit()
+		receipt, err := d.env.Backend.TransactionReceipt(context.Background(), tx.Hash())
+		if err != nil {
+			d.t.Fatal(err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			d.t.Fatalf("%s: transaction reverted although gas estimation passed", call)
+		}
+	}
+	if !errors.Is(got, modelErr) {
+		d.t.Fatalf("%s: contract returned %v, model %v", call, got, modelErr)
+	}
+	return got == nil
+}
+
+// revertReason decodes the revert of a failed call into the error the model
+// returns for it.
+func revertReason(t *testing.T, err error) error {
+	t.Helper()
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		t.Fatalf("call failed without revert data: %v", err)
+	}
+	hexData, _ := dataErr.ErrorData().(string)
+	data := common.FromHex(hexData)
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return mcms.ContractError(reason)
+	}
+	if contractErr := gethwrappers.UnpackManyChainMultiSigError(data); contractErr != nil {
+		return contractErr
+	}
+	t.Fatalf("undecodable revert data %x", data)
+	return nil
+}
+
+// now returns the timestamp the next block will have.
+func (d *differential) now() uint64 {
+	return uint64(d.env.Now().Unix()) + 10
+}
+
+// randomConfig returns setConfig arguments that are mostly, but not always,
+// valid.
+func (d *differential) randomConfig() ([]common.Address, []uint8, [mcms.NumGroups]uint8, [mcms.NumGroups]uint8) {
+	rnd := d.rnd
+	numGroups := 1 + rnd.Intn(4)
+	var quorums, parents [mcms.NumGroups]uint8
+	for g := 1; g < numGroups; g++ {
+		parents[g] = uint8(rnd.Intn(g))
+	}
+	n := rnd.Intn(len(d.signers) + 1)
+	perm := rnd.Perm(len(d.signers))[:n]
+	sort.Ints(perm)
+	addrs := make([]common.Address, n)
+	groups := make([]uint8, n)
+	children := make([]int, mcms.NumGroups)
+	for i, p := range perm {
+		addrs[i] = d.signers[p].Address
+		groups[i] = uint8(rnd.Intn(numGroups))
+		children[groups[i]]++
+	}
+	for g := numGroups - 1; g >= 0; g-- {
+		if children[g] > 0 {
+			quorums[g] = uint8(1 + rnd.Intn(children[g]))
+			children[parents[g]]++
+		}
+	}
+
+	switch rnd.Intn(12) {
+	case 0:
+		if n > 1 {
+			addrs[0], addrs[1] = addrs[1], addrs[0]
+		}
+	case 1:
+		if n > 0 {
+			groups[rnd.Intn(n)] = uint8(mcms.NumGroups + rnd.Intn(3))
+		}
+	case 2:
+		quorums[rnd.Intn(numGroups)] += uint8(1 + rnd.Intn(2))
+	case 3:
+		parents[rnd.Intn(mcms.NumGroups)] = uint8(rnd.Intn(mcms.NumGroups))
+	case 4:
+		quorums[numGroups] = 1
+	case 5:
+		groups = append(groups, 0)
+	}
+	return addrs, groups, quorums, parents
+}
+
+func (d *differential) setConfig() bool {
+	addrs, groups, quorums, parents := d.randomConfig()
+	clearRoot := d.rnd.Intn(4) == 0
+	tx, err := d.contract.SetConfig(d.env.Deployer.Opts, addrs, groups, quorums, parents, clearRoot)
+	return d.compare("setConfig", tx, err, d.model.SetConfig(addrs, groups, quorums, parents, clearRoot))
+}
+
+// randomProposal returns a proposal for the contract that setRoot mostly
+// accepts.
+func (d *differential) randomProposal() *mcms.Proposal {
+	rnd := d.rnd
+	p := &mcms.Proposal{
+		ValidUntil: uint32(d.now()) + uint32(rnd.Intn(3600)),
+		Chains: []mcms.ChainMetadata{{
+			ChainID:              d.model.ChainID,
+			MultiSig:             d.model.Address,
+			PreOpCount:           d.model.OpCount,
+			OverridePreviousRoot: rnd.Intn(3) == 0,
+		}},
+	}
+	switch rnd.Intn(10) {
+	case 0:
+		p.ValidUntil = uint32(d.now()) - uint32(1+rnd.Intn(3600))
+	case 1:
+		p.Chains[0].PreOpCount += uint64(1 + rnd.Intn(2))
+	case 2:
+		p.Chains[0].ChainID = big.NewInt(int64(rnd.Intn(2000)))
+	case 3:
+		p.Chains[0].MultiSig = common.HexToAddress("0xdead")
+	}
+	if rnd.Intn(4) == 0 {
+		p.Chains = append(p.Chains, mcms.ChainMetadata{ChainID: big.NewInt(99), MultiSig: d.model.Address})
+	}
+	for i, n := 0, rnd.Intn(4); i < n; i++ {
+		chain := p.Chains[rnd.Intn(len(p.Chains))]
+		p.Ops = append(p.Ops, mcms.Operation{
+			ChainID:  chain.ChainID,
+			MultiSig: chain.MultiSig,
+			To:       common.BigToAddress(big.NewInt(int64(0x1000 + rnd.Intn(16)))),
+			Data:     []byte{byte(rnd.Intn(256))},
+		})
+	}
+	return p
+}
+
+// randomSignatures signs root with a random subset of the candidate signers
+// and occasionally corrupts the result.
+func (d *differential) randomSignatures(root common.Hash, validUntil uint32) []gethwrappers.ManyChainMultiSigSignature {
+	rnd := d.rnd
+	var sigs []gethwrappers.ManyChainMultiSigSignature
+	for _, s := range d.signers {
+		_, configured := mcms.SignerGroup(d.model.Config, s.Address)
+		if !configured && rnd.Intn(32) != 0 || rnd.Intn(4) == 0 {
+			continue
+		}
+		sig, err := signer.SignRoot(context.Background(), s.Signer, root, validUntil)
+		if err != nil {
+			d.t.Fatal(err)
+		}
+		sigs = append(sigs, sig)
+	}
+	if len(sigs) == 0 {
+		return sigs
+	}
+	i := rnd.Intn(len(sigs))
+	switch rnd.Intn(10) {
+	case 0:
+		sigs = append(sigs, sigs[i])
+	case 1:
+		j := rnd.Intn(len(sigs))
+		sigs[i], sigs[j] = sigs[j], sigs[i]
+	case 2:
+		// (r, n-s) with the other v recovers the same signer: it is the
+		// high-s twin of a valid signature.
+		s := new(big.Int).Sub(crypto.S256().Params().N, new(big.Int).SetBytes(sigs[i].S[:]))
+		sigs[i].S = common.BigToHash(s)
+		if sigs[i].V == 27 {
+			sigs[i].V = 28
+		} else {
+			sigs[i].V = 27
+		}
+	case 3:
+		sigs[i].V = uint8(rnd.Intn(256))
+	case 4:
+		sigs[i].R[rnd.Intn(32)] ^= 0xff
+	case 5:
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			d.t.Fatal(err)
+		}
+		foreign, err := signer.SignRoot(context.Background(), signer.NewKeySigner(key), root, validUntil)
+		if err != nil {
+			d.t.Fatal(err)
+		}
+		sigs[i] = foreign
+	}
+	return sigs
+}
+
+func (d *differential) setRoot() *mcms.Proposal {
+	p := d.randomProposal()
+	args, err := p.SetRootArgs(0)
+	if err != nil {
+		d.t.Fatal(err)
+	}
+	args.Signatures = d.randomSignatures(args.Root, args.ValidUntil)
+	if d.rnd.Intn(10) == 0 && len(p.Chains) > 1 {
+		tree, err := p.MerkleTree()
+		if err != nil {
+			d.t.Fatal(err)
+		}
+		args.MetadataProof = merkle.ToBytes32(tree.MetadataProofs[1])
+	}
+	tx, err := d.contract.SetRoot(d.env.Deployer.Opts, args.Root, args.ValidUntil, args.Metadata, args.MetadataProof, args.Signatures)
+	if !d.compare("setRoot", tx, err, d.model.SetRoot(d.now(), args)) {
+		return nil
+	}
+	return p
+}
+
+func (d *differential) execute(p *mcms.Proposal) {
+	executeArgs, err := p.ExecuteArgs(0)
+	if err != nil {
+		d.t.Fatal(err)
+	}
+	for i, n := 0, d.rnd.Intn(len(executeArgs)+2); i < n; i++ {
+		var args mcms.ExecuteArgs
+		if len(executeArgs) > 0 {
+			args = executeArgs[d.rnd.Intn(len(executeArgs))]
+			if d.rnd.Intn(2) == 0 && int(d.model.OpCount-p.Chains[0].PreOpCount) < len(executeArgs) {
+				args = executeArgs[d.model.OpCount-p.Chains[0].PreOpCount]
+			}
+		} else {
+			args.Op = gethwrappers.ManyChainMultiSigOp{
+				ChainId: d.model.ChainID, MultiSig: d.model.Address, Nonce: new(big.Int).SetUint64(d.model.OpCount), Value: new(big.Int),
+			}
+		}
+		switch d.rnd.Intn(8) {
+		case 0:
+			args.Proof = nil
+		case 1:
+			d.env.AdvanceTime(time.Duration(1+d.rnd.Intn(3600)) * time.Second)
+		}
+		tx, err := d.contract.Execute(d.env.Deployer.Opts, args.Op, args.Proof)
+		d.compare("execute", tx, err, d.model.Execute(d.now(), &args))
+	}
+}
+
+func FuzzSetConfig(f *testing.F) {
+	for i := int64(0); i < fuzzSeeds; i++ {
+		f.Add(i)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		d := newDifferential(t, seed)
+		for i := 0; i < 4; i++ {
+			d.setConfig()
+		}
+	})
+}
+
+func FuzzSetRootAndExecute(f *testing.F) {
+	for i := int64(0); i < fuzzSeeds; i++ {
+		f.Add(i)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		d := newDifferential(t, seed)
+		for i := 0; i < 3; i++ {
+			if d.rnd.Intn(3) == 0 || d.model.Config.GroupQuorums[0] == 0 {
+				d.setConfig()
+			}
+			if p := d.setRoot(); p != nil {
+				d.execute(p)
+			}
+		}
+	})
+}
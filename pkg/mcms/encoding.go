@@ -0,0 +1,60 @@
+package mcms
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+)
+
+var (
+	// MetadataDomainSeparator is MANY_CHAIN_MULTI_SIG_DOMAIN_SEPARATOR_METADATA.
+	MetadataDomainSeparator = crypto.Keccak256Hash([]byte("MANY_CHAIN_MULTI_SIG_DOMAIN_SEPARATOR_METADATA"))
+	// OpDomainSeparator is MANY_CHAIN_MULTI_SIG_DOMAIN_SEPARATOR_OP.
+	OpDomainSeparator = crypto.Keccak256Hash([]byte("MANY_CHAIN_MULTI_SIG_DOMAIN_SEPARATOR_OP"))
+
+	// MaxOpCount is the largest value representable by the uint40 opCount.
+	MaxOpCount = uint64(1)<<40 - 1
+
+	// ManyChainMultiSigABI is the parsed ABI of ManyChainMultiSig.
+	ManyChainMultiSigABI = evm.MustParseABI(gethwrappers.ManyChainMultiSigMetaData)
+
+	bytes32Type, _   = abi.NewType("bytes32", "", nil)
+	metadataLeafArgs = abi.Arguments{
+		{Type: bytes32Type},
+		{Type: ManyChainMultiSigABI.Methods["setRoot"].Inputs[2].Type},
+	}
+	opLeafArgs = abi.Arguments{
+		{Type: bytes32Type},
+		{Type: ManyChainMultiSigABI.Methods["execute"].Inputs[0].Type},
+	}
+)
+
+// MetadataLeaf returns keccak256(abi.encode(MetadataDomainSeparator, metadata)).
+func MetadataLeaf(metadata gethwrappers.ManyChainMultiSigRootMetadata) (common.Hash, error) {
+	encoded, err := metadataLeafArgs.Pack(MetadataDomainSeparator, metadata)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("encoding metadata leaf: %w", err)
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// OpLeaf returns keccak256(abi.encode(OpDomainSeparator, op)).
+func OpLeaf(op gethwrappers.ManyChainMultiSigOp) (common.Hash, error) {
+	if op.Value == nil {
+		op.Value = new(big.Int)
+	}
+	if op.Data == nil {
+		op.Data = []byte{}
+	}
+	encoded, err := opLeafArgs.Pack(OpDomainSeparator, op)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("encoding op leaf: %w", err)
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
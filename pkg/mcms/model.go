@@ -0,0 +1,243 @@
+package mcms
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/merkle"
+)
+
+// ContractError is the reason string of a require in a dependency of
+// ManyChainMultiSig, such as OpenZeppelin's ECDSA.
+type ContractError string
+
+func (e ContractError) Error() string {
+	return string(e)
+}
+
+// The reasons OpenZeppelin's ECDSA reverts with.
+const (
+	ErrECDSAInvalidSignature  ContractError = "ECDSA: invalid signature"
+	ErrECDSAInvalidSignatureS ContractError = "ECDSA: invalid signature 's' value"
+)
+
+// Model is an executable model of the checks ManyChainMultiSig performs in
+// setConfig, setRoot and execute. The checks run in the contract's order, so
+// a call the contract rejects fails here with the same error: the generated
+// gethwrappers type of the contract's custom error, e.g.
+// *gethwrappers.ManyChainMultiSigInsufficientSigners, or a ContractError.
+// Calls that pass update the model the way they update the contract.
+//
+// The model does not perform the calls of executed ops; Execute succeeding
+// means the op was dispatched, not that its call succeeded.
+type Model struct {
+	ChainID *big.Int
+	Address common.Address
+
+	Config       gethwrappers.ManyChainMultiSigConfig
+	Root         common.Hash
+	ValidUntil   uint32
+	OpCount      uint64
+	RootMetadata gethwrappers.ManyChainMultiSigRootMetadata
+	// SeenSignedHashes is keyed by EIP-191 prefixed hash, see SignedHash.
+	SeenSignedHashes map[common.Hash]bool
+}
+
+// NewModel returns the model of a freshly deployed ManyChainMultiSig.
+func NewModel(chainID *big.Int, address common.Address) *Model {
+	return &Model{
+		ChainID: chainID,
+		Address: address,
+		RootMetadata: gethwrappers.ManyChainMultiSigRootMetadata{
+			ChainId:     new(big.Int),
+			PreOpCount:  new(big.Int),
+			PostOpCount: new(big.Int),
+		},
+		SeenSignedHashes: make(map[common.Hash]bool),
+	}
+}
+
+// ValidateConfig checks setConfig arguments like the contract does.
+func ValidateConfig(signerAddresses []common.Address, signerGroups []uint8, groupQuorums, groupParents [NumGroups]uint8) error {
+	if len(signerAddresses) == 0 || len(signerAddresses) > MaxNumSigners {
+		return &gethwrappers.ManyChainMultiSigOutOfBoundsNumOfSigners{}
+	}
+	if len(signerAddresses) != len(signerGroups) {
+		return &gethwrappers.ManyChainMultiSigSignerGroupsLengthMismatch{}
+	}
+	var children [NumGroups]int
+	for _, g := range signerGroups {
+		if g >= NumGroups {
+			return &gethwrappers.ManyChainMultiSigOutOfBoundsGroup{}
+		}
+		children[g]++
+	}
+	for i := NumGroups - 1; i >= 0; i-- {
+		if (i != 0 && int(groupParents[i]) >= i) || (i == 0 && groupParents[i] != 0) {
+			return &gethwrappers.ManyChainMultiSigGroupTreeNotWellFormed{}
+		}
+		if groupQuorums[i] == 0 {
+			if children[i] > 0 {
+				return &gethwrappers.ManyChainMultiSigSignerInDisabledGroup{}
+			}
+			continue
+		}
+		if children[i] < int(groupQuorums[i]) {
+			return &gethwrappers.ManyChainMultiSigOutOfBoundsGroupQuorum{}
+		}
+		children[groupParents[i]]++
+	}
+	var prev common.Address
+	for _, addr := range signerAddresses {
+		if bytes.Compare(prev[:], addr[:]) >= 0 {
+			return &gethwrappers.ManyChainMultiSigSignersAddressesMustBeStrictlyIncreasing{}
+		}
+		prev = addr
+	}
+	return nil
+}
+
+// SetConfig models setConfig.
+func (m *Model) SetConfig(signerAddresses []common.Address, signerGroups []uint8, groupQuorums, groupParents [NumGroups]uint8, clearRoot bool) error {
+	if err := ValidateConfig(signerAddresses, signerGroups, groupQuorums, groupParents); err != nil {
+		return err
+	}
+	config := gethwrappers.ManyChainMultiSigConfig{GroupQuorums: groupQuorums, GroupParents: groupParents}
+	for i, addr := range signerAddresses {
+		config.Signers = append(config.Signers, gethwrappers.ManyChainMultiSigSigner{Addr: addr, Index: uint8(i), Group: signerGroups[i]})
+	}
+	m.Config = config
+	if clearRoot {
+		m.Root, m.ValidUntil = common.Hash{}, 0
+		m.RootMetadata = gethwrappers.ManyChainMultiSigRootMetadata{
+			ChainId:              m.ChainID,
+			MultiSig:             m.Address,
+			PreOpCount:           new(big.Int).SetUint64(m.OpCount),
+			PostOpCount:          new(big.Int).SetUint64(m.OpCount),
+			OverridePreviousRoot: true,
+		}
+	}
+	return nil
+}
+
+// SetRoot models setRoot at block time now.
+func (m *Model) SetRoot(now uint64, args *SetRootArgs) error {
+	signedHash := SignedHash(args.Root, args.ValidUntil)
+	if m.SeenSignedHashes[signedHash] {
+		return &gethwrappers.ManyChainMultiSigSignedHashAlreadySeen{}
+	}
+
+	signers := make([]common.Address, 0, len(args.Signatures))
+	var prev common.Address
+	for _, sig := range args.Signatures {
+		addr, err := ecdsaRecover(signedHash, sig)
+		if err != nil {
+			return err
+		}
+		if bytes.Compare(prev[:], addr[:]) >= 0 {
+			return &gethwrappers.ManyChainMultiSigSignersAddressesMustBeStrictlyIncreasing{}
+		}
+		prev = addr
+		if _, ok := SignerGroup(m.Config, addr); !ok {
+			return &gethwrappers.ManyChainMultiSigInvalidSigner{}
+		}
+		signers = append(signers, addr)
+	}
+	if m.Config.GroupQuorums[0] == 0 {
+		return &gethwrappers.ManyChainMultiSigMissingConfig{}
+	}
+	if !QuorumReached(m.Config, signers) {
+		return &gethwrappers.ManyChainMultiSigInsufficientSigners{}
+	}
+
+	if uint64(args.ValidUntil) < now {
+		return &gethwrappers.ManyChainMultiSigValidUntilHasAlreadyPassed{}
+	}
+	leaf, err := MetadataLeaf(args.Metadata)
+	if err != nil {
+		return err
+	}
+	if !merkle.Verify(toHashes(args.MetadataProof), args.Root, leaf) {
+		return &gethwrappers.ManyChainMultiSigProofCannotBeVerified{}
+	}
+	if args.Metadata.ChainId.Cmp(m.ChainID) != 0 {
+		return &gethwrappers.ManyChainMultiSigWrongChainId{}
+	}
+	if args.Metadata.MultiSig != m.Address {
+		return &gethwrappers.ManyChainMultiSigWrongMultiSig{}
+	}
+	if m.OpCount != m.RootMetadata.PostOpCount.Uint64() && !args.Metadata.OverridePreviousRoot {
+		return &gethwrappers.ManyChainMultiSigPendingOps{}
+	}
+	if m.OpCount != args.Metadata.PreOpCount.Uint64() {
+		return &gethwrappers.ManyChainMultiSigWrongPreOpCount{}
+	}
+	if args.Metadata.PreOpCount.Cmp(args.Metadata.PostOpCount) > 0 {
+		return &gethwrappers.ManyChainMultiSigWrongPostOpCount{}
+	}
+
+	m.SeenSignedHashes[signedHash] = true
+	m.Root, m.ValidUntil = args.Root, args.ValidUntil
+	m.OpCount = args.Metadata.PreOpCount.Uint64()
+	m.RootMetadata = args.Metadata
+	return nil
+}
+
+// Execute models execute at block time now.
+func (m *Model) Execute(now uint64, args *ExecuteArgs) error {
+	if m.RootMetadata.PostOpCount.Uint64() <= m.OpCount {
+		return &gethwrappers.ManyChainMultiSigPostOpCountReached{}
+	}
+	if args.Op.ChainId.Cmp(m.ChainID) != 0 {
+		return &gethwrappers.ManyChainMultiSigWrongChainId{}
+	}
+	if args.Op.MultiSig != m.Address {
+		return &gethwrappers.ManyChainMultiSigWrongMultiSig{}
+	}
+	if now > uint64(m.ValidUntil) {
+		return &gethwrappers.ManyChainMultiSigRootExpired{}
+	}
+	if args.Op.Nonce.Uint64() != m.OpCount {
+		return &gethwrappers.ManyChainMultiSigWrongNonce{}
+	}
+	leaf, err := OpLeaf(args.Op)
+	if err != nil {
+		return err
+	}
+	if !merkle.Verify(toHashes(args.Proof), m.Root, leaf) {
+		return &gethwrappers.ManyChainMultiSigProofCannotBeVerified{}
+	}
+	m.OpCount++
+	return nil
+}
+
+// ecdsaRecover mirrors OpenZeppelin's ECDSA.recover(hash, v, r, s).
+func ecdsaRecover(hash common.Hash, sig gethwrappers.ManyChainMultiSigSignature) (common.Address, error) {
+	if new(big.Int).SetBytes(sig.S[:]).Cmp(secp256k1halfN) > 0 {
+		return common.Address{}, ErrECDSAInvalidSignatureS
+	}
+	if sig.V != 27 && sig.V != 28 {
+		return common.Address{}, ErrECDSAInvalidSignature
+	}
+	raw := make([]byte, 65)
+	copy(raw, sig.R[:])
+	copy(raw[32:], sig.S[:])
+	raw[64] = sig.V - 27
+	pub, err := crypto.SigToPub(hash[:], raw)
+	if err != nil {
+		return common.Address{}, ErrECDSAInvalidSignature
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+func toHashes(proof [][32]byte) []common.Hash {
+	hashes := make([]common.Hash, len(proof))
+	for i, p := range proof {
+		hashes[i] = p
+	}
+	return hashes
+}
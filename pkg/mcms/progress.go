@@ -0,0 +1,296 @@
+package mcms
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+)
+
+// ProgressBackend is the subset of a chain client needed to track the
+// execution of a proposal.
+type ProgressBackend interface {
+	bind.ContractCaller
+	LogBackend
+}
+
+// ProgressStatus summarizes how far a proposal got on one chain, or on all
+// of them.
+type ProgressStatus string
+
+const (
+	// ProgressRootNotSet means the root of the proposal was never set on
+	// the chain.
+	ProgressRootNotSet ProgressStatus = "root-not-set"
+	// ProgressExecuting means the root is set and ops are left.
+	ProgressExecuting ProgressStatus = "executing"
+	// ProgressDone means every op of the chain was executed.
+	ProgressDone ProgressStatus = "done"
+	// ProgressExpired means ops are left but validUntil has passed.
+	ProgressExpired ProgressStatus = "expired"
+	// ProgressSuperseded means another root replaced the root of the
+	// proposal before all of its ops were executed, or ops of another root
+	// used its nonces.
+	ProgressSuperseded ProgressStatus = "superseded"
+
+	// ProgressNotStarted means the root is set on no chain; overall only.
+	ProgressNotStarted ProgressStatus = "not-started"
+	// ProgressInProgress means some chains are not done, none is stuck;
+	// overall only.
+	ProgressInProgress ProgressStatus = "in-progress"
+	// ProgressStuck means a chain can no longer complete; overall only.
+	ProgressStuck ProgressStatus = "stuck"
+)
+
+// ExecutedOp is an op of a proposal found in an OpExecuted event.
+type ExecutedOp struct {
+	Nonce       uint64      `json:"nonce"`
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      common.Hash `json:"txHash"`
+}
+
+// ChainProgress is the progress of a proposal on one of its chains.
+type ChainProgress struct {
+	ChainID     *big.Int       `json:"chainId"`
+	MultiSig    common.Address `json:"multiSig"`
+	PreOpCount  uint64         `json:"preOpCount"`
+	PostOpCount uint64         `json:"postOpCount"`
+	// OpCount is the getOpCount value of the ManyChainMultiSig.
+	OpCount uint64 `json:"opCount"`
+	// RootSet is true if the root of the proposal is the current root.
+	RootSet bool `json:"rootSet"`
+	// SetRoot is the NewRoot event that set the root of the proposal, if
+	// one was found.
+	SetRoot *SeenRoot `json:"setRoot,omitempty"`
+	// Executed is the number of ops of the proposal executed on the chain,
+	// derived from OpCount.
+	Executed uint64 `json:"executed"`
+	// ExecutedOps are the OpExecuted events found for the nonces of the
+	// proposal. Events before the scanned range are missing.
+	ExecutedOps []ExecutedOp `json:"executedOps"`
+	// NextNonce is the nonce of the next op to execute, if any is left.
+	NextNonce *uint64 `json:"nextNonce,omitempty"`
+	// NextOp is the index into Ops of the next op to execute.
+	NextOp  *int           `json:"nextOp,omitempty"`
+	Expired bool           `json:"expired"`
+	Status  ProgressStatus `json:"status"`
+	// Problem explains a superseded status, or why the root cannot be set
+	// yet.
+	Problem string `json:"problem,omitempty"`
+}
+
+// Progress is the progress of a proposal on all of its chains.
+type Progress struct {
+	Root       common.Hash     `json:"root"`
+	ValidUntil uint32          `json:"validUntil"`
+	Chains     []ChainProgress `json:"chains"`
+	Status     ProgressStatus  `json:"status"`
+}
+
+// TrackProgress reports how far the execution of p got on each of its
+// chains. backendFor returns the ProgressBackend for a chain id. NewRoot and
+// OpExecuted events are looked up from fromBlock on.
+func TrackProgress(ctx context.Context, p *Proposal, backendFor func(chainID *big.Int) (ProgressBackend, error), fromBlock uint64) (*Progress, error) {
+	root, err := p.Root()
+	if err != nil {
+		return nil, err
+	}
+	progress := &Progress{Root: root, ValidUntil: p.ValidUntil, Chains: make([]ChainProgress, len(p.Chains))}
+	for i, c := range p.Chains {
+		backend, err := backendFor(c.ChainID)
+		if err != nil {
+			return nil, err
+		}
+		cp, err := chainProgress(ctx, backend, p, i, root, fromBlock)
+		if err != nil {
+			return nil, fmt.Errorf("chain %v: %w", c.ChainID, err)
+		}
+		progress.Chains[i] = *cp
+	}
+	progress.Status = overallProgress(progress.Chains)
+	return progress, nil
+}
+
+func chainProgress(ctx context.Context, backend ProgressBackend, p *Proposal, i int, root common.Hash, fromBlock uint64) (*ChainProgress, error) {
+	c := p.Chains[i]
+	cp := &ChainProgress{
+		ChainID:     c.ChainID,
+		MultiSig:    c.MultiSig,
+		PreOpCount:  c.PreOpCount,
+		PostOpCount: p.PostOpCount(i),
+		ExecutedOps: []ExecutedOp{},
+	}
+	head, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	caller, err := gethwrappers.NewManyChainMultiSigCaller(c.MultiSig, backend)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: head.Number}
+	current, err := caller.GetRoot(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getRoot: %w", err)
+	}
+	opCount, err := caller.GetOpCount(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getOpCount: %w", err)
+	}
+	cp.OpCount = opCount.Uint64()
+	cp.RootSet = current.Root == root && current.ValidUntil == p.ValidUntil
+	cp.Expired = uint64(p.ValidUntil) < head.Time
+	if cp.OpCount > cp.PreOpCount {
+		cp.Executed = min(cp.OpCount, cp.PostOpCount) - cp.PreOpCount
+	}
+
+	seen, err := FetchSeenSignedHashes(ctx, backend, c.MultiSig, fromBlock, evm.DefaultLogBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	if record, ok := seen.Lookup(root, p.ValidUntil); ok {
+		cp.SetRoot = &record
+	}
+	indices := p.OpIndices(c.ChainID, c.MultiSig)
+	foreign, err := fetchExecutedOps(ctx, backend, p, cp, indices, fromBlock, head.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case foreign != "":
+		// The root of the proposal proves its own ops only, so the
+		// nonce was used by another root.
+		cp.Status, cp.Problem = ProgressSuperseded, foreign
+	case cp.OpCount >= cp.PostOpCount && (cp.RootSet || cp.SetRoot != nil || uint64(len(cp.ExecutedOps)) == cp.Executed):
+		// The nonces of the proposal are used, and by its ops: its root
+		// was set, or the OpExecuted events of all of them match.
+		cp.Status = ProgressDone
+	case !cp.RootSet && (cp.SetRoot != nil || cp.OpCount > cp.PreOpCount):
+		cp.Status = ProgressSuperseded
+		cp.Problem = fmt.Sprintf("the current root is %s", common.Hash(current.Root))
+		if cp.SetRoot == nil {
+			cp.Problem = fmt.Sprintf("opCount is %d, past preOpCount %d, but the root of the proposal was never set", cp.OpCount, cp.PreOpCount)
+		}
+	case cp.Expired:
+		cp.Status = ProgressExpired
+	case cp.RootSet:
+		cp.Status = ProgressExecuting
+	default:
+		cp.Status = ProgressRootNotSet
+		if cp.OpCount < cp.PreOpCount {
+			cp.Problem = fmt.Sprintf("opCount is %d, below preOpCount %d: ops of an earlier root are pending", cp.OpCount, cp.PreOpCount)
+		}
+	}
+	if (cp.Status == ProgressExecuting || cp.Status == ProgressRootNotSet) && cp.Executed < uint64(len(indices)) {
+		next := cp.PreOpCount + cp.Executed
+		op := indices[cp.Executed]
+		cp.NextNonce, cp.NextOp = &next, &op
+	}
+	return cp, nil
+}
+
+// fetchExecutedOps adds the OpExecuted events of the nonces of chain cp to
+// cp.ExecutedOps, and describes the first event that is not of the op of
+// the proposal with its nonce.
+func fetchExecutedOps(ctx context.Context, backend ProgressBackend, p *Proposal, cp *ChainProgress, indices []int, fromBlock, latest uint64) (string, error) {
+	if len(indices) == 0 || cp.OpCount <= cp.PreOpCount {
+		return "", nil
+	}
+	filterer, err := gethwrappers.NewManyChainMultiSigFilterer(cp.MultiSig, backend)
+	if err != nil {
+		return "", err
+	}
+	scan := newExecutedScan(p, indices, cp.PreOpCount, cp.Executed)
+	if err := scan.scanBlocks(ctx, filterer, fromBlock, latest); err != nil {
+		return "", err
+	}
+	cp.ExecutedOps = append(cp.ExecutedOps, scan.ops...)
+	return scan.foreign, nil
+}
+
+// executedScan matches the OpExecuted events of the first executed nonces of
+// a chain against the ops of the proposal.
+type executedScan struct {
+	p          *Proposal
+	indices    []int
+	preOpCount uint64
+	nonces     []*big.Int
+	// ops are the events of ops of the proposal.
+	ops []ExecutedOp
+	// foreign describes the first event of another op, if any.
+	foreign string
+}
+
+func newExecutedScan(p *Proposal, indices []int, preOpCount, executed uint64) *executedScan {
+	s := &executedScan{p: p, indices: indices, preOpCount: preOpCount}
+	for n := preOpCount; n < preOpCount+executed; n++ {
+		s.nonces = append(s.nonces, new(big.Int).SetUint64(n))
+	}
+	return s
+}
+
+// scanBlocks scans blocks fromBlock to latest, evm.DefaultLogBatchSize blocks
+// per request.
+func (s *executedScan) scanBlocks(ctx context.Context, filterer gethwrappers.ManyChainMultiSigFiltererInterface, fromBlock, latest uint64) error {
+	for start := fromBlock; start <= latest; start += evm.DefaultLogBatchSize {
+		end := min(start+evm.DefaultLogBatchSize-1, latest)
+		if err := s.scan(filterer, &bind.FilterOpts{Start: start, End: &end, Context: ctx}); err != nil {
+			return fmt.Errorf("fetching OpExecuted logs in blocks [%d, %d]: %w", start, end, err)
+		}
+	}
+	return nil
+}
+
+func (s *executedScan) scan(filterer gethwrappers.ManyChainMultiSigFiltererInterface, opts *bind.FilterOpts) error {
+	it, err := filterer.FilterOpExecuted(opts, s.nonces)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.Next() {
+		ev := it.Event
+		nonce := ev.Nonce.Uint64()
+		op := s.p.Ops[s.indices[nonce-s.preOpCount]]
+		value := op.Value
+		if value == nil {
+			value = new(big.Int)
+		}
+		if ev.To != op.To || !bytes.Equal(ev.Data, op.Data) || ev.Value.Cmp(value) != 0 {
+			if s.foreign == "" {
+				s.foreign = fmt.Sprintf("the op with nonce %d executed in tx %s is not the op of the proposal", nonce, ev.Raw.TxHash)
+			}
+			continue
+		}
+		s.ops = append(s.ops, ExecutedOp{Nonce: nonce, BlockNumber: ev.Raw.BlockNumber, TxHash: ev.Raw.TxHash})
+	}
+	return it.Error()
+}
+
+func overallProgress(chains []ChainProgress) ProgressStatus {
+	done, notSet := 0, 0
+	for _, c := range chains {
+		switch c.Status {
+		case ProgressExpired, ProgressSuperseded:
+			return ProgressStuck
+		case ProgressDone:
+			done++
+		case ProgressRootNotSet:
+			notSet++
+		}
+	}
+	switch {
+	case done == len(chains):
+		return ProgressDone
+	case notSet == len(chains):
+		return ProgressNotStarted
+	default:
+		return ProgressInProgress
+	}
+}
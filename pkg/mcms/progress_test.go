@@ -0,0 +1,110 @@
+package mcms_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+func TestTrackProgress(t *testing.T) {
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	track := func(p *mcms.Proposal) *mcms.Progress {
+		t.Helper()
+		progress, err := mcms.TrackProgress(context.Background(), p, func(*big.Int) (mcms.ProgressBackend, error) {
+			return e.Backend, nil
+		}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return progress
+	}
+	schedule := func(salt byte) mcms.Operation {
+		return e.ScheduleOp(&timelock.Batch{Calls: []timelock.Call{{Target: e.TimelockAddress}}, Salt: common.Hash{salt}})
+	}
+
+	p := e.NewProposal(e.Proposer, schedule(1), schedule(2))
+	progress := track(p)
+	c := progress.Chains[0]
+	if progress.Status != mcms.ProgressNotStarted || c.Status != mcms.ProgressRootNotSet || c.RootSet || *c.NextNonce != 0 || *c.NextOp != 0 {
+		t.Fatalf("before setRoot: %+v", progress)
+	}
+
+	e.SetRoot(p)
+	args, err := p.ExecuteArgs(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt := e.Send(e.Proposer.Contract.Execute(e.Deployer.Opts, args[0].Op, args[0].Proof))
+	progress = track(p)
+	c = progress.Chains[0]
+	if progress.Status != mcms.ProgressInProgress || c.Status != mcms.ProgressExecuting || !c.RootSet || c.SetRoot == nil {
+		t.Fatalf("after one op: %+v", progress)
+	}
+	if c.Executed != 1 || len(c.ExecutedOps) != 1 || c.ExecutedOps[0].TxHash != receipt.TxHash || *c.NextNonce != 1 || *c.NextOp != 1 {
+		t.Fatalf("after one op: %+v", c)
+	}
+
+	e.Send(e.Proposer.Contract.Execute(e.Deployer.Opts, args[1].Op, args[1].Proof))
+	progress = track(p)
+	c = progress.Chains[0]
+	if progress.Status != mcms.ProgressDone || c.Status != mcms.ProgressDone || c.Executed != 2 || c.NextNonce != nil {
+		t.Fatalf("after all ops: %+v", progress)
+	}
+
+	// A proposal whose root is set but left to expire is stuck.
+	expiring := e.NewProposal(e.Proposer, schedule(3))
+	e.SetRoot(expiring)
+	e.AdvanceTime(25 * time.Hour)
+	if progress := track(expiring); progress.Status != mcms.ProgressStuck || progress.Chains[0].Status != mcms.ProgressExpired {
+		t.Fatalf("after validUntil: %+v", progress)
+	}
+	// Once a later root overrides it, the expired one is superseded.
+	override := e.NewProposal(e.Proposer, schedule(4))
+	override.Chains[0].OverridePreviousRoot = true
+	override.Signatures = nil
+	root, err := override.Root()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range e.Proposer.Signers[:e.Proposer.Config.GroupQuorums[0]] {
+		sig, err := signer.SignRoot(context.Background(), s.Signer, root, override.ValidUntil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := override.AddSignature(signer.FromGethSignature(sig)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	e.Submit(override)
+	if c := track(expiring).Chains[0]; c.Status != mcms.ProgressSuperseded || c.Problem == "" {
+		t.Fatalf("after a later root: %+v", c)
+	}
+}
+
+func TestTrackProgressNonceReused(t *testing.T) {
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	op := func(salt byte) mcms.Operation {
+		return e.ScheduleOp(&timelock.Batch{Calls: []timelock.Call{{Target: e.TimelockAddress}}, Salt: common.Hash{salt}})
+	}
+	// Two proposals built for the same nonce; only the second is executed.
+	planned := e.NewProposal(e.Proposer, op(1))
+	e.Submit(e.NewProposal(e.Proposer, op(2)))
+
+	progress, err := mcms.TrackProgress(context.Background(), planned, func(*big.Int) (mcms.ProgressBackend, error) {
+		return e.Backend, nil
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := progress.Chains[0]; progress.Status != mcms.ProgressStuck || c.Status != mcms.ProgressSuperseded || c.Problem == "" {
+		t.Fatalf("progress = %+v", progress)
+	}
+}
@@ -0,0 +1,399 @@
+// Package mcms builds, signs and verifies ManyChainMultiSig proposals.
+//
+// A Proposal is the offchain counterpart of a signed Merkle root: it lists the
+// ManyChainMultiSig instances (one per chain) the root is destined for and the
+// ops to execute on each of them. The Merkle tree, the proofs passed to
+// setRoot/execute and the hash that signers sign are all derived from it.
+package mcms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/merkle"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+)
+
+// ChainMetadata identifies a ManyChainMultiSig instance targeted by a proposal
+// and carries the fields of its RootMetadata leaf.
+type ChainMetadata struct {
+	ChainID  *big.Int       `json:"chainId"`
+	MultiSig common.Address `json:"multiSig"`
+	// PreOpCount is the opCount of the ManyChainMultiSig before the root is
+	// set. The nonces of the ops for this instance start here.
+	PreOpCount uint64 `json:"preOpCount"`
+	// OverridePreviousRoot should be left false unless the pending ops of the
+	// previous root are meant to be abandoned.
+	OverridePreviousRoot bool `json:"overridePreviousRoot"`
+}
+
+// Operation is a single call to be made by a ManyChainMultiSig instance. Its
+// nonce is implied by its position among the ops for the same instance.
+type Operation struct {
+	ChainID  *big.Int       `json:"chainId"`
+	MultiSig common.Address `json:"multiSig"`
+	To       common.Address `json:"to"`
+	Value    *big.Int       `json:"value,omitempty"`
+	Data     hexutil.Bytes  `json:"data"`
+}
+
+// Proposal is a set of ops spanning one or more ManyChainMultiSig instances
+// that is authorized by a single signed Merkle root.
+type Proposal struct {
+	Description string          `json:"description,omitempty"`
+	ValidUntil  uint32          `json:"validUntil"`
+	Chains      []ChainMetadata `json:"chains"`
+	Ops         []Operation     `json:"ops"`
+	// Signatures holds the collected [R || S || V] signatures on
+	// (root, validUntil). They are not part of the proposal hash.
+	Signatures []hexutil.Bytes `json:"signatures,omitempty"`
+}
+
+// Tree is the Merkle tree of a proposal together with the proofs required to
+// call setRoot and execute.
+type Tree struct {
+	Root common.Hash
+	// MetadataProofs[i] proves the RootMetadata of Chains[i].
+	MetadataProofs [][]common.Hash
+	// OpProofs[i] proves Ops[i].
+	OpProofs [][]common.Hash
+}
+
+// LoadProposal reads a JSON encoded proposal from path.
+func LoadProposal(path string) (*Proposal, error) {
+	var p Proposal
+	if err := readJSON(path, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ProposalFiles expands paths into proposal files: a file is taken as it
+// is, a directory stands for the *.json files in it. The result is sorted.
+func ProposalFiles(paths ...string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Save writes the proposal to path as indented JSON.
+func (p *Proposal) Save(path string) error {
+	return writeJSON(path, p)
+}
+
+// Validate checks that the proposal is well formed.
+func (p *Proposal) Validate() error {
+	if p.ValidUntil == 0 {
+		return errors.New("validUntil must be set")
+	}
+	if len(p.Chains) == 0 {
+		return errors.New("proposal targets no chains")
+	}
+	seen := make(map[string]bool)
+	for i, c := range p.Chains {
+		if c.ChainID == nil || c.ChainID.Sign() <= 0 {
+			return fmt.Errorf("chains[%d]: invalid chain id", i)
+		}
+		if c.MultiSig == (common.Address{}) {
+			return fmt.Errorf("chains[%d]: missing multiSig", i)
+		}
+		key := chainKey(c.ChainID, c.MultiSig)
+		if seen[key] {
+			return fmt.Errorf("chains[%d]: duplicate entry for %s", i, key)
+		}
+		seen[key] = true
+	}
+	for i, op := range p.Ops {
+		if p.ChainIndex(op.ChainID, op.MultiSig) < 0 {
+			return fmt.Errorf("ops[%d]: %s is not listed in chains", i, chainKey(op.ChainID, op.MultiSig))
+		}
+		if op.Value != nil && op.Value.Sign() < 0 {
+			return fmt.Errorf("ops[%d]: negative value", i)
+		}
+	}
+	for i := range p.Chains {
+		if p.PostOpCount(i) > MaxOpCount {
+			return fmt.Errorf("chains[%d]: postOpCount overflows uint40", i)
+		}
+	}
+	return nil
+}
+
+// PostOpCount returns the opCount of Chains[i] once all of its ops are executed.
+func (p *Proposal) PostOpCount(i int) uint64 {
+	c := p.Chains[i]
+	return c.PreOpCount + uint64(len(p.OpIndices(c.ChainID, c.MultiSig)))
+}
+
+// OpIndices returns the indices into Ops of the ops targeting the given
+// ManyChainMultiSig, in nonce order.
+func (p *Proposal) OpIndices(chainID *big.Int, multiSig common.Address) []int {
+	var indices []int
+	for i, op := range p.Ops {
+		if sameChain(op.ChainID, op.MultiSig, chainID, multiSig) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// RootMetadata returns the RootMetadata leaf contents for Chains[i].
+func (p *Proposal) RootMetadata(i int) gethwrappers.ManyChainMultiSigRootMetadata {
+	c := p.Chains[i]
+	return gethwrappers.ManyChainMultiSigRootMetadata{
+		ChainId:              new(big.Int).Set(c.ChainID),
+		MultiSig:             c.MultiSig,
+		PreOpCount:           new(big.Int).SetUint64(c.PreOpCount),
+		PostOpCount:          new(big.Int).SetUint64(p.PostOpCount(i)),
+		OverridePreviousRoot: c.OverridePreviousRoot,
+	}
+}
+
+// MCMSOps returns the ops in proposal order with their nonces filled in.
+func (p *Proposal) MCMSOps() ([]gethwrappers.ManyChainMultiSigOp, error) {
+	ops := make([]gethwrappers.ManyChainMultiSigOp, len(p.Ops))
+	next := make(map[string]uint64)
+	for i, op := range p.Ops {
+		ci := p.ChainIndex(op.ChainID, op.MultiSig)
+		if ci < 0 {
+			return nil, fmt.Errorf("ops[%d]: %s is not listed in chains", i, chainKey(op.ChainID, op.MultiSig))
+		}
+		key := chainKey(op.ChainID, op.MultiSig)
+		nonce, ok := next[key]
+		if !ok {
+			nonce = p.Chains[ci].PreOpCount
+		}
+		next[key] = nonce + 1
+
+		value := new(big.Int)
+		if op.Value != nil {
+			value.Set(op.Value)
+		}
+		data := []byte(op.Data)
+		if data == nil {
+			data = []byte{}
+		}
+		ops[i] = gethwrappers.ManyChainMultiSigOp{
+			ChainId:  new(big.Int).Set(op.ChainID),
+			MultiSig: op.MultiSig,
+			Nonce:    new(big.Int).SetUint64(nonce),
+			To:       op.To,
+			Value:    value,
+			Data:     data,
+		}
+	}
+	return ops, nil
+}
+
+// Leaves returns the Merkle tree leaves: one metadata leaf per chain followed
+// by one leaf per op, in proposal order.
+func (p *Proposal) Leaves() ([]common.Hash, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	leaves := make([]common.Hash, 0, len(p.Chains)+len(p.Ops))
+	for i := range p.Chains {
+		leaf, err := MetadataLeaf(p.RootMetadata(i))
+		if err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, leaf)
+	}
+	ops, err := p.MCMSOps()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		leaf, err := OpLeaf(op)
+		if err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, leaf)
+	}
+	return leaves, nil
+}
+
+// MerkleTree builds the proposal's Merkle tree and all proofs.
+func (p *Proposal) MerkleTree() (*Tree, error) {
+	leaves, err := p.Leaves()
+	if err != nil {
+		return nil, err
+	}
+	tree, err := merkle.New(leaves)
+	if err != nil {
+		return nil, err
+	}
+	result := &Tree{
+		Root:           tree.Root(),
+		MetadataProofs: make([][]common.Hash, len(p.Chains)),
+		OpProofs:       make([][]common.Hash, len(p.Ops)),
+	}
+	for i := range p.Chains {
+		if result.MetadataProofs[i], err = tree.Proof(i); err != nil {
+			return nil, err
+		}
+	}
+	for i := range p.Ops {
+		if result.OpProofs[i], err = tree.Proof(len(p.Chains) + i); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Root returns the Merkle root of the proposal.
+func (p *Proposal) Root() (common.Hash, error) {
+	tree, err := p.MerkleTree()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return tree.Root, nil
+}
+
+// SigningHash returns keccak256(abi.encode(root, validUntil)), the hash
+// signers sign (with the EIP-191 prefix applied) to approve the proposal.
+func (p *Proposal) SigningHash() (common.Hash, error) {
+	root, err := p.Root()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return signer.RootHash(root, p.ValidUntil), nil
+}
+
+// Hash returns a digest of the proposal contents, excluding signatures. It
+// identifies a proposal across the tools that exchange it.
+func (p *Proposal) Hash() (common.Hash, error) {
+	unsigned := *p
+	unsigned.Signatures = nil
+	encoded, err := json.Marshal(unsigned)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// AddSignature validates sig against the proposal's signing hash and records
+// it, returning the recovered signer. Adding a second signature from the same
+// signer is an error.
+func (p *Proposal) AddSignature(sig []byte) (common.Address, error) {
+	hash, err := p.SigningHash()
+	if err != nil {
+		return common.Address{}, err
+	}
+	addr, err := signer.Recover(hash, sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	for _, existing := range p.Signatures {
+		other, err := signer.Recover(hash, existing)
+		if err == nil && other == addr {
+			return common.Address{}, fmt.Errorf("already have a signature from %s", addr)
+		}
+	}
+	p.Signatures = append(p.Signatures, common.CopyBytes(sig))
+	return addr, nil
+}
+
+// SetRootSignatures recovers the signer of each collected signature and
+// returns the signatures sorted by signer address, as setRoot requires.
+func (p *Proposal) SetRootSignatures() ([]gethwrappers.ManyChainMultiSigSignature, error) {
+	hash, err := p.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+	type recovered struct {
+		addr common.Address
+		sig  gethwrappers.ManyChainMultiSigSignature
+	}
+	sigs := make([]recovered, 0, len(p.Signatures))
+	for i, raw := range p.Signatures {
+		addr, err := signer.Recover(hash, raw)
+		if err != nil {
+			return nil, fmt.Errorf("signatures[%d]: %w", i, err)
+		}
+		sig, err := signer.ToGethSignature(raw)
+		if err != nil {
+			return nil, fmt.Errorf("signatures[%d]: %w", i, err)
+		}
+		sigs = append(sigs, recovered{addr: addr, sig: sig})
+	}
+	sort.Slice(sigs, func(i, j int) bool {
+		return sigs[i].addr.Cmp(sigs[j].addr) < 0
+	})
+	out := make([]gethwrappers.ManyChainMultiSigSignature, len(sigs))
+	for i, s := range sigs {
+		if i > 0 && sigs[i-1].addr == s.addr {
+			return nil, fmt.Errorf("duplicate signature from %s", s.addr)
+		}
+		out[i] = s.sig
+	}
+	return out, nil
+}
+
+// ChainIndex returns the index into Chains of the given ManyChainMultiSig, or
+// -1 if the proposal does not target it.
+func (p *Proposal) ChainIndex(chainID *big.Int, multiSig common.Address) int {
+	for i, c := range p.Chains {
+		if sameChain(c.ChainID, c.MultiSig, chainID, multiSig) {
+			return i
+		}
+	}
+	return -1
+}
+
+func sameChain(chainA *big.Int, multiSigA common.Address, chainB *big.Int, multiSigB common.Address) bool {
+	return chainA != nil && chainB != nil && chainA.Cmp(chainB) == 0 && multiSigA == multiSigB
+}
+
+func chainKey(chainID *big.Int, multiSig common.Address) string {
+	return fmt.Sprintf("%v/%s", chainID, multiSig)
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,211 @@
+package mcms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+)
+
+// RelayOptions configures Relay.
+type RelayOptions struct {
+	// Concurrency is the number of chains worked on at once, all of them if
+	// zero.
+	Concurrency int
+	// ChainTimeout bounds the work on each chain, if set.
+	ChainTimeout time.Duration
+	// SetRootOnly stops after setRoot.
+	SetRootOnly bool
+	// Logf, if set, is called as transactions are mined. It is called from
+	// several goroutines.
+	Logf func(format string, args ...interface{})
+}
+
+// RelayResult is the outcome of relaying a proposal to one of its chains.
+type RelayResult struct {
+	ChainID  *big.Int       `json:"chainId"`
+	MultiSig common.Address `json:"multiSig"`
+	// SetRootTx is the setRoot transaction sent by the relay, nil if the
+	// root was already set or none was sent.
+	SetRootTx *common.Hash `json:"setRootTx,omitempty"`
+	RootSet   bool         `json:"rootSet"`
+	// Executed are the ops executed by the relay, in nonce order.
+	Executed []ExecutedOp `json:"executed"`
+	// Done is true once every op of the chain is executed, by the relay or
+	// before it.
+	Done bool `json:"done"`
+	// Superseded is true if the nonces of the chain were used by the ops of
+	// another root; Err is then ErrSuperseded.
+	Superseded bool          `json:"superseded"`
+	Duration   time.Duration `json:"duration"`
+	Err        error         `json:"-"`
+	// Error is the text of Err, for JSON output.
+	Error string `json:"error,omitempty"`
+}
+
+// Relay submits p to all of its chains concurrently: on each chain it calls
+// setRoot unless the root is already set, then executes the ops of the chain
+// one by one in nonce order, as execute only accepts the op whose nonce is
+// the op count. clientFor returns the client of a ManyChainMultiSig; it is
+// called from the worker of its chain, with the context bounded by
+// ChainTimeout. Workers whose clients send from the same account on the same
+// chain take turns sending, so that they do not pick the same nonce.
+//
+// Chains are isolated from each other: a chain that fails, or hangs until
+// ChainTimeout, stops with an error in its result while the others go on.
+// Results are in the order of p.Chains. Relaying again after a failure
+// resumes where each chain stopped.
+func Relay(ctx context.Context, p *Proposal, clientFor RelayClientFunc, opts RelayOptions) []RelayResult {
+	results := make([]RelayResult, len(p.Chains))
+	concurrency := opts.Concurrency
+	if concurrency <= 0 || concurrency > len(p.Chains) {
+		concurrency = len(p.Chains)
+	}
+	sem := make(chan struct{}, concurrency)
+	senders := &senderLocks{locks: make(map[string]chan struct{})}
+	var wg sync.WaitGroup
+	for i, c := range p.Chains {
+		results[i] = RelayResult{ChainID: c.ChainID, MultiSig: c.MultiSig, Executed: []ExecutedOp{}}
+		wg.Add(1)
+		go func(r *RelayResult) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			start := time.Now()
+			r.Err = relayChain(ctx, p, clientFor, opts, senders, r)
+			r.Duration = time.Since(start)
+			if r.Err != nil {
+				r.Error = r.Err.Error()
+				r.Superseded = errors.Is(r.Err, ErrSuperseded)
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+// RelayClientFunc returns the client Relay uses for the ManyChainMultiSig at
+// multiSig on chain chainID.
+type RelayClientFunc func(ctx context.Context, chainID *big.Int, multiSig common.Address) (*Client, error)
+
+func relayChain(ctx context.Context, p *Proposal, clientFor RelayClientFunc, opts RelayOptions, senders *senderLocks, r *RelayResult) (err error) {
+	defer func() {
+		// A bug in one worker must not take the other chains down.
+		if v := recover(); v != nil {
+			err = fmt.Errorf("panic: %v", v)
+		}
+	}()
+	if opts.ChainTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.ChainTimeout)
+		defer cancel()
+	}
+	logf := func(format string, args ...interface{}) {
+		if opts.Logf != nil {
+			opts.Logf("chain %v: "+format, append([]interface{}{r.ChainID}, args...)...)
+		}
+	}
+	client, err := clientFor(ctx, r.ChainID, r.MultiSig)
+	if err != nil {
+		return err
+	}
+	// send runs f, which sends a transaction and waits for it, holding the
+	// turn of the client's sender.
+	sender := chainKey(r.ChainID, client.opts.From)
+	send := func(f func() error) error {
+		unlock, err := senders.lock(ctx, sender)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		return f()
+	}
+	chainIndex, err := client.chainIndex(p)
+	if err != nil {
+		return err
+	}
+	root, err := p.Root()
+	if err != nil {
+		return err
+	}
+	state, err := client.State(ctx)
+	if err != nil {
+		return err
+	}
+	postOpCount := p.PostOpCount(chainIndex)
+	switch {
+	case state.Root == root && state.ValidUntil == p.ValidUntil:
+		r.RootSet = true
+	case state.OpCount > p.Chains[chainIndex].PreOpCount:
+		// Nonces of the proposal are used and its root can no longer be
+		// set; ExecuteNext tells whether they were used by its ops, all of
+		// them, or the proposal was superseded.
+		if _, err := client.ExecuteNext(ctx, p); !errors.Is(err, ErrNoPendingOps) {
+			return err
+		}
+		r.Done = true
+		return nil
+	default:
+		var ev *gethwrappers.ManyChainMultiSigNewRoot
+		if err := send(func() (err error) {
+			ev, err = client.SetRoot(ctx, p)
+			return err
+		}); err != nil {
+			return err
+		}
+		r.SetRootTx, r.RootSet = &ev.Raw.TxHash, true
+		logf("root set in tx %s", ev.Raw.TxHash)
+	}
+	if opts.SetRootOnly {
+		r.Done = state.OpCount >= postOpCount
+		return nil
+	}
+	for {
+		var ev *gethwrappers.ManyChainMultiSigOpExecuted
+		err := send(func() (err error) {
+			ev, err = client.ExecuteNext(ctx, p)
+			return err
+		})
+		if errors.Is(err, ErrNoPendingOps) {
+			r.Done = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		nonce := ev.Nonce.Uint64()
+		r.Executed = append(r.Executed, ExecutedOp{Nonce: nonce, BlockNumber: ev.Raw.BlockNumber, TxHash: ev.Raw.TxHash})
+		logf("op with nonce %d executed in tx %s", nonce, ev.Raw.TxHash)
+	}
+}
+
+// senderLocks gives the workers of Relay sending from the same account on the
+// same chain turns.
+type senderLocks struct {
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+// lock waits for the turn of sender, or for ctx to be done, and returns the
+// function ending the turn.
+func (l *senderLocks) lock(ctx context.Context, sender string) (func(), error) {
+	l.mu.Lock()
+	turn, ok := l.locks[sender]
+	if !ok {
+		turn = make(chan struct{}, 1)
+		l.locks[sender] = turn
+	}
+	l.mu.Unlock()
+	select {
+	case turn <- struct{}{}:
+		return func() { <-turn }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
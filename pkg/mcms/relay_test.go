@@ -0,0 +1,183 @@
+package mcms_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/fake"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+)
+
+func TestRelay(t *testing.T) {
+	ctx := context.Background()
+	accounts := harness.GenerateSigners(t, 3)
+	deployer, signers := accounts[0], accounts[1:]
+	start := time.Unix(1_700_000_000, 0)
+	p := &mcms.Proposal{ValidUntil: uint32(start.Add(time.Hour).Unix())}
+	clients := make(map[string]*mcms.Client)
+	// Chains 1 and 2 have two ops and one op; chain 3 has no endpoint.
+	for id, numOps := range []int{2, 1, 1} {
+		chainID := big.NewInt(int64(id + 1))
+		chain := fake.NewChain(chainID, start)
+		address, _, ms, err := fake.DeployManyChainMultiSig(deployer.Opts, chain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ms.SetConfig(deployer.Opts, harness.SignerAddresses(signers), []uint8{0, 0}, [32]uint8{2}, [32]uint8{}, false); err != nil {
+			t.Fatal(err)
+		}
+		p.Chains = append(p.Chains, mcms.ChainMetadata{ChainID: chainID, MultiSig: address})
+		for i := 0; i < numOps; i++ {
+			p.Ops = append(p.Ops, mcms.Operation{
+				ChainID:  chainID,
+				MultiSig: address,
+				To:       common.BigToAddress(big.NewInt(int64(0x1000 + i))),
+				Data:     []byte{byte(i)},
+			})
+		}
+		if id < 2 {
+			clients[chainID.String()] = mcms.NewClientFromContract(chainID, address, ms, chain, deployer.Opts)
+		}
+	}
+	signProposal(t, p, signers)
+	errNoEndpoint := errors.New("no endpoint")
+	clientFor := func(_ context.Context, chainID *big.Int, _ common.Address) (*mcms.Client, error) {
+		if c, ok := clients[chainID.String()]; ok {
+			return c, nil
+		}
+		return nil, errNoEndpoint
+	}
+
+	results := mcms.Relay(ctx, p, clientFor, mcms.RelayOptions{Concurrency: 2})
+	if len(results) != 3 {
+		t.Fatalf("%d results", len(results))
+	}
+	for i, wantOps := range []int{2, 1} {
+		r := results[i]
+		if r.Err != nil || !r.Done || r.SetRootTx == nil || len(r.Executed) != wantOps {
+			t.Fatalf("chain %v: %+v", r.ChainID, r)
+		}
+		for nonce, op := range r.Executed {
+			if op.Nonce != uint64(nonce) {
+				t.Fatalf("chain %v executed nonce %d at position %d", r.ChainID, op.Nonce, nonce)
+			}
+		}
+	}
+	if r := results[2]; !errors.Is(r.Err, errNoEndpoint) || r.Done || r.Error == "" {
+		t.Fatalf("chain without endpoint: %+v", r)
+	}
+
+	// Relaying again finds the work done.
+	for _, r := range mcms.Relay(ctx, p, clientFor, mcms.RelayOptions{})[:2] {
+		if r.Err != nil || !r.Done || r.SetRootTx != nil || len(r.Executed) != 0 {
+			t.Fatalf("chain %v relayed again: %+v", r.ChainID, r)
+		}
+	}
+}
+
+// slowBackend takes a while to accept each transaction, so that senders that
+// do not take turns pick the same nonce.
+type slowBackend struct {
+	harness.AutoMiningBackend
+}
+
+func (b slowBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	time.Sleep(50 * time.Millisecond)
+	return b.AutoMiningBackend.SendTransaction(ctx, tx)
+}
+
+func TestRelaySimulated(t *testing.T) {
+	ctx := context.Background()
+	e := harness.NewBare(t)
+	// Two multisigs with the same signers on one chain, relayed to by the
+	// same account, and a third chain whose node hangs.
+	first := e.DeployMultiSig(3, 2)
+	second := e.DeployMultiSig(3, 2)
+	var quorums, parents [mcms.NumGroups]uint8
+	quorums[0] = 2
+	e.Send(second.Contract.SetConfig(e.Deployer.Opts, harness.SignerAddresses(first.Signers), make([]uint8, 3), quorums, parents, false))
+	hanging := big.NewInt(2)
+	p := &mcms.Proposal{ValidUntil: uint32(e.Now().Add(time.Hour).Unix())}
+	for _, c := range []mcms.ChainMetadata{
+		{ChainID: harness.ChainID, MultiSig: first.Address},
+		{ChainID: harness.ChainID, MultiSig: second.Address},
+		{ChainID: hanging, MultiSig: first.Address},
+	} {
+		p.Chains = append(p.Chains, c)
+		for i := 0; i < 2; i++ {
+			p.Ops = append(p.Ops, mcms.Operation{
+				ChainID:  c.ChainID,
+				MultiSig: c.MultiSig,
+				To:       common.BigToAddress(big.NewInt(int64(0x1000 + i))),
+				Data:     []byte{byte(i)},
+			})
+		}
+	}
+	signProposal(t, p, first.Signers[:2])
+
+	backend := slowBackend{e.AutoMining()}
+	clientFor := func(ctx context.Context, chainID *big.Int, multiSig common.Address) (*mcms.Client, error) {
+		if chainID.Cmp(hanging) == 0 {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return mcms.NewClient(chainID, multiSig, backend, e.Deployer.Opts)
+	}
+	results := mcms.Relay(ctx, p, clientFor, mcms.RelayOptions{ChainTimeout: 3 * time.Second})
+	for _, r := range results[:2] {
+		if r.Err != nil || !r.Done || r.SetRootTx == nil || len(r.Executed) != 2 {
+			t.Fatalf("chain %v %s: %+v", r.ChainID, r.MultiSig, r)
+		}
+	}
+	if r := results[2]; !errors.Is(r.Err, context.DeadlineExceeded) || r.Done {
+		t.Fatalf("hanging chain: %+v", r)
+	}
+
+	// Another root executing the nonces of the second multisig supersedes
+	// the proposal there.
+	rival := &mcms.Proposal{ValidUntil: p.ValidUntil, Chains: p.Chains[1:2], Ops: append([]mcms.Operation(nil), p.Ops[2:4]...)}
+	rival.Ops[0].Data = []byte{9}
+	if results = mcms.Relay(ctx, rival, clientFor, mcms.RelayOptions{}); !results[0].Superseded || !errors.Is(results[0].Err, mcms.ErrSuperseded) {
+		t.Fatalf("superseded chain: %+v", results[0])
+	}
+
+	// So does a one-op root using the first of its two nonces; the relay
+	// does not try to set its root.
+	chain := mcms.ChainMetadata{ChainID: harness.ChainID, MultiSig: second.Address, PreOpCount: 2}
+	one := &mcms.Proposal{ValidUntil: p.ValidUntil, Chains: []mcms.ChainMetadata{chain}, Ops: p.Ops[2:3]}
+	signProposal(t, one, first.Signers[:2])
+	if results = mcms.Relay(ctx, one, clientFor, mcms.RelayOptions{}); results[0].Err != nil || !results[0].Done {
+		t.Fatalf("one-op root: %+v", results[0])
+	}
+	two := &mcms.Proposal{ValidUntil: p.ValidUntil, Chains: []mcms.ChainMetadata{chain}, Ops: []mcms.Operation{p.Ops[3], p.Ops[2]}}
+	signProposal(t, two, first.Signers[:2])
+	if results = mcms.Relay(ctx, two, clientFor, mcms.RelayOptions{}); !results[0].Superseded || results[0].SetRootTx != nil {
+		t.Fatalf("partly superseded chain: %+v", results[0])
+	}
+}
+
+// signProposal adds the signatures of signers to p.
+func signProposal(t *testing.T, p *mcms.Proposal, signers []*harness.Account) {
+	t.Helper()
+	root, err := p.Root()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range signers {
+		sig, err := signer.SignRoot(context.Background(), s.Signer, root, p.ValidUntil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.AddSignature(signer.FromGethSignature(sig)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package mcms
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+)
+
+// LogBackend is the subset of a chain client needed to scan contract logs.
+type LogBackend interface {
+	bind.ContractFilterer
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// SeenRoot records a NewRoot event.
+type SeenRoot struct {
+	Root        common.Hash `json:"root"`
+	ValidUntil  uint32      `json:"validUntil"`
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      common.Hash `json:"txHash"`
+}
+
+// SeenSignedHashes is the set of signed hashes a ManyChainMultiSig instance
+// has accepted, i.e. the offchain view of s_seenSignedHashes. Keys are the
+// EIP-191 prefixed hashes, as stored by the contract.
+type SeenSignedHashes map[common.Hash]SeenRoot
+
+// SignedHash returns the hash setRoot records in s_seenSignedHashes for
+// (root, validUntil).
+func SignedHash(root common.Hash, validUntil uint32) common.Hash {
+	return signer.EthSignedMessageHash(signer.RootHash(root, validUntil))
+}
+
+// Lookup returns the NewRoot event that used (root, validUntil), if any.
+func (s SeenSignedHashes) Lookup(root common.Hash, validUntil uint32) (SeenRoot, bool) {
+	seen, ok := s[SignedHash(root, validUntil)]
+	return seen, ok
+}
+
+// FetchSeenSignedHashes rebuilds the set of signed hashes used with the
+// ManyChainMultiSig at multiSig from its NewRoot events between fromBlock and
+// the latest block, querying batchSize blocks at a time.
+func FetchSeenSignedHashes(ctx context.Context, backend LogBackend, multiSig common.Address, fromBlock, batchSize uint64) (SeenSignedHashes, error) {
+	if batchSize == 0 {
+		batchSize = evm.DefaultLogBatchSize
+	}
+	filterer, err := gethwrappers.NewManyChainMultiSigFilterer(multiSig, backend)
+	if err != nil {
+		return nil, err
+	}
+	head, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	latest := head.Number.Uint64()
+
+	seen := make(SeenSignedHashes)
+	for start := fromBlock; start <= latest; start += batchSize {
+		end := start + batchSize - 1
+		if end > latest {
+			end = latest
+		}
+		it, err := filterer.FilterNewRoot(&bind.FilterOpts{Start: start, End: &end, Context: ctx}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("fetching NewRoot logs in blocks [%d, %d]: %w", start, end, err)
+		}
+		for it.Next() {
+			ev := it.Event
+			seen[SignedHash(ev.Root, ev.ValidUntil)] = SeenRoot{
+				Root:        ev.Root,
+				ValidUntil:  ev.ValidUntil,
+				BlockNumber: ev.Raw.BlockNumber,
+				TxHash:      ev.Raw.TxHash,
+			}
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return seen, nil
+}
+
+// ReplayCheck is the result of checking one chain of a proposal for replay.
+type ReplayCheck struct {
+	ChainID  *big.Int       `json:"chainId"`
+	MultiSig common.Address `json:"multiSig"`
+	// Seen is set if the proposal's (root, validUntil) was already used on
+	// this instance; setRoot would revert with SignedHashAlreadySeen.
+	Seen *SeenRoot `json:"seen,omitempty"`
+}
+
+// CheckReplay checks every chain of p against its ManyChainMultiSig's
+// NewRoot history. backendFor returns the LogBackend for a chain id.
+func CheckReplay(ctx context.Context, p *Proposal, backendFor func(chainID *big.Int) (LogBackend, error), fromBlock uint64) ([]ReplayCheck, error) {
+	root, err := p.Root()
+	if err != nil {
+		return nil, err
+	}
+	checks := make([]ReplayCheck, len(p.Chains))
+	for i, c := range p.Chains {
+		backend, err := backendFor(c.ChainID)
+		if err != nil {
+			return nil, err
+		}
+		seen, err := FetchSeenSignedHashes(ctx, backend, c.MultiSig, fromBlock, evm.DefaultLogBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("chain %v: %w", c.ChainID, err)
+		}
+		checks[i] = ReplayCheck{ChainID: c.ChainID, MultiSig: c.MultiSig}
+		if record, ok := seen.Lookup(root, p.ValidUntil); ok {
+			checks[i].Seen = &record
+		}
+	}
+	return checks, nil
+}
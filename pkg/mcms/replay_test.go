@@ -0,0 +1,96 @@
+package mcms
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/merkle"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+)
+
+func TestFetchSeenSignedHashes(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{owner: {Balance: big.NewInt(1e18)}}, 30_000_000)
+	defer sim.Close()
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	if err != nil {
+		t.Fatal(err)
+	}
+	multiSig, _, contract, err := gethwrappers.DeployManyChainMultiSig(opts, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	var quorums, parents [NumGroups]uint8
+	quorums[0] = 1
+	if _, err := contract.SetConfig(opts, []common.Address{owner}, []uint8{0}, quorums, parents, false); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	var used []*Proposal
+	for i := uint32(0); i < 3; i++ {
+		p := &Proposal{
+			ValidUntil: 2000000000 + i,
+			Chains:     []ChainMetadata{{ChainID: big.NewInt(1337), MultiSig: multiSig}},
+		}
+		tree, err := p.MerkleTree()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := signer.SignRoot(context.Background(), signer.NewKeySigner(key), tree.Root, p.ValidUntil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = contract.SetRoot(opts, tree.Root, p.ValidUntil, p.RootMetadata(0),
+			merkle.ToBytes32(tree.MetadataProofs[0]), []gethwrappers.ManyChainMultiSigSignature{sig})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sim.Commit()
+		sim.Commit()
+		used = append(used, p)
+	}
+
+	seen, err := FetchSeenSignedHashes(context.Background(), sim, multiSig, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != len(used) {
+		t.Fatalf("got %d seen hashes, want %d", len(seen), len(used))
+	}
+	for _, p := range used {
+		root, _ := p.Root()
+		if _, ok := seen.Lookup(root, p.ValidUntil); !ok {
+			t.Fatalf("root with validUntil %d not found", p.ValidUntil)
+		}
+	}
+
+	fresh := &Proposal{ValidUntil: 2100000000, Chains: used[0].Chains}
+	checks, err := CheckReplay(context.Background(), fresh, func(*big.Int) (LogBackend, error) { return sim, nil }, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checks[0].Seen != nil {
+		t.Fatal("fresh proposal reported as replayed")
+	}
+	checks, err = CheckReplay(context.Background(), used[1], func(*big.Int) (LogBackend, error) { return sim, nil }, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checks[0].Seen == nil {
+		t.Fatal("used proposal not reported as replayed")
+	}
+}
@@ -0,0 +1,170 @@
+package mcms
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+)
+
+// secp256k1halfN is the largest s value accepted by OpenZeppelin's ECDSA.recover.
+var secp256k1halfN = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// Aliases maps signer addresses to human readable names.
+type Aliases map[common.Address]string
+
+// LoadAliases reads a JSON object mapping addresses to names from path.
+func LoadAliases(path string) (Aliases, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var aliases Aliases
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return aliases, nil
+}
+
+// SignatureReportEntry describes one collected signature.
+type SignatureReportEntry struct {
+	Index     int            `json:"index"`
+	Signature hexutil.Bytes  `json:"signature"`
+	Recovered common.Address `json:"recovered,omitempty"`
+	Alias     string         `json:"alias,omitempty"`
+	// Group is the recovered signer's group, nil if it is not a signer.
+	Group *uint8 `json:"group,omitempty"`
+	// Valid is true if setRoot would accept this signature for the
+	// proposal's root and validUntil.
+	Valid bool `json:"valid"`
+	// Duplicate is true if an earlier signature recovers to the same address.
+	Duplicate bool `json:"duplicate"`
+	// Diagnosis explains why the signature is not valid.
+	Diagnosis string `json:"diagnosis,omitempty"`
+}
+
+// SignatureReport lists every signature collected for a proposal.
+type SignatureReport struct {
+	Root          common.Hash            `json:"root"`
+	ValidUntil    uint32                 `json:"validUntil"`
+	SigningHash   common.Hash            `json:"signingHash"`
+	Entries       []SignatureReportEntry `json:"entries"`
+	ValidSigners  int                    `json:"validSigners"`
+	QuorumReached bool                   `json:"quorumReached"`
+}
+
+// wrongDigest is a digest that signers commonly sign by mistake instead of
+// the EIP-191 personal message of the signing hash.
+type wrongDigest struct {
+	digest    common.Hash
+	diagnosis string
+}
+
+// NewSignatureReport checks every signature of p against config, the current
+// ManyChainMultiSigConfig of the targeted instance.
+func NewSignatureReport(p *Proposal, config gethwrappers.ManyChainMultiSigConfig, aliases Aliases) (*SignatureReport, error) {
+	root, err := p.Root()
+	if err != nil {
+		return nil, err
+	}
+	signingHash := signer.RootHash(root, p.ValidUntil)
+	report := &SignatureReport{
+		Root:        root,
+		ValidUntil:  p.ValidUntil,
+		SigningHash: signingHash,
+		Entries:     make([]SignatureReportEntry, len(p.Signatures)),
+	}
+	wrong := []wrongDigest{
+		{signingHash, "signed keccak256(abi.encode(root, validUntil)) without the EIP-191 prefix"},
+		{signer.EthSignedMessageHash(root), "signed the root without validUntil"},
+		{root, "signed the raw root without validUntil or the EIP-191 prefix"},
+		{signer.EthSignedMessageHash(signer.EthSignedMessageHash(signingHash)), "applied the EIP-191 prefix twice"},
+		{common.BytesToHash(accounts.TextHash([]byte(signingHash.Hex()))), "signed the hex string of the signing hash as text"},
+	}
+
+	seen := make(map[common.Address]bool)
+	var valid []common.Address
+	for i, sig := range p.Signatures {
+		entry := SignatureReportEntry{Index: i, Signature: sig}
+		entry.Recovered, entry.Diagnosis = checkSignature(sig, signer.EthSignedMessageHash(signingHash))
+		if entry.Diagnosis == "" {
+			entry.Alias = aliases[entry.Recovered]
+			if group, ok := SignerGroup(config, entry.Recovered); ok {
+				entry.Group = &group
+				entry.Valid = true
+			} else {
+				entry.Diagnosis = diagnoseWrongDigest(sig, wrong, config, aliases)
+			}
+		}
+		if entry.Recovered != (common.Address{}) {
+			entry.Duplicate = seen[entry.Recovered]
+			seen[entry.Recovered] = true
+		}
+		if entry.Valid && !entry.Duplicate {
+			valid = append(valid, entry.Recovered)
+		}
+		report.Entries[i] = entry
+	}
+	report.ValidSigners = len(valid)
+	report.QuorumReached = QuorumReached(config, valid)
+	return report, nil
+}
+
+// checkSignature recovers the signer of digest, returning a diagnosis if
+// setRoot would reject the signature regardless of who signed it.
+func checkSignature(sig []byte, digest common.Hash) (common.Address, string) {
+	if len(sig) != signer.SignatureLength {
+		return common.Address{}, fmt.Sprintf("malformed signature: length %d, want %d", len(sig), signer.SignatureLength)
+	}
+	if v := sig[64]; v != 0 && v != 1 && v != 27 && v != 28 {
+		return common.Address{}, fmt.Sprintf("malformed signature: invalid v %d", v)
+	}
+	addr, err := recoverDigest(digest, sig)
+	if err != nil {
+		return common.Address{}, fmt.Sprintf("malformed signature: %v", err)
+	}
+	if new(big.Int).SetBytes(sig[32:64]).Cmp(secp256k1halfN) > 0 {
+		return addr, "s value is in the upper half of the curve order, which ECDSA.recover rejects"
+	}
+	return addr, ""
+}
+
+func diagnoseWrongDigest(sig []byte, wrong []wrongDigest, config gethwrappers.ManyChainMultiSigConfig, aliases Aliases) string {
+	for _, w := range wrong {
+		addr, err := recoverDigest(w.digest, sig)
+		if err != nil {
+			continue
+		}
+		if _, ok := SignerGroup(config, addr); ok {
+			return fmt.Sprintf("%s (signer %s)", w.diagnosis, describeAddress(addr, aliases))
+		}
+	}
+	return "recovered address is not a signer in the current config"
+}
+
+func describeAddress(addr common.Address, aliases Aliases) string {
+	if alias, ok := aliases[addr]; ok {
+		return fmt.Sprintf("%s %s", alias, addr)
+	}
+	return addr.Hex()
+}
+
+func recoverDigest(digest common.Hash, sig []byte) (common.Address, error) {
+	normalized := common.CopyBytes(sig)
+	if normalized[64] >= 27 {
+		normalized[64] -= 27
+	}
+	pub, err := crypto.SigToPub(digest[:], normalized)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
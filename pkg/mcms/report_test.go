@@ -0,0 +1,104 @@
+package mcms
+
+import (
+	"crypto/ecdsa"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/signer"
+)
+
+// testConfig returns a 2-of-2 root group over two 1-of-2 subgroups, with the
+// keys sorted by address.
+func testConfig(t *testing.T) ([]*ecdsa.PrivateKey, gethwrappers.ManyChainMultiSigConfig) {
+	keys := make([]*ecdsa.PrivateKey, 4)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = key
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return crypto.PubkeyToAddress(keys[i].PublicKey).Cmp(crypto.PubkeyToAddress(keys[j].PublicKey)) < 0
+	})
+	var config gethwrappers.ManyChainMultiSigConfig
+	config.GroupQuorums[0], config.GroupQuorums[1], config.GroupQuorums[2] = 2, 1, 1
+	for i, key := range keys {
+		config.Signers = append(config.Signers, gethwrappers.ManyChainMultiSigSigner{
+			Addr: crypto.PubkeyToAddress(key.PublicKey), Index: uint8(i), Group: uint8(i%2 + 1),
+		})
+	}
+	return keys, config
+}
+
+func signDigest(t *testing.T, key *ecdsa.PrivateKey, digest common.Hash) []byte {
+	sig, err := crypto.Sign(digest[:], key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[64] += 27
+	return sig
+}
+
+func TestQuorumReached(t *testing.T) {
+	keys, config := testConfig(t)
+	addr := func(i int) common.Address { return crypto.PubkeyToAddress(keys[i].PublicKey) }
+
+	cases := []struct {
+		signers []common.Address
+		want    bool
+	}{
+		{nil, false},
+		{[]common.Address{addr(0)}, false},
+		{[]common.Address{addr(0), addr(2)}, false}, // both in group 1
+		{[]common.Address{addr(0), addr(0), addr(2)}, false},
+		{[]common.Address{addr(0), addr(1)}, true},
+		{[]common.Address{addr(0), addr(1), addr(2), addr(3)}, true},
+		{[]common.Address{addr(0), common.HexToAddress("0x1")}, false},
+	}
+	for i, c := range cases {
+		if got := QuorumReached(config, c.signers); got != c.want {
+			t.Errorf("case %d: QuorumReached = %t, want %t", i, got, c.want)
+		}
+	}
+}
+
+func TestSignatureReport(t *testing.T) {
+	keys, config := testConfig(t)
+	p := testProposal()
+	hash, err := p.SigningHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := signDigest(t, keys[0], signer.EthSignedMessageHash(hash))
+	p.Signatures = append(p.Signatures,
+		valid,
+		signDigest(t, keys[1], hash), // missing EIP-191 prefix
+		valid,
+	)
+	aliases := Aliases{crypto.PubkeyToAddress(keys[0].PublicKey): "alice"}
+
+	report, err := NewSignatureReport(p, config, aliases)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second, third := report.Entries[0], report.Entries[1], report.Entries[2]
+	if !first.Valid || first.Duplicate || first.Alias != "alice" || first.Group == nil || *first.Group != 1 {
+		t.Errorf("unexpected first entry %+v", first)
+	}
+	if second.Valid || !strings.Contains(second.Diagnosis, "without the EIP-191 prefix") {
+		t.Errorf("unexpected second entry %+v", second)
+	}
+	if !third.Duplicate {
+		t.Errorf("third entry should be a duplicate")
+	}
+	if report.ValidSigners != 1 || report.QuorumReached {
+		t.Errorf("unexpected totals %d %t", report.ValidSigners, report.QuorumReached)
+	}
+}
@@ -0,0 +1,109 @@
+package mcms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ProposalSpec is the hand written input from which a Proposal is built. It
+// differs from a Proposal in that the expiry may be relative and the opCounts
+// may be left for the builder to read from chain.
+type ProposalSpec struct {
+	Description string `json:"description,omitempty"`
+	// Exactly one of ValidUntil (a unix timestamp) and ValidFor (a Go
+	// duration such as "36h", relative to build time) must be set.
+	ValidUntil uint32      `json:"validUntil,omitempty"`
+	ValidFor   string      `json:"validFor,omitempty"`
+	Chains     []ChainSpec `json:"chains"`
+	Ops        []Operation `json:"ops"`
+}
+
+// ChainSpec describes a targeted ManyChainMultiSig instance.
+type ChainSpec struct {
+	ChainID  *big.Int       `json:"chainId"`
+	MultiSig common.Address `json:"multiSig"`
+	// MultiSigRole names the multisig by its role in a deployment manifest
+	// ("proposer", "canceller" or "bypasser") instead of by MultiSig. It
+	// must be resolved with manifest.ResolveProposalSpec before building.
+	MultiSigRole string `json:"multiSigRole,omitempty"`
+	// PreOpCount is read from chain when nil.
+	PreOpCount           *uint64 `json:"preOpCount,omitempty"`
+	OverridePreviousRoot bool    `json:"overridePreviousRoot,omitempty"`
+}
+
+// OpCountFunc returns the current opCount of a ManyChainMultiSig instance.
+type OpCountFunc func(ctx context.Context, chainID *big.Int, multiSig common.Address) (uint64, error)
+
+// LoadProposalSpec reads a JSON encoded proposal spec from path.
+func LoadProposalSpec(path string) (*ProposalSpec, error) {
+	var spec ProposalSpec
+	if err := readJSON(path, &spec); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// BuildProposal turns spec into a validated Proposal. now anchors ValidFor and
+// opCount is consulted for chains without an explicit PreOpCount; it may be
+// nil if every chain has one.
+func BuildProposal(ctx context.Context, spec *ProposalSpec, now time.Time, opCount OpCountFunc) (*Proposal, error) {
+	p := &Proposal{
+		Description: spec.Description,
+		Ops:         spec.Ops,
+	}
+	switch {
+	case spec.ValidUntil != 0 && spec.ValidFor != "":
+		return nil, errors.New("only one of validUntil and validFor may be set")
+	case spec.ValidUntil != 0:
+		p.ValidUntil = spec.ValidUntil
+	case spec.ValidFor != "":
+		d, err := time.ParseDuration(spec.ValidFor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validFor: %w", err)
+		}
+		validUntil := now.Add(d).Unix()
+		if validUntil > math.MaxUint32 {
+			return nil, errors.New("validFor overflows uint32 validUntil")
+		}
+		p.ValidUntil = uint32(validUntil)
+	default:
+		return nil, errors.New("one of validUntil and validFor must be set")
+	}
+	if int64(p.ValidUntil) <= now.Unix() {
+		return nil, fmt.Errorf("validUntil %d is not in the future", p.ValidUntil)
+	}
+
+	for i, c := range spec.Chains {
+		if c.MultiSig == (common.Address{}) && c.MultiSigRole != "" {
+			return nil, fmt.Errorf("chains[%d]: multiSigRole %q not resolved, build with a manifest", i, c.MultiSigRole)
+		}
+		chain := ChainMetadata{
+			ChainID:              c.ChainID,
+			MultiSig:             c.MultiSig,
+			OverridePreviousRoot: c.OverridePreviousRoot,
+		}
+		switch {
+		case c.PreOpCount != nil:
+			chain.PreOpCount = *c.PreOpCount
+		case opCount == nil:
+			return nil, fmt.Errorf("chains[%d]: preOpCount not given and no chain access configured", i)
+		default:
+			count, err := opCount(ctx, c.ChainID, c.MultiSig)
+			if err != nil {
+				return nil, fmt.Errorf("chains[%d]: reading opCount: %w", i, err)
+			}
+			chain.PreOpCount = count
+		}
+		p.Chains = append(p.Chains, chain)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,55 @@
+package mcms
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBuildProposal(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	multiSig := common.HexToAddress("0xaa")
+	explicit := uint64(7)
+	spec := &ProposalSpec{
+		ValidFor: "2h",
+		Chains: []ChainSpec{
+			{ChainID: big.NewInt(1), MultiSig: multiSig, PreOpCount: &explicit},
+			{ChainID: big.NewInt(2), MultiSig: multiSig},
+		},
+		Ops: []Operation{{ChainID: big.NewInt(2), MultiSig: multiSig, To: common.HexToAddress("0x1")}},
+	}
+	opCount := func(_ context.Context, chainID *big.Int, _ common.Address) (uint64, error) {
+		return chainID.Uint64() * 100, nil
+	}
+
+	p, err := BuildProposal(context.Background(), spec, now, opCount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ValidUntil != uint32(now.Add(2*time.Hour).Unix()) {
+		t.Errorf("validUntil = %d", p.ValidUntil)
+	}
+	if p.Chains[0].PreOpCount != 7 || p.Chains[1].PreOpCount != 200 {
+		t.Errorf("preOpCounts = %d, %d", p.Chains[0].PreOpCount, p.Chains[1].PreOpCount)
+	}
+	if p.PostOpCount(1) != 201 {
+		t.Errorf("postOpCount = %d", p.PostOpCount(1))
+	}
+
+	if _, err := BuildProposal(context.Background(), spec, now, nil); err == nil {
+		t.Error("expected missing preOpCount without chain access to fail")
+	}
+	unresolved := *spec
+	unresolved.Chains = []ChainSpec{{ChainID: big.NewInt(1), MultiSigRole: "proposer", PreOpCount: &explicit}}
+	if _, err := BuildProposal(context.Background(), &unresolved, now, opCount); err == nil {
+		t.Error("expected an unresolved multiSigRole to fail")
+	}
+	spec.ValidFor = ""
+	spec.ValidUntil = uint32(now.Unix())
+	if _, err := BuildProposal(context.Background(), spec, now, opCount); err == nil {
+		t.Error("expected expired validUntil to fail")
+	}
+}
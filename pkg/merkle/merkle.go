@@ -0,0 +1,96 @@
+// Package merkle implements the Merkle tree used by ManyChainMultiSig.
+//
+// Internal nodes are the commutative keccak256 hash of their children, as in
+// OpenZeppelin's MerkleProof, so proofs produced here verify on chain. Layers
+// with an odd number of nodes are padded with the zero hash.
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Tree is a Merkle tree over a fixed list of leaf hashes.
+type Tree struct {
+	// layers[0] holds the leaves, the last layer holds the root.
+	layers [][]common.Hash
+	// leaves is the number of leaves before padding.
+	leaves int
+}
+
+// New builds a tree over leaves. At least one leaf is required.
+func New(leaves []common.Hash) (*Tree, error) {
+	if len(leaves) == 0 {
+		return nil, errors.New("merkle: no leaves")
+	}
+	layer := make([]common.Hash, len(leaves))
+	copy(layer, leaves)
+	layers := [][]common.Hash{layer}
+	for len(layer) > 1 {
+		if len(layer)%2 == 1 {
+			layer = append(layer, common.Hash{})
+			layers[len(layers)-1] = layer
+		}
+		next := make([]common.Hash, len(layer)/2)
+		for i := range next {
+			next[i] = HashPair(layer[2*i], layer[2*i+1])
+		}
+		layers = append(layers, next)
+		layer = next
+	}
+	return &Tree{layers: layers, leaves: len(leaves)}, nil
+}
+
+// Root returns the root of the tree.
+func (t *Tree) Root() common.Hash {
+	return t.layers[len(t.layers)-1][0]
+}
+
+// Proof returns the proof of inclusion of the leaf at index.
+func (t *Tree) Proof(index int) ([]common.Hash, error) {
+	if index < 0 || index >= t.leaves {
+		return nil, fmt.Errorf("merkle: leaf index %d out of range", index)
+	}
+	proof := make([]common.Hash, 0, len(t.layers)-1)
+	for _, layer := range t.layers[:len(t.layers)-1] {
+		proof = append(proof, layer[index^1])
+		index /= 2
+	}
+	return proof, nil
+}
+
+// ProcessProof returns the root obtained by walking proof up from leaf, like
+// MerkleProof.processProof.
+func ProcessProof(proof []common.Hash, leaf common.Hash) common.Hash {
+	computed := leaf
+	for _, sibling := range proof {
+		computed = HashPair(computed, sibling)
+	}
+	return computed
+}
+
+// Verify reports whether proof proves the inclusion of leaf under root.
+func Verify(proof []common.Hash, root, leaf common.Hash) bool {
+	return ProcessProof(proof, leaf) == root
+}
+
+// HashPair returns the commutative keccak256 hash of a and b.
+func HashPair(a, b common.Hash) common.Hash {
+	if bytes.Compare(a[:], b[:]) < 0 {
+		return crypto.Keccak256Hash(a[:], b[:])
+	}
+	return crypto.Keccak256Hash(b[:], a[:])
+}
+
+// ToBytes32 converts a proof into the form expected by the generated wrappers.
+func ToBytes32(proof []common.Hash) [][32]byte {
+	out := make([][32]byte, len(proof))
+	for i, h := range proof {
+		out[i] = h
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func leaves(n int) []common.Hash {
+	out := make([]common.Hash, n)
+	for i := range out {
+		out[i] = crypto.Keccak256Hash([]byte{byte(i)})
+	}
+	return out
+}
+
+func TestProofsVerify(t *testing.T) {
+	for n := 1; n <= 17; n++ {
+		tree, err := New(leaves(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, leaf := range leaves(n) {
+			proof, err := tree.Proof(i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !Verify(proof, tree.Root(), leaf) {
+				t.Fatalf("n=%d: proof for leaf %d does not verify", n, i)
+			}
+			if Verify(proof, tree.Root(), crypto.Keccak256Hash([]byte("other"))) {
+				t.Fatalf("n=%d: proof for leaf %d verifies a foreign leaf", n, i)
+			}
+		}
+	}
+}
+
+func TestSingleLeafIsRoot(t *testing.T) {
+	tree, err := New(leaves(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Root() != leaves(1)[0] {
+		t.Fatal("root of a single leaf tree must be the leaf")
+	}
+}
+
+func TestHashPairIsCommutative(t *testing.T) {
+	a, b := leaves(2)[0], leaves(2)[1]
+	if HashPair(a, b) != HashPair(b, a) {
+		t.Fatal("HashPair must be commutative")
+	}
+}
+
+func TestNoLeaves(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestProofRejectsPadLeaf(t *testing.T) {
+	tree, err := New(leaves(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []int{-1, 3, 4} {
+		if _, err := tree.Proof(i); err == nil {
+			t.Fatalf("expected error for index %d", i)
+		}
+	}
+}
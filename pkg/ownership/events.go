@@ -0,0 +1,172 @@
+package ownership
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+)
+
+// Backend is the subset of a chain client needed to follow transfers.
+type Backend interface {
+	bind.ContractCaller
+	bind.ContractFilterer
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// EventKind tells the two Ownable2Step events apart.
+type EventKind string
+
+const (
+	// EventStarted is OwnershipTransferStarted, emitted by transferOwnership.
+	EventStarted EventKind = "started"
+	// EventTransferred is OwnershipTransferred, emitted once the new owner
+	// accepted (and by the constructor).
+	EventTransferred EventKind = "transferred"
+)
+
+// Event is an ownership event of a contract.
+type Event struct {
+	Kind          EventKind      `json:"kind"`
+	PreviousOwner common.Address `json:"previousOwner"`
+	NewOwner      common.Address `json:"newOwner"`
+	BlockNumber   uint64         `json:"blockNumber"`
+	TxHash        common.Hash    `json:"txHash"`
+	LogIndex      uint           `json:"logIndex"`
+}
+
+// FetchEvents returns the ownership events of contract between fromBlock and
+// the latest block, querying batchSize blocks at a time, in chain order.
+func FetchEvents(ctx context.Context, backend Backend, contract common.Address, fromBlock, batchSize uint64) ([]Event, error) {
+	if batchSize == 0 {
+		batchSize = evm.DefaultLogBatchSize
+	}
+	filterer, err := gethwrappers.NewManyChainMultiSigFilterer(contract, backend)
+	if err != nil {
+		return nil, err
+	}
+	head, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	latest := head.Number.Uint64()
+
+	var events []Event
+	for start := fromBlock; start <= latest; start += batchSize {
+		end := start + batchSize - 1
+		if end > latest {
+			end = latest
+		}
+		filterOpts := &bind.FilterOpts{Start: start, End: &end, Context: ctx}
+		started, err := filterer.FilterOwnershipTransferStarted(filterOpts, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("fetching OwnershipTransferStarted logs in blocks [%d, %d]: %w", start, end, err)
+		}
+		var batch []Event
+		for started.Next() {
+			batch = append(batch, newEvent(EventStarted, started.Event.PreviousOwner, started.Event.NewOwner, started.Event.Raw))
+		}
+		err = started.Error()
+		started.Close()
+		if err != nil {
+			return nil, err
+		}
+		transferred, err := filterer.FilterOwnershipTransferred(filterOpts, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("fetching OwnershipTransferred logs in blocks [%d, %d]: %w", start, end, err)
+		}
+		for transferred.Next() {
+			batch = append(batch, newEvent(EventTransferred, transferred.Event.PreviousOwner, transferred.Event.NewOwner, transferred.Event.Raw))
+		}
+		err = transferred.Error()
+		transferred.Close()
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(batch, func(i, j int) bool {
+			if batch[i].BlockNumber != batch[j].BlockNumber {
+				return batch[i].BlockNumber < batch[j].BlockNumber
+			}
+			return batch[i].LogIndex < batch[j].LogIndex
+		})
+		events = append(events, batch...)
+	}
+	return events, nil
+}
+
+func newEvent(kind EventKind, previous, next common.Address, l types.Log) Event {
+	return Event{Kind: kind, PreviousOwner: previous, NewOwner: next, BlockNumber: l.BlockNumber, TxHash: l.TxHash, LogIndex: l.Index}
+}
+
+// Stuck describes a transfer that was started but not accepted.
+type Stuck struct {
+	Contract     common.Address `json:"contract"`
+	Owner        common.Address `json:"owner"`
+	PendingOwner common.Address `json:"pendingOwner"`
+	// Since is the time of the block that started the transfer, or zero if
+	// the OwnershipTransferStarted event was not found after fromBlock.
+	Since time.Time `json:"since"`
+}
+
+func (s *Stuck) String() string {
+	if s.Since.IsZero() {
+		return fmt.Sprintf("%s: transfer from %s to %s is pending", s.Contract, s.Owner, s.PendingOwner)
+	}
+	return fmt.Sprintf("%s: transfer from %s to %s pending since %s", s.Contract, s.Owner, s.PendingOwner, s.Since.UTC().Format(time.RFC3339))
+}
+
+// FindStuck returns the contracts with a non-zero pending owner whose
+// transfer was started more than maxAge before the latest block, or whose
+// start could not be found after fromBlock. A maxAge of zero reports every
+// pending transfer.
+func FindStuck(ctx context.Context, backend Backend, contracts []common.Address, fromBlock uint64, maxAge time.Duration) ([]*Stuck, error) {
+	head, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Unix(int64(head.Time), 0)
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: head.Number}
+	var stuck []*Stuck
+	for _, contract := range contracts {
+		caller, err := gethwrappers.NewManyChainMultiSigCaller(contract, backend)
+		if err != nil {
+			return nil, err
+		}
+		s := &Stuck{Contract: contract}
+		if s.PendingOwner, err = caller.PendingOwner(opts); err != nil {
+			return nil, fmt.Errorf("pendingOwner of %s: %w", contract, err)
+		}
+		if s.PendingOwner == (common.Address{}) {
+			continue
+		}
+		if s.Owner, err = caller.Owner(opts); err != nil {
+			return nil, fmt.Errorf("owner of %s: %w", contract, err)
+		}
+		events, err := FetchEvents(ctx, backend, contract, fromBlock, 0)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(events) - 1; i >= 0; i-- {
+			if ev := events[i]; ev.Kind == EventStarted && ev.NewOwner == s.PendingOwner {
+				header, err := backend.HeaderByNumber(ctx, new(big.Int).SetUint64(ev.BlockNumber))
+				if err != nil {
+					return nil, err
+				}
+				s.Since = time.Unix(int64(header.Time), 0)
+				break
+			}
+		}
+		if s.Since.IsZero() || now.Sub(s.Since) >= maxAge {
+			stuck = append(stuck, s)
+		}
+	}
+	return stuck, nil
+}
@@ -0,0 +1,149 @@
+// Package ownership moves ownership of Ownable2Step contracts, such as the
+// ManyChainMultiSigs and the OWNED contracts of the README, to and from an
+// RBACTimelock.
+//
+// An Ownable2Step transfer takes two calls: transferOwnership from the
+// current owner, then acceptOwnership from the new owner. Whichever of the
+// two the timelock has to make must be scheduled through a proposer
+// ManyChainMultiSig and executed once the delay has passed; the other one is
+// an ordinary transaction.
+package ownership
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+// ownableABI holds the Ownable2Step functions and events, which
+// ManyChainMultiSig inherits unchanged.
+var ownableABI = mcms.ManyChainMultiSigABI
+
+// The Ownable2Step functions.
+const (
+	MethodTransferOwnership = "transferOwnership"
+	MethodAcceptOwnership   = "acceptOwnership"
+)
+
+// Step is one of the two calls of a transfer.
+type Step struct {
+	Method string         `json:"method"`
+	Sender common.Address `json:"sender"`
+	Call   timelock.Call  `json:"call"`
+	// ViaTimelock is set if Sender is the timelock, so that the call must be
+	// scheduled rather than sent.
+	ViaTimelock bool `json:"viaTimelock"`
+}
+
+// Plan lists the calls still needed to move ownership of Contract to
+// NewOwner. It is empty once NewOwner owns Contract.
+type Plan struct {
+	Contract     common.Address `json:"contract"`
+	Owner        common.Address `json:"owner"`
+	PendingOwner common.Address `json:"pendingOwner"`
+	NewOwner     common.Address `json:"newOwner"`
+	Steps        []Step         `json:"steps"`
+}
+
+// PlanTransfer reads the current owner and pending owner of contract and
+// plans the steps that remain to hand it to newOwner. Calls made by timelock
+// are marked ViaTimelock. A pending transfer to another account is
+// overridden by a new transferOwnership.
+func PlanTransfer(ctx context.Context, backend bind.ContractCaller, contract, newOwner, timelock common.Address) (*Plan, error) {
+	caller, err := gethwrappers.NewManyChainMultiSigCaller(contract, backend)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	p := &Plan{Contract: contract, NewOwner: newOwner}
+	if p.Owner, err = caller.Owner(opts); err != nil {
+		return nil, fmt.Errorf("owner of %s: %w", contract, err)
+	}
+	if p.PendingOwner, err = caller.PendingOwner(opts); err != nil {
+		return nil, fmt.Errorf("pendingOwner of %s: %w", contract, err)
+	}
+	if p.Owner == newOwner {
+		return p, nil
+	}
+	if p.PendingOwner != newOwner {
+		step, err := newStep(contract, MethodTransferOwnership, p.Owner, timelock, newOwner)
+		if err != nil {
+			return nil, err
+		}
+		p.Steps = append(p.Steps, step)
+	}
+	step, err := newStep(contract, MethodAcceptOwnership, newOwner, timelock)
+	if err != nil {
+		return nil, err
+	}
+	p.Steps = append(p.Steps, step)
+	return p, nil
+}
+
+func newStep(contract common.Address, method string, sender, timelockAddr common.Address, args ...interface{}) (Step, error) {
+	data, err := ownableABI.Pack(method, args...)
+	if err != nil {
+		return Step{}, err
+	}
+	return Step{
+		Method:      method,
+		Sender:      sender,
+		Call:        timelock.Call{Target: contract, Data: data},
+		ViaTimelock: sender == timelockAddr,
+	}, nil
+}
+
+// Next returns the first remaining step, or nil if the transfer is done.
+func (p *Plan) Next() *Step {
+	if len(p.Steps) == 0 {
+		return nil
+	}
+	return &p.Steps[0]
+}
+
+// Batch collects the calls the timelock has to make for plans into one
+// batch, or returns nil if it has none to make.
+func Batch(plans []*Plan, predecessor, salt common.Hash) *timelock.Batch {
+	b := &timelock.Batch{Predecessor: predecessor, Salt: salt}
+	for _, p := range plans {
+		for _, s := range p.Steps {
+			if s.ViaTimelock {
+				b.Calls = append(b.Calls, s.Call)
+			}
+		}
+	}
+	if len(b.Calls) == 0 {
+		return nil
+	}
+	return b
+}
+
+// ScheduleOp returns the ManyChainMultiSig op that schedules b on the
+// timelock with the given delay. The caller fills in ChainID and MultiSig.
+func ScheduleOp(timelockAddr common.Address, b *timelock.Batch, delay *big.Int) (mcms.Operation, error) {
+	data, err := timelock.RBACTimelockABI.Pack("scheduleBatch", b.RBACTimelockCalls(), b.Predecessor, b.Salt, delay)
+	if err != nil {
+		return mcms.Operation{}, err
+	}
+	return mcms.Operation{To: timelockAddr, Data: data}, nil
+}
+
+// Send sends a step that is not made through the timelock. opts.From must
+// be the step's sender.
+func Send(opts *bind.TransactOpts, backend bind.ContractTransactor, s *Step) (*types.Transaction, error) {
+	if s.ViaTimelock {
+		return nil, fmt.Errorf("%s on %s must be scheduled on the timelock", s.Method, s.Call.Target)
+	}
+	if opts.From != s.Sender {
+		return nil, fmt.Errorf("%s on %s must be sent by %s, not %s", s.Method, s.Call.Target, s.Sender, opts.From)
+	}
+	return bind.NewBoundContract(s.Call.Target, *ownableABI, nil, backend, nil).RawTransact(opts, s.Call.Data)
+}
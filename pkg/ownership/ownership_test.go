@@ -0,0 +1,118 @@
+package ownership_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/ownership"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+func TestTransferThroughTimelock(t *testing.T) {
+	ctx := context.Background()
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	// An OWNED contract, still owned by the deployer.
+	owned, _, _, err := gethwrappers.DeployManyChainMultiSig(e.Deployer.Opts, e.Backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Commit()
+
+	// Deployer -> timelock: transferOwnership is sent directly,
+	// acceptOwnership goes through the proposer and the timelock.
+	plan := transfer(t, e, owned, e.TimelockAddress, []bool{false, true})
+	e.Send(ownership.Send(e.Deployer.Opts, e.Backend, plan.Next()))
+	stuck, err := ownership.FindStuck(ctx, e.Backend, []common.Address{owned, e.Proposer.Address}, 0, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stuck) != 0 {
+		t.Fatalf("fresh transfer reported stuck: %v", stuck)
+	}
+	e.AdvanceTime(2 * time.Hour)
+	if stuck, err = ownership.FindStuck(ctx, e.Backend, []common.Address{owned, e.Proposer.Address}, 0, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if len(stuck) != 1 || stuck[0].Contract != owned || stuck[0].PendingOwner != e.TimelockAddress || stuck[0].Since.IsZero() {
+		t.Fatalf("stuck = %v", stuck)
+	}
+	plan = transfer(t, e, owned, e.TimelockAddress, []bool{true})
+	executeThroughTimelock(t, e, plan)
+	transfer(t, e, owned, e.TimelockAddress, nil)
+
+	// Timelock -> account: the timelock schedules transferOwnership, the new
+	// owner accepts directly.
+	newOwner := e.NewAccount()
+	plan = transfer(t, e, owned, newOwner.Address, []bool{true, false})
+	executeThroughTimelock(t, e, plan)
+	plan = transfer(t, e, owned, newOwner.Address, []bool{false})
+	if _, err := ownership.Send(e.Deployer.Opts, e.Backend, plan.Next()); err == nil {
+		t.Fatal("sending another account's step succeeded")
+	}
+	e.Send(ownership.Send(newOwner.Opts, e.Backend, plan.Next()))
+	transfer(t, e, owned, newOwner.Address, nil)
+
+	events, err := ownership.FetchEvents(ctx, e.Backend, owned, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		kind ownership.EventKind
+		to   common.Address
+	}{
+		{ownership.EventTransferred, e.Deployer.Address}, // constructor
+		{ownership.EventStarted, e.TimelockAddress},
+		{ownership.EventTransferred, e.TimelockAddress},
+		{ownership.EventStarted, newOwner.Address},
+		{ownership.EventTransferred, newOwner.Address},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v", events)
+	}
+	for i, ev := range events {
+		if ev.Kind != want[i].kind || ev.NewOwner != want[i].to {
+			t.Fatalf("events[%d] = %+v, want %s to %s", i, ev, want[i].kind, want[i].to)
+		}
+	}
+}
+
+// transfer plans the transfer of contract to newOwner and checks that the
+// remaining steps are made through the timelock as given.
+func transfer(t *testing.T, e *harness.Env, contract, newOwner common.Address, viaTimelock []bool) *ownership.Plan {
+	t.Helper()
+	plan, err := ownership.PlanTransfer(context.Background(), e.Backend, contract, newOwner, e.TimelockAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Steps) != len(viaTimelock) {
+		t.Fatalf("plan = %+v, want %d steps", plan, len(viaTimelock))
+	}
+	for i, s := range plan.Steps {
+		if s.ViaTimelock != viaTimelock[i] {
+			t.Fatalf("steps[%d] = %+v, want viaTimelock %t", i, s, viaTimelock[i])
+		}
+	}
+	return plan
+}
+
+// executeThroughTimelock schedules the timelock's calls of plan through the
+// proposer and executes them once ready.
+func executeThroughTimelock(t *testing.T, e *harness.Env, plan *ownership.Plan) {
+	t.Helper()
+	b := ownership.Batch([]*ownership.Plan{plan}, common.Hash{}, common.Hash{})
+	if b == nil || len(b.Calls) != 1 {
+		t.Fatalf("batch = %+v", b)
+	}
+	op, err := ownership.ScheduleOp(e.TimelockAddress, b, e.MinDelay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Submit(e.NewProposal(e.Proposer, op))
+	e.AdvanceTime(time.Hour)
+	e.Send(timelock.ExecuteBatch(e.Deployer.Opts, e.Backend, e.CallProxyAddress, b))
+}
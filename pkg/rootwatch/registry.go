@@ -0,0 +1,105 @@
+package rootwatch
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+// Approved is a root we built and reviewed, with the setRoot arguments it
+// may be set with on each chain.
+type Approved struct {
+	Root       common.Hash
+	ValidUntil uint32
+	Metadata   []gethwrappers.ManyChainMultiSigRootMetadata
+	// Source is the proposal file the root came from, if any.
+	Source string
+}
+
+// Registry holds the approved roots. It is safe for concurrent use.
+type Registry struct {
+	paths []string
+
+	mu     sync.RWMutex
+	byRoot map[common.Hash]*Approved
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{byRoot: make(map[common.Hash]*Approved)}
+}
+
+// LoadRegistry reads the approved proposals at paths. A path is a proposal
+// file or a directory whose *.json files are proposals. It fails if a path
+// cannot be read; like Reload, it skips the files it cannot load and returns
+// their errors together with a registry of the others.
+func LoadRegistry(paths ...string) (*Registry, error) {
+	if _, err := mcms.ProposalFiles(paths...); err != nil {
+		return nil, err
+	}
+	r := NewRegistry()
+	r.paths = paths
+	return r, r.Reload()
+}
+
+// Reload reads the paths the registry was loaded from again, so that
+// proposals approved since are known. Proposals added with Add are kept. A
+// file that cannot be loaded is skipped, so that one bad file does not hide
+// the approvals of the others; the errors of all skipped files are returned
+// together.
+func (r *Registry) Reload() error {
+	files, err := mcms.ProposalFiles(r.paths...)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, file := range files {
+		if err := r.load(file); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Registry) load(file string) error {
+	p, err := mcms.LoadProposal(file)
+	if err != nil {
+		return err
+	}
+	return r.Add(p, file)
+}
+
+// Add approves the root of p.
+func (r *Registry) Add(p *mcms.Proposal, source string) error {
+	root, err := p.Root()
+	if err != nil {
+		return err
+	}
+	a := &Approved{Root: root, ValidUntil: p.ValidUntil, Source: source}
+	for i := range p.Chains {
+		a.Metadata = append(a.Metadata, p.RootMetadata(i))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byRoot[root] = a
+	return nil
+}
+
+// Lookup returns the approval of root, or nil.
+func (r *Registry) Lookup(root common.Hash) *Approved {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.byRoot[root]
+}
+
+// Len returns the number of approved roots.
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.byRoot)
+}
@@ -0,0 +1,208 @@
+// Package rootwatch raises alerts for ManyChainMultiSig roots nobody
+// approved.
+//
+// Every NewRoot event is matched against a Registry of the proposals that
+// were built and reviewed. A root that is not in the registry, or that was
+// set with another validUntil or metadata than approved, is critical: it
+// means a quorum of signers signed something outside the review process.
+// Roots that override a previous root, and roots valid for unusually long,
+// are worth a look even when approved.
+package rootwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/alert"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/follow"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+)
+
+// The alert kinds raised by this package.
+const (
+	KindUnknownRoot   = "unknown-root"
+	KindRootMismatch  = "root-mismatch"
+	KindOverride      = "override-previous-root"
+	KindLongValidity  = "long-valid-until"
+	KindRootRetracted = "root-retracted"
+)
+
+// DefaultMaxValidity is the default validUntil horizon above which a root is
+// reported.
+const DefaultMaxValidity = 7 * 24 * time.Hour
+
+// RootDetails is the Details of the alerts raised for a NewRoot event.
+type RootDetails struct {
+	Root       common.Hash                                `json:"root"`
+	ValidUntil uint32                                     `json:"validUntil"`
+	Metadata   gethwrappers.ManyChainMultiSigRootMetadata `json:"metadata"`
+	// Source is the approved proposal file, if the root is known.
+	Source string `json:"source,omitempty"`
+}
+
+// Check returns the alerts raised by a NewRoot event on chainID emitted in a
+// block with time blockTime. A maxValidity of zero disables the validUntil
+// check.
+func Check(registry *Registry, chainID *big.Int, ev *gethwrappers.ManyChainMultiSigNewRoot, blockTime uint64, maxValidity time.Duration) []*alert.Alert {
+	details := &RootDetails{Root: ev.Root, ValidUntil: ev.ValidUntil, Metadata: ev.Metadata}
+	newAlert := func(severity alert.Severity, kind, format string, args ...interface{}) *alert.Alert {
+		return &alert.Alert{
+			Time:        time.Now(),
+			Severity:    severity,
+			Kind:        kind,
+			ChainID:     chainID,
+			Contract:    ev.Raw.Address,
+			BlockNumber: ev.Raw.BlockNumber,
+			TxHash:      ev.Raw.TxHash,
+			Message:     fmt.Sprintf(format, args...),
+			Details:     details,
+		}
+	}
+
+	var alerts []*alert.Alert
+	root := common.Hash(ev.Root)
+	if approved := registry.Lookup(root); approved == nil {
+		alerts = append(alerts, newAlert(alert.SeverityCritical, KindUnknownRoot, "root %s is not in the registry of approved proposals", root))
+	} else {
+		details.Source = approved.Source
+		if problem := mismatch(approved, ev); problem != "" {
+			alerts = append(alerts, newAlert(alert.SeverityCritical, KindRootMismatch, "root %s was approved in %s, but %s", root, approved.Source, problem))
+		}
+	}
+	if ev.Metadata.OverridePreviousRoot {
+		alerts = append(alerts, newAlert(alert.SeverityWarning, KindOverride, "root %s overrides the previous root", root))
+	}
+	if validFor := time.Duration(int64(ev.ValidUntil)-int64(blockTime)) * time.Second; maxValidity > 0 && validFor > maxValidity {
+		alerts = append(alerts, newAlert(alert.SeverityWarning, KindLongValidity, "root %s is valid for %s, more than %s", root, validFor, maxValidity))
+	}
+	return alerts
+}
+
+// mismatch describes how ev differs from the approval of its root, or
+// returns "".
+func mismatch(approved *Approved, ev *gethwrappers.ManyChainMultiSigNewRoot) string {
+	if ev.ValidUntil != approved.ValidUntil {
+		return fmt.Sprintf("set with validUntil %d instead of %d", ev.ValidUntil, approved.ValidUntil)
+	}
+	for _, md := range approved.Metadata {
+		if md.ChainId.Cmp(ev.Metadata.ChainId) != 0 || md.MultiSig != ev.Metadata.MultiSig {
+			continue
+		}
+		if md.PreOpCount.Cmp(ev.Metadata.PreOpCount) != 0 || md.PostOpCount.Cmp(ev.Metadata.PostOpCount) != 0 || md.OverridePreviousRoot != ev.Metadata.OverridePreviousRoot {
+			return fmt.Sprintf("set with metadata %+v instead of %+v", ev.Metadata, md)
+		}
+		return ""
+	}
+	return fmt.Sprintf("not for %s on chain %v", ev.Metadata.MultiSig, ev.Metadata.ChainId)
+}
+
+// Monitor follows the NewRoot events of the ManyChainMultiSigs of several
+// chains and sends the alerts of Check to a sink. The follow.Chain
+// Confirmations trade alerting speed for noise: roots reorged out after an
+// alert are reported as retracted.
+type Monitor struct {
+	registry    *Registry
+	sink        alert.Sink
+	maxValidity time.Duration
+	watch       *follow.Watch
+
+	// sent, reloadErr and partialErr are collected during Poll.
+	sent       int
+	reloadErr  error
+	partialErr error
+}
+
+// NewMonitor returns a monitor of the ManyChainMultiSigs listed as the
+// Contracts of chains. A maxValidity of zero disables the validUntil check.
+func NewMonitor(registry *Registry, sink alert.Sink, maxValidity time.Duration, chains []follow.Chain) (*Monitor, error) {
+	topics, err := follow.EventTopics(mcms.ManyChainMultiSigABI, "NewRoot")
+	if err != nil {
+		return nil, err
+	}
+	m := &Monitor{registry: registry, sink: sink, maxValidity: maxValidity}
+	m.watch = follow.NewWatch(topics, chains, m.handle)
+	return m, nil
+}
+
+// Poll checks the new roots of every chain once and returns the number of
+// alerts sent. A chain whose node fails, or whose alerts are not all
+// delivered, is retried from its first unchecked root on the next poll, so
+// alerts are sent at least once. An alert that reached some of the sinks of
+// an alert.Multi counts as sent. The errors of all chains, of reloading the
+// registry and of partial deliveries are returned together.
+func (m *Monitor) Poll(ctx context.Context) (int, error) {
+	m.sent, m.reloadErr, m.partialErr = 0, nil, nil
+	err := m.watch.Poll(ctx)
+	return m.sent, errors.Join(err, m.reloadErr, m.partialErr)
+}
+
+// handle is the follow.Handler of the monitor.
+func (m *Monitor) handle(ctx context.Context, c *follow.Chain, logs []types.Log) (int, error) {
+	for i, l := range logs {
+		alerts, err := m.check(ctx, c, l)
+		if err != nil {
+			return i, err
+		}
+		for _, a := range alerts {
+			var partial *alert.PartialError
+			if err := m.sink.Send(ctx, a); errors.As(err, &partial) {
+				m.partialErr = errors.Join(m.partialErr, err)
+			} else if err != nil {
+				return i, err
+			}
+			m.sent++
+		}
+	}
+	return len(logs), nil
+}
+
+func (m *Monitor) check(ctx context.Context, c *follow.Chain, l types.Log) ([]*alert.Alert, error) {
+	filterer, err := gethwrappers.NewManyChainMultiSigFilterer(l.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	ev, err := filterer.ParseNewRoot(l)
+	if err != nil {
+		return nil, err
+	}
+	if l.Removed {
+		return []*alert.Alert{{
+			Time:        time.Now(),
+			Severity:    alert.SeverityInfo,
+			Kind:        KindRootRetracted,
+			ChainID:     c.ChainID,
+			Contract:    l.Address,
+			BlockNumber: l.BlockNumber,
+			TxHash:      l.TxHash,
+			Message:     fmt.Sprintf("root %s was reorged out", common.Hash(ev.Root)),
+		}}, nil
+	}
+	header, err := c.Backend.HeaderByNumber(ctx, new(big.Int).SetUint64(l.BlockNumber))
+	if err != nil {
+		return nil, err
+	}
+	if m.registry.Lookup(ev.Root) == nil && m.registry.paths != nil {
+		// The proposal may have been approved after the registry was
+		// loaded. Files that fail to load do not hold up the check.
+		if err := m.registry.Reload(); err != nil {
+			m.reloadErr = errors.Join(m.reloadErr, err)
+		}
+	}
+	return Check(m.registry, c.ChainID, ev, header.Time, m.maxValidity), nil
+}
+
+// Run polls every interval until ctx is done, passing the errors of Poll to
+// onError, which may be nil.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	return follow.PollEvery(ctx, interval, func(ctx context.Context) error {
+		_, err := m.Poll(ctx)
+		return err
+	}, onError)
+}
@@ -0,0 +1,241 @@
+package rootwatch_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/alert"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/follow"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/rootwatch"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+var errDown = errors.New("down")
+
+type recorder struct {
+	mu     sync.Mutex
+	alerts []*alert.Alert
+	// down fails every Send while set.
+	down bool
+}
+
+func (r *recorder) Send(_ context.Context, a *alert.Alert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.down {
+		return errDown
+	}
+	r.alerts = append(r.alerts, a)
+	return nil
+}
+
+func (r *recorder) kinds() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kinds := make([]string, len(r.alerts))
+	for i, a := range r.alerts {
+		kinds[i] = a.Kind
+	}
+	r.alerts = nil
+	return kinds
+}
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	dir := t.TempDir()
+	registry, err := rootwatch.LoadRegistry(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &recorder{}
+	m, err := rootwatch.NewMonitor(registry, sink, rootwatch.DefaultMaxValidity, []follow.Chain{{
+		ChainID:   harness.ChainID,
+		Backend:   e.Backend,
+		Contracts: []common.Address{e.Proposer.Address},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An approved proposal, saved to the registry directory after the
+	// monitor started.
+	approved := e.NewProposal(e.Proposer, e.ScheduleOp(&timelock.Batch{Calls: []timelock.Call{{Target: e.TimelockAddress}}}))
+	if err := approved.Save(filepath.Join(dir, "approved.json")); err != nil {
+		t.Fatal(err)
+	}
+	e.Submit(approved)
+	if _, err := m.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if kinds := sink.kinds(); len(kinds) != 0 {
+		t.Fatalf("alerts for an approved root: %v", kinds)
+	}
+
+	// A root signed outside the registry.
+	e.SetRoot(e.NewProposal(e.Proposer, e.ScheduleOp(&timelock.Batch{Calls: []timelock.Call{{Target: e.TimelockAddress}}, Salt: common.Hash{1}})))
+	if _, err := m.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if kinds := sink.kinds(); len(kinds) != 1 || kinds[0] != rootwatch.KindUnknownRoot {
+		t.Fatalf("alerts = %v, want %s", kinds, rootwatch.KindUnknownRoot)
+	}
+}
+
+// flakyBackend fails every request while down is set.
+type flakyBackend struct {
+	follow.Backend
+	down bool
+}
+
+func (b *flakyBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if b.down {
+		return nil, errDown
+	}
+	return b.Backend.HeaderByNumber(ctx, number)
+}
+
+func TestMonitorFailures(t *testing.T) {
+	ctx := context.Background()
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	dir := t.TempDir()
+	// A path that cannot be read is fatal, unlike a file that cannot be
+	// loaded.
+	if _, err := rootwatch.LoadRegistry(filepath.Join(dir, "missing")); err == nil {
+		t.Fatal("LoadRegistry of a missing directory succeeded")
+	}
+	// A malformed file does not keep the others from being approved.
+	if err := os.WriteFile(filepath.Join(dir, "bad.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	approved := e.NewProposal(e.Proposer, e.ScheduleOp(&timelock.Batch{Calls: []timelock.Call{{Target: e.TimelockAddress}}}))
+	if err := approved.Save(filepath.Join(dir, "approved.json")); err != nil {
+		t.Fatal(err)
+	}
+	registry, err := rootwatch.LoadRegistry(dir)
+	if err == nil || registry.Len() != 1 {
+		t.Fatalf("LoadRegistry: %d roots, err %v", registry.Len(), err)
+	}
+
+	// Both chains watch the same multisig; only the second one fails.
+	sink := &recorder{}
+	flaky := &flakyBackend{Backend: e.Backend}
+	m, err := rootwatch.NewMonitor(registry, sink, rootwatch.DefaultMaxValidity, []follow.Chain{
+		{ChainID: big.NewInt(1), Backend: e.Backend, Contracts: []common.Address{e.Proposer.Address}},
+		{ChainID: big.NewInt(2), Backend: flaky, Contracts: []common.Address{e.Proposer.Address}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.SetRoot(e.NewProposal(e.Proposer, e.ScheduleOp(&timelock.Batch{Calls: []timelock.Call{{Target: e.TimelockAddress}}, Salt: common.Hash{1}})))
+
+	flaky.down = true
+	if _, err := m.Poll(ctx); !errors.Is(err, errDown) {
+		t.Fatalf("Poll with a failing chain: got %v", err)
+	}
+	if len(sink.alerts) != 1 || sink.alerts[0].ChainID.Int64() != 1 {
+		t.Fatalf("alerts = %v, want the alert of chain 1", sink.alerts)
+	}
+	sink.alerts = nil
+
+	// The chain recovers while the sink fails: the alert is kept until it
+	// is delivered.
+	flaky.down, sink.down = false, true
+	if _, err := m.Poll(ctx); !errors.Is(err, errDown) {
+		t.Fatalf("Poll with a failing sink: got %v", err)
+	}
+	e.Commit()
+	if _, err := m.Poll(ctx); !errors.Is(err, errDown) {
+		t.Fatalf("Poll with a failing sink: got %v", err)
+	}
+	// Only the malformed file, re-read for the unknown root, is reported.
+	sink.down = false
+	if n, err := m.Poll(ctx); n != 1 || err == nil || !strings.Contains(err.Error(), "bad.json") || errors.Is(err, errDown) {
+		t.Fatalf("Poll after recovery: %d alerts, err %v", n, err)
+	}
+	if len(sink.alerts) != 1 || sink.alerts[0].ChainID.Int64() != 2 || sink.alerts[0].Kind != rootwatch.KindUnknownRoot {
+		t.Fatalf("alerts = %v, want the unknown root alert of chain 2", sink.alerts)
+	}
+}
+
+func TestMonitorPartialDelivery(t *testing.T) {
+	ctx := context.Background()
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	up, down := &recorder{}, &recorder{down: true}
+	m, err := rootwatch.NewMonitor(rootwatch.NewRegistry(), alert.Multi{up, down}, rootwatch.DefaultMaxValidity, []follow.Chain{{
+		ChainID:   harness.ChainID,
+		Backend:   e.Backend,
+		Contracts: []common.Address{e.Proposer.Address},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.SetRoot(e.NewProposal(e.Proposer, e.ScheduleOp(&timelock.Batch{Calls: []timelock.Call{{Target: e.TimelockAddress}}})))
+
+	// The alert reached one of the sinks: it is reported, not sent again.
+	if n, err := m.Poll(ctx); n != 1 || !errors.Is(err, errDown) {
+		t.Fatalf("Poll = %d, %v; want 1 alert and the failing sink's error", n, err)
+	}
+	if n, err := m.Poll(ctx); n != 0 || err != nil {
+		t.Fatalf("Poll again = %d, %v", n, err)
+	}
+	if kinds := up.kinds(); len(kinds) != 1 {
+		t.Fatalf("alerts = %v, want one", kinds)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	p := e.NewProposal(e.Proposer, e.TimelockOp("updateDelay", big.NewInt(7200)))
+	registry := rootwatch.NewRegistry()
+	if err := registry.Add(p, "p.json"); err != nil {
+		t.Fatal(err)
+	}
+	root, err := p.Root()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := uint64(e.Now().Unix())
+	newRoot := func(validUntil uint32, metadata gethwrappers.ManyChainMultiSigRootMetadata) *gethwrappers.ManyChainMultiSigNewRoot {
+		return &gethwrappers.ManyChainMultiSigNewRoot{Root: root, ValidUntil: validUntil, Metadata: metadata}
+	}
+	override := p.RootMetadata(0)
+	override.OverridePreviousRoot = true
+	otherChain := p.RootMetadata(0)
+	otherChain.ChainId = big.NewInt(5)
+
+	for _, tc := range []struct {
+		name string
+		ev   *gethwrappers.ManyChainMultiSigNewRoot
+		want []string
+	}{
+		{"approved", newRoot(p.ValidUntil, p.RootMetadata(0)), nil},
+		{"other validUntil", newRoot(p.ValidUntil+1, p.RootMetadata(0)), []string{rootwatch.KindRootMismatch}},
+		{"other chain", newRoot(p.ValidUntil, otherChain), []string{rootwatch.KindRootMismatch}},
+		{"override", newRoot(p.ValidUntil, override), []string{rootwatch.KindRootMismatch, rootwatch.KindOverride}},
+		{"long validity", newRoot(uint32(now)+30*24*3600, p.RootMetadata(0)), []string{rootwatch.KindRootMismatch, rootwatch.KindLongValidity}},
+	} {
+		alerts := rootwatch.Check(registry, harness.ChainID, tc.ev, now, rootwatch.DefaultMaxValidity)
+		if len(alerts) != len(tc.want) {
+			t.Errorf("%s: alerts = %v, want %v", tc.name, alerts, tc.want)
+			continue
+		}
+		for i, a := range alerts {
+			if a.Kind != tc.want[i] {
+				t.Errorf("%s: alerts = %v, want %v", tc.name, alerts, tc.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package schedwatch
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+// Proposals links timelock operations to the proposals that schedule them.
+// It is safe for concurrent use.
+type Proposals struct {
+	paths    []string
+	linkBase string
+
+	mu   sync.RWMutex
+	byOp map[common.Hash]string
+}
+
+// NewProposals returns an empty index.
+func NewProposals() *Proposals {
+	return &Proposals{byOp: make(map[common.Hash]string)}
+}
+
+// LoadProposals indexes the proposal files at paths, each a file or a
+// directory of *.json files. A proposal is linked as linkBase followed by
+// its file name, e.g. a repository URL, or by its path if linkBase is empty.
+// It fails if a path cannot be read; like Reload, it skips the files it
+// cannot load and returns their errors together with an index of the others.
+func LoadProposals(linkBase string, paths ...string) (*Proposals, error) {
+	if _, err := mcms.ProposalFiles(paths...); err != nil {
+		return nil, err
+	}
+	p := NewProposals()
+	p.paths, p.linkBase = paths, linkBase
+	return p, p.Reload()
+}
+
+// Reload reads the paths the index was loaded from again. A file that
+// cannot be loaded is skipped; the errors of all skipped files are returned
+// together.
+func (p *Proposals) Reload() error {
+	files, err := mcms.ProposalFiles(p.paths...)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, file := range files {
+		if err := p.load(file); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Proposals) load(file string) error {
+	proposal, err := mcms.LoadProposal(file)
+	if err != nil {
+		return err
+	}
+	link := file
+	if p.linkBase != "" {
+		link = strings.TrimSuffix(p.linkBase, "/") + "/" + filepath.Base(file)
+	}
+	return p.Add(proposal, link)
+}
+
+// Add links the operations scheduled by the scheduleBatch ops of proposal
+// to link. Other ops are ignored.
+func (p *Proposals) Add(proposal *mcms.Proposal, link string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, op := range proposal.Ops {
+		b, _, err := timelock.DecodeScheduleBatch(op.Data)
+		if err != nil {
+			continue
+		}
+		id, err := b.ID()
+		if err != nil {
+			return err
+		}
+		p.byOp[id] = link
+	}
+	return nil
+}
+
+// Link returns the link of the proposal scheduling operation id, or "".
+func (p *Proposals) Link(id common.Hash) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.byOp[id]
+}
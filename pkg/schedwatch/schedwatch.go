@@ -0,0 +1,245 @@
+// Package schedwatch announces the operations scheduled on RBACTimelocks,
+// so that people can review them while the delay runs.
+//
+// Every CallScheduled event is decoded with a CallDecoder; the calls of an
+// operation are collected into one notification with the time the
+// operation becomes executable and, when a proposal scheduling it is known,
+// a link to that proposal. Notifications are alerts, so they go wherever
+// the other monitors send theirs; webhook payloads carry a Slack-compatible
+// "text" field.
+package schedwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/alert"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/follow"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+// The alert kinds raised by this package.
+const (
+	KindCallScheduled = "call-scheduled"
+	KindCallRetracted = "call-scheduled-retracted"
+)
+
+// Call is a decoded call of a scheduled operation.
+type Call struct {
+	Index       uint64         `json:"index"`
+	Target      common.Address `json:"target"`
+	Value       *big.Int       `json:"value"`
+	Data        hexutil.Bytes  `json:"data"`
+	Description string         `json:"description"`
+}
+
+// Scheduled is a scheduled operation, the Details of its notification.
+type Scheduled struct {
+	ChainID     *big.Int       `json:"chainId"`
+	Timelock    common.Address `json:"timelock"`
+	ID          common.Hash    `json:"id"`
+	Predecessor common.Hash    `json:"predecessor"`
+	Salt        common.Hash    `json:"salt"`
+	Delay       *big.Int       `json:"delay"`
+	ScheduledAt time.Time      `json:"scheduledAt"`
+	// ETA is when the operation becomes executable: the time of the
+	// scheduling block plus the delay.
+	ETA         time.Time   `json:"eta"`
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      common.Hash `json:"txHash"`
+	Calls       []Call      `json:"calls"`
+	// Proposal links to the proposal that scheduled the operation, if known.
+	Proposal string `json:"proposal,omitempty"`
+}
+
+// Text renders s for humans.
+func (s *Scheduled) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "operation %s scheduled with %d calls, executable from %s (delay %s)",
+		s.ID, len(s.Calls), s.ETA.UTC().Format(time.RFC3339), time.Duration(s.Delay.Int64())*time.Second)
+	if s.Proposal != "" {
+		fmt.Fprintf(&b, "\nproposal: %s", s.Proposal)
+	} else {
+		b.WriteString("\nproposal: unknown")
+	}
+	for _, c := range s.Calls {
+		fmt.Fprintf(&b, "\n%d. %s", c.Index, c.Target)
+		if c.Value != nil && c.Value.Sign() != 0 {
+			fmt.Fprintf(&b, " value %v", c.Value)
+		}
+		fmt.Fprintf(&b, ": %s", c.Description)
+	}
+	return b.String()
+}
+
+// Watchdog follows the CallScheduled events of the RBACTimelocks of several
+// chains and sends a notification per scheduled operation.
+type Watchdog struct {
+	decoder   *mcms.CallDecoder
+	proposals *Proposals
+	sink      alert.Sink
+	watch     *follow.Watch
+
+	// sent, reloadErr and partialErr are collected during Poll.
+	sent       int
+	reloadErr  error
+	partialErr error
+}
+
+// New returns a watchdog of the RBACTimelocks listed as the Contracts of
+// chains. proposals may be nil.
+func New(decoder *mcms.CallDecoder, proposals *Proposals, sink alert.Sink, chains []follow.Chain) (*Watchdog, error) {
+	topics, err := follow.EventTopics(timelock.RBACTimelockABI, "CallScheduled")
+	if err != nil {
+		return nil, err
+	}
+	if proposals == nil {
+		proposals = NewProposals()
+	}
+	w := &Watchdog{decoder: decoder, proposals: proposals, sink: sink}
+	w.watch = follow.NewWatch(topics, chains, w.handle)
+	return w, nil
+}
+
+// Poll announces the operations scheduled on every chain since the last
+// call and returns the number of notifications sent. A chain whose node
+// fails, or whose notifications are not all delivered, is retried from its
+// first unannounced operation on the next poll, so notifications are sent at
+// least once. A notification that reached some of the sinks of an
+// alert.Multi counts as sent. The errors of all chains, of reloading the
+// proposals and of partial deliveries are returned together.
+func (w *Watchdog) Poll(ctx context.Context) (int, error) {
+	w.sent, w.reloadErr, w.partialErr = 0, nil, nil
+	err := w.watch.Poll(ctx)
+	return w.sent, errors.Join(err, w.reloadErr, w.partialErr)
+}
+
+// handle is the follow.Handler of the watchdog.
+func (w *Watchdog) handle(ctx context.Context, c *follow.Chain, logs []types.Log) (int, error) {
+	ops, err := w.collect(ctx, c, logs)
+	if err != nil {
+		return 0, err
+	}
+	handled := 0
+	for _, op := range ops {
+		var partial *alert.PartialError
+		if err := w.sink.Send(ctx, op.alert); errors.As(err, &partial) {
+			w.partialErr = errors.Join(w.partialErr, err)
+		} else if err != nil {
+			return handled, err
+		}
+		w.sent++
+		handled = op.end
+	}
+	return handled, nil
+}
+
+// collectedOp is the notification of an operation and the index after its
+// last log.
+type collectedOp struct {
+	alert *alert.Alert
+	end   int
+}
+
+// collect groups logs into operations. The CallScheduled events of one
+// operation are emitted by one transaction, so they are adjacent.
+func (w *Watchdog) collect(ctx context.Context, c *follow.Chain, logs []types.Log) ([]collectedOp, error) {
+	var ops []collectedOp
+	var current *Scheduled
+	var removed bool
+	flush := func(end int) {
+		if current == nil {
+			return
+		}
+		a := &alert.Alert{
+			Time:        time.Now(),
+			Severity:    alert.SeverityInfo,
+			Kind:        KindCallScheduled,
+			ChainID:     current.ChainID,
+			Contract:    current.Timelock,
+			BlockNumber: current.BlockNumber,
+			TxHash:      current.TxHash,
+			Message:     current.Text(),
+			Details:     current,
+		}
+		if removed {
+			a.Kind = KindCallRetracted
+			a.Message = fmt.Sprintf("scheduling of operation %s was reorged out", current.ID)
+		}
+		ops = append(ops, collectedOp{alert: a, end: end})
+		current = nil
+	}
+	for i, l := range logs {
+		filterer, err := gethwrappers.NewRBACTimelockFilterer(l.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+		ev, err := filterer.ParseCallScheduled(l)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil || current.ID != ev.Id || current.TxHash != l.TxHash || current.Timelock != l.Address || removed != l.Removed {
+			flush(i)
+			header, err := c.Backend.HeaderByNumber(ctx, new(big.Int).SetUint64(l.BlockNumber))
+			if err != nil {
+				return nil, err
+			}
+			scheduledAt := time.Unix(int64(header.Time), 0)
+			current = &Scheduled{
+				ChainID:     c.ChainID,
+				Timelock:    l.Address,
+				ID:          ev.Id,
+				Predecessor: ev.Predecessor,
+				Salt:        ev.Salt,
+				Delay:       ev.Delay,
+				ScheduledAt: scheduledAt,
+				ETA:         scheduledAt.Add(time.Duration(ev.Delay.Int64()) * time.Second),
+				BlockNumber: l.BlockNumber,
+				TxHash:      l.TxHash,
+			}
+			current.Proposal = w.link(ev.Id)
+			removed = l.Removed
+		}
+		current.Calls = append(current.Calls, Call{
+			Index:       ev.Index.Uint64(),
+			Target:      ev.Target,
+			Value:       ev.Value,
+			Data:        ev.Data,
+			Description: w.decoder.Describe(ev.Data),
+		})
+	}
+	flush(len(logs))
+	return ops, nil
+}
+
+// link returns the link of the proposal scheduling id, reloading the
+// proposals once if it is not known yet. Files that fail to load do not
+// hold up the notification.
+func (w *Watchdog) link(id common.Hash) string {
+	if link := w.proposals.Link(id); link != "" || w.proposals.paths == nil {
+		return link
+	}
+	if err := w.proposals.Reload(); err != nil {
+		w.reloadErr = errors.Join(w.reloadErr, err)
+	}
+	return w.proposals.Link(id)
+}
+
+// Run polls every interval until ctx is done, passing the errors of Poll to
+// onError, which may be nil.
+func (w *Watchdog) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	return follow.PollEvery(ctx, interval, func(ctx context.Context) error {
+		_, err := w.Poll(ctx)
+		return err
+	}, onError)
+}
@@ -0,0 +1,232 @@
+package schedwatch_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/alert"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/follow"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/schedwatch"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+const tokenABI = `{"abi": [{"type": "function", "name": "transfer", "stateMutability": "nonpayable",
+	"inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}],
+	"outputs": [{"name": "", "type": "bool"}]}]}`
+
+// payload is what the webhook stub receives.
+type payload struct {
+	Text    string               `json:"text"`
+	Kind    string               `json:"kind"`
+	Details schedwatch.Scheduled `json:"details"`
+}
+
+func TestWatchdog(t *testing.T) {
+	ctx := context.Background()
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	dir := t.TempDir()
+	abiPath := filepath.Join(dir, "Token.json")
+	if err := os.WriteFile(abiPath, []byte(tokenABI), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	token, err := mcms.LoadABI(abiPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposalsDir := filepath.Join(dir, "proposals")
+	if err := os.Mkdir(proposalsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	proposals, err := schedwatch.LoadProposals("https://example.com/proposals/", proposalsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var received []payload
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p payload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		received = append(received, p)
+		mu.Unlock()
+	}))
+	defer stub.Close()
+	w, err := schedwatch.New(mcms.NewCallDecoder(token), proposals, alert.NewWebhook(stub.URL), []follow.Chain{{
+		ChainID:   harness.ChainID,
+		Backend:   e.Backend,
+		Contracts: []common.Address{e.TimelockAddress},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updateDelay, err := timelock.RBACTimelockABI.Pack("updateDelay", big.NewInt(7200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipient := common.Address{0xaa}
+	transfer, err := token.Pack("transfer", recipient, big.NewInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &timelock.Batch{Calls: []timelock.Call{
+		{Target: e.TimelockAddress, Data: updateDelay},
+		{Target: common.Address{0xbb}, Data: transfer},
+	}}
+	p := e.NewProposal(e.Proposer, e.ScheduleOp(b))
+	// The proposal is saved after the watchdog started.
+	if err := p.Save(filepath.Join(proposalsDir, "delay.json")); err != nil {
+		t.Fatal(err)
+	}
+	e.Submit(p)
+	e.Submit(e.NewProposal(e.Proposer, e.ScheduleOp(&timelock.Batch{Calls: b.Calls[:1], Salt: common.Hash{1}})))
+
+	if n, err := w.Poll(ctx); err != nil || n != 2 {
+		t.Fatalf("Poll = %d, %v; want 2 notifications", n, err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 2 {
+		t.Fatalf("stub received %d notifications", len(received))
+	}
+	first := received[0]
+	id, err := b.ID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Kind != schedwatch.KindCallScheduled || first.Details.ID != id || len(first.Details.Calls) != 2 {
+		t.Fatalf("first notification = %+v", first)
+	}
+	if eta := first.Details.ScheduledAt.Add(time.Hour); !first.Details.ETA.Equal(eta) {
+		t.Fatalf("ETA %s, want %s", first.Details.ETA, eta)
+	}
+	for _, want := range []string{
+		"proposal: https://example.com/proposals/delay.json",
+		"updateDelay(newDelay=7200)",
+		"transfer(to=" + recipient.Hex() + ", amount=5)",
+		first.Details.ETA.UTC().Format(time.RFC3339),
+	} {
+		if !strings.Contains(first.Text, want) {
+			t.Errorf("text does not contain %q:\n%s", want, first.Text)
+		}
+	}
+	if !strings.Contains(received[1].Text, "proposal: unknown") {
+		t.Errorf("second notification links a proposal:\n%s", received[1].Text)
+	}
+}
+
+var errDown = errors.New("down")
+
+// flakySink records notifications and fails the Send calls listed in fail,
+// counted from 1.
+type flakySink struct {
+	calls int
+	fail  map[int]bool
+	ids   []common.Hash
+}
+
+func (s *flakySink) Send(_ context.Context, a *alert.Alert) error {
+	s.calls++
+	if s.fail[s.calls] {
+		return errDown
+	}
+	s.ids = append(s.ids, a.Details.(*schedwatch.Scheduled).ID)
+	return nil
+}
+
+// flakyBackend fails every request while down is set.
+type flakyBackend struct {
+	follow.Backend
+	down bool
+}
+
+func (b *flakyBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if b.down {
+		return nil, errDown
+	}
+	return b.Backend.HeaderByNumber(ctx, number)
+}
+
+func TestWatchdogFailures(t *testing.T) {
+	ctx := context.Background()
+	e := harness.New(t, harness.Options{MinDelay: time.Hour})
+	if _, err := schedwatch.LoadProposals("", filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("LoadProposals of a missing directory succeeded")
+	}
+	var ids []common.Hash
+	for i := byte(0); i < 2; i++ {
+		b := &timelock.Batch{Calls: []timelock.Call{{Target: e.TimelockAddress}}, Salt: common.Hash{i}}
+		id, err := b.ID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+		e.Submit(e.NewProposal(e.Proposer, e.ScheduleOp(b)))
+	}
+
+	// Both chains watch the same timelock. The first chain's second
+	// notification fails to send, the second chain's node is down.
+	sink := &flakySink{fail: map[int]bool{2: true}}
+	flaky := &flakyBackend{Backend: e.Backend, down: true}
+	w, err := schedwatch.New(mcms.NewCallDecoder(), nil, sink, []follow.Chain{
+		{ChainID: big.NewInt(1), Backend: e.Backend, Contracts: []common.Address{e.TimelockAddress}},
+		{ChainID: big.NewInt(2), Backend: flaky, Contracts: []common.Address{e.TimelockAddress}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := w.Poll(ctx); n != 1 || !errors.Is(err, errDown) {
+		t.Fatalf("Poll = %d, %v; want 1 notification and an error", n, err)
+	}
+
+	// The undelivered notification is sent again, the delivered one is not.
+	flaky.down = false
+	if n, err := w.Poll(ctx); n != 3 || err != nil {
+		t.Fatalf("Poll = %d, %v; want 3 notifications", n, err)
+	}
+	want := []common.Hash{ids[0], ids[1], ids[0], ids[1]}
+	if len(sink.ids) != len(want) {
+		t.Fatalf("notified %v, want %v", sink.ids, want)
+	}
+	for i := range want {
+		if sink.ids[i] != want[i] {
+			t.Fatalf("notified %v, want %v", sink.ids, want)
+		}
+	}
+
+	// A notification that reached one of the sinks counts as sent.
+	up, down := &flakySink{}, &flakySink{fail: map[int]bool{1: true, 2: true}}
+	w, err = schedwatch.New(mcms.NewCallDecoder(), nil, alert.Multi{up, down}, []follow.Chain{
+		{ChainID: big.NewInt(3), Backend: e.Backend, Contracts: []common.Address{e.TimelockAddress}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := w.Poll(ctx); n != 2 || !errors.Is(err, errDown) {
+		t.Fatalf("Poll = %d, %v; want 2 notifications and the failing sink's error", n, err)
+	}
+	if n, err := w.Poll(ctx); n != 0 || err != nil {
+		t.Fatalf("Poll again = %d, %v", n, err)
+	}
+	if len(up.ids) != 2 {
+		t.Fatalf("notified %v, want both operations once", up.ids)
+	}
+}
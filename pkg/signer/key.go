@@ -0,0 +1,50 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// KeySigner signs with an in-memory private key. It is meant for tests and
+// local tooling only; production signers should use a keystore or a remote
+// signer.
+type KeySigner struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+var _ Signer = (*KeySigner)(nil)
+
+// NewKeySigner returns a Signer backed by key.
+func NewKeySigner(key *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{key: key, addr: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// NewKeySignerFromHex returns a Signer backed by a hex encoded private key.
+func NewKeySignerFromHex(hexKey string) (*KeySigner, error) {
+	key, err := crypto.HexToECDSA(hexKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySigner(key), nil
+}
+
+func (s *KeySigner) Address() common.Address {
+	return s.addr
+}
+
+func (s *KeySigner) SignHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	return signWithKey(s.key, hash)
+}
+
+func signWithKey(key *ecdsa.PrivateKey, hash common.Hash) ([]byte, error) {
+	sig, err := crypto.Sign(EthSignedMessageHash(hash).Bytes(), key)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
@@ -0,0 +1,44 @@
+package signer
+
+import (
+	"context"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// KeystoreSigner signs with a key loaded from a go-ethereum encrypted
+// keystore (V3) file.
+type KeystoreSigner struct {
+	key *keystore.Key
+}
+
+var _ Signer = (*KeystoreSigner)(nil)
+
+// NewKeystoreSigner decrypts the keystore file at path with passphrase.
+func NewKeystoreSigner(path, passphrase string) (*KeystoreSigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeystoreSignerFromJSON(keyJSON, passphrase)
+}
+
+// NewKeystoreSignerFromJSON decrypts an encrypted keystore JSON blob with
+// passphrase.
+func NewKeystoreSignerFromJSON(keyJSON []byte, passphrase string) (*KeystoreSigner, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &KeystoreSigner{key: key}, nil
+}
+
+func (s *KeystoreSigner) Address() common.Address {
+	return s.key.Address
+}
+
+func (s *KeystoreSigner) SignHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	return signWithKey(s.key.PrivateKey, hash)
+}
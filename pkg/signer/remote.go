@@ -0,0 +1,63 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// textPlainContentType is the clef content type for EIP-191 personal messages.
+const textPlainContentType = "text/plain"
+
+// RemoteSigner signs through an external signer speaking the clef JSON-RPC
+// API (account_signData), e.g. clef itself or a custody service exposing the
+// same method. The private key never leaves the remote signer.
+type RemoteSigner struct {
+	client *rpc.Client
+	addr   common.Address
+}
+
+var _ Signer = (*RemoteSigner)(nil)
+
+// DialRemoteSigner connects to the remote signer at url (http, ws or ipc)
+// which will sign as addr.
+func DialRemoteSigner(ctx context.Context, url string, addr common.Address) (*RemoteSigner, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemoteSigner(client, addr), nil
+}
+
+// NewRemoteSigner returns a Signer that signs as addr over client.
+func NewRemoteSigner(client *rpc.Client, addr common.Address) *RemoteSigner {
+	return &RemoteSigner{client: client, addr: addr}
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.addr
+}
+
+func (s *RemoteSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	var sig hexutil.Bytes
+	err := s.client.CallContext(ctx, &sig, "account_signData",
+		textPlainContentType, common.NewMixedcaseAddress(s.addr), hexutil.Bytes(hash[:]))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	if len(sig) != SignatureLength {
+		return nil, fmt.Errorf("remote signer: invalid signature length %d", len(sig))
+	}
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	return sig, nil
+}
+
+// Close closes the underlying RPC connection.
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
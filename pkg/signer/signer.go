@@ -0,0 +1,112 @@
+// Package signer provides the backends used to sign ManyChainMultiSig roots.
+//
+// ManyChainMultiSig.setRoot recovers signers from the EIP-191 personal message
+// hash of keccak256(abi.encode(root, validUntil)). Every Signer in this package
+// is handed the unprefixed hash and applies the prefix itself, which is the
+// same contract as eth_sign and hardware wallets.
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+)
+
+// SignatureLength is the length of an encoded [R || S || V] signature.
+const SignatureLength = crypto.SignatureLength
+
+// Signer signs ManyChainMultiSig root hashes on behalf of a single address.
+type Signer interface {
+	// Address returns the address whose signatures this Signer produces.
+	Address() common.Address
+	// SignHash signs the EIP-191 personal message of hash and returns the
+	// signature as [R || S || V] with V in {27, 28}.
+	SignHash(ctx context.Context, hash common.Hash) ([]byte, error)
+}
+
+// RootHash returns keccak256(abi.encode(root, validUntil)), the hash that
+// signers sign for a call to setRoot.
+func RootHash(root common.Hash, validUntil uint32) common.Hash {
+	return crypto.Keccak256Hash(
+		root[:],
+		common.LeftPadBytes(new(big.Int).SetUint64(uint64(validUntil)).Bytes(), 32),
+	)
+}
+
+// EthSignedMessageHash returns the EIP-191 personal message hash of hash,
+// matching ECDSA.toEthSignedMessageHash in OpenZeppelin.
+func EthSignedMessageHash(hash common.Hash) common.Hash {
+	return common.BytesToHash(accounts.TextHash(hash[:]))
+}
+
+// Recover returns the address that produced sig over the EIP-191 personal
+// message of hash.
+func Recover(hash common.Hash, sig []byte) (common.Address, error) {
+	if len(sig) != SignatureLength {
+		return common.Address{}, fmt.Errorf("invalid signature length %d", len(sig))
+	}
+	normalized := make([]byte, SignatureLength)
+	copy(normalized, sig)
+	if normalized[64] >= 27 {
+		normalized[64] -= 27
+	}
+	pub, err := crypto.SigToPub(EthSignedMessageHash(hash).Bytes(), normalized)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// SignRoot signs (root, validUntil) with s, checks that the signature recovers
+// to s.Address() and returns it in the form expected by setRoot.
+func SignRoot(ctx context.Context, s Signer, root common.Hash, validUntil uint32) (gethwrappers.ManyChainMultiSigSignature, error) {
+	hash := RootHash(root, validUntil)
+	sig, err := s.SignHash(ctx, hash)
+	if err != nil {
+		return gethwrappers.ManyChainMultiSigSignature{}, err
+	}
+	recovered, err := Recover(hash, sig)
+	if err != nil {
+		return gethwrappers.ManyChainMultiSigSignature{}, err
+	}
+	if recovered != s.Address() {
+		return gethwrappers.ManyChainMultiSigSignature{}, fmt.Errorf("signature recovers to %s, expected %s", recovered, s.Address())
+	}
+	return ToGethSignature(sig)
+}
+
+// ToGethSignature converts an [R || S || V] signature into the
+// ManyChainMultiSig.Signature struct.
+func ToGethSignature(sig []byte) (gethwrappers.ManyChainMultiSigSignature, error) {
+	if len(sig) != SignatureLength {
+		return gethwrappers.ManyChainMultiSigSignature{}, fmt.Errorf("invalid signature length %d", len(sig))
+	}
+	v := sig[64]
+	if v < 27 {
+		v += 27
+	}
+	if v != 27 && v != 28 {
+		return gethwrappers.ManyChainMultiSigSignature{}, errors.New("invalid signature recovery id")
+	}
+	return gethwrappers.ManyChainMultiSigSignature{
+		V: v,
+		R: common.BytesToHash(sig[:32]),
+		S: common.BytesToHash(sig[32:64]),
+	}, nil
+}
+
+// FromGethSignature converts a ManyChainMultiSig.Signature struct into
+// [R || S || V] form.
+func FromGethSignature(sig gethwrappers.ManyChainMultiSigSignature) []byte {
+	out := make([]byte, 0, SignatureLength)
+	out = append(out, sig.R[:]...)
+	out = append(out, sig.S[:]...)
+	return append(out, sig.V)
+}
@@ -0,0 +1,107 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// clefStandIn implements the subset of the clef API used by RemoteSigner.
+type clefStandIn struct {
+	key *ecdsa.PrivateKey
+}
+
+func (c *clefStandIn) SignData(contentType string, addr common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	if contentType != textPlainContentType {
+		return nil, errors.New("unsupported content type")
+	}
+	if addr.Address() != crypto.PubkeyToAddress(c.key.PublicKey) {
+		return nil, errors.New("unknown account")
+	}
+	return signWithKey(c.key, common.BytesToHash(data))
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func checkSigner(t *testing.T, s Signer, want common.Address) {
+	t.Helper()
+	if s.Address() != want {
+		t.Fatalf("address = %s, want %s", s.Address(), want)
+	}
+	root := common.HexToHash("0x1234")
+	sig, err := SignRoot(context.Background(), s, root, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Recover(RootHash(root, 1000), FromGethSignature(sig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("recovered %s, want %s", got, want)
+	}
+}
+
+func TestKeySigner(t *testing.T) {
+	key := newKey(t)
+	checkSigner(t, NewKeySigner(key), crypto.PubkeyToAddress(key.PublicKey))
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	account, err := keystore.StoreKey(t.TempDir(), "passphrase", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewKeystoreSigner(account.URL.Path, "wrong"); err == nil {
+		t.Fatal("expected wrong passphrase to fail")
+	}
+	s, err := NewKeystoreSigner(account.URL.Path, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSigner(t, s, account.Address)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key := newKey(t)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("account", &clefStandIn{key: key}); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	s, err := DialRemoteSigner(context.Background(), httpServer.URL, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	checkSigner(t, s, addr)
+
+	other, err := DialRemoteSigner(context.Background(), httpServer.URL, common.HexToAddress("0x1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	if _, err := other.SignHash(context.Background(), common.Hash{}); err == nil {
+		t.Fatal("expected unknown account to fail")
+	}
+}
@@ -0,0 +1,102 @@
+// Package timelock works with RBACTimelock operations: batch files, operation
+// ids, roles and the operation history reconstructed from contract logs.
+package timelock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+)
+
+var (
+	// RBACTimelockABI is the parsed ABI of RBACTimelock.
+	RBACTimelockABI = evm.MustParseABI(gethwrappers.RBACTimelockMetaData)
+
+	// operationIDArgs encodes exactly the (calls, predecessor, salt) tuple
+	// hashOperationBatch hashes.
+	operationIDArgs = RBACTimelockABI.Methods["hashOperationBatch"].Inputs
+)
+
+// Call is one call of a batch.
+type Call struct {
+	Target common.Address `json:"target"`
+	Value  *big.Int       `json:"value,omitempty"`
+	Data   hexutil.Bytes  `json:"data"`
+}
+
+// Batch is the input of scheduleBatch and executeBatch. Its JSON encoding is
+// the batch file format of the timelock tool.
+type Batch struct {
+	Calls       []Call      `json:"calls"`
+	Predecessor common.Hash `json:"predecessor"`
+	Salt        common.Hash `json:"salt"`
+}
+
+// LoadBatch reads a batch file.
+func LoadBatch(path string) (*Batch, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b Batch
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	if len(b.Calls) == 0 {
+		return nil, fmt.Errorf("%s: batch has no calls", path)
+	}
+	return &b, nil
+}
+
+// RBACTimelockCalls returns the calls in the form taken by the wrapper.
+func (b *Batch) RBACTimelockCalls() []gethwrappers.RBACTimelockCall {
+	calls := make([]gethwrappers.RBACTimelockCall, len(b.Calls))
+	for i, c := range b.Calls {
+		value := c.Value
+		if value == nil {
+			value = new(big.Int)
+		}
+		calls[i] = gethwrappers.RBACTimelockCall{Target: c.Target, Value: value, Data: c.Data}
+	}
+	return calls
+}
+
+// DecodeScheduleBatch decodes scheduleBatch call data into the batch it
+// schedules and its delay.
+func DecodeScheduleBatch(data []byte) (*Batch, *big.Int, error) {
+	method := RBACTimelockABI.Methods["scheduleBatch"]
+	if len(data) < 4 || !bytes.Equal(data[:4], method.ID) {
+		return nil, nil, errors.New("not a scheduleBatch call")
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding scheduleBatch: %w", err)
+	}
+	calls := *abi.ConvertType(args[0], new([]gethwrappers.RBACTimelockCall)).(*[]gethwrappers.RBACTimelockCall)
+	b := &Batch{Predecessor: args[1].([32]byte), Salt: args[2].([32]byte)}
+	for _, c := range calls {
+		b.Calls = append(b.Calls, Call{Target: c.Target, Value: c.Value, Data: c.Data})
+	}
+	return b, args[3].(*big.Int), nil
+}
+
+// ID returns the operation id, keccak256(abi.encode(calls, predecessor, salt)),
+// as computed by hashOperationBatch.
+func (b *Batch) ID() (common.Hash, error) {
+	encoded, err := operationIDArgs.Pack(b.RBACTimelockCalls(), b.Predecessor, b.Salt)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("encoding batch: %w", err)
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
@@ -0,0 +1,270 @@
+package timelock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+)
+
+// DefaultPollInterval is how often Client.WaitUntilReady checks the chain.
+const DefaultPollInterval = 15 * time.Second
+
+// ErrEmptyBatch is returned by Schedule and Execute for a batch without
+// calls. The timelock accepts it but emits no event to confirm it by.
+var ErrEmptyBatch = errors.New("batch has no calls")
+
+// ReceiptBackend is what a Client needs besides the contract: receipts and
+// the latest block time.
+type ReceiptBackend interface {
+	bind.DeployBackend
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// ClientBackend is what NewClient needs from a node.
+type ClientBackend interface {
+	bind.ContractBackend
+	ReceiptBackend
+}
+
+// Client drives operations through their lifecycle on one RBACTimelock:
+// schedule, wait until ready, execute or cancel. Transactions are sent with
+// the TransactOpts the client was created with; their Context is replaced by
+// the one passed to each method.
+type Client struct {
+	Address common.Address
+	// CallProxy, if set, is used to send executeBatch instead of the
+	// timelock itself. It must hold the EXECUTOR_ROLE.
+	CallProxy gethwrappers.CallProxyTransactorInterface
+	// PollInterval is how often WaitUntilReady checks the chain. Defaults to
+	// DefaultPollInterval.
+	PollInterval time.Duration
+
+	contract gethwrappers.RBACTimelockInterface
+	backend  ReceiptBackend
+	opts     *bind.TransactOpts
+}
+
+// NewClient returns a client for the RBACTimelock at address.
+func NewClient(address common.Address, backend ClientBackend, opts *bind.TransactOpts) (*Client, error) {
+	contract, err := gethwrappers.NewRBACTimelock(address, backend)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientFromContract(address, contract, backend, opts), nil
+}
+
+// NewClientFromContract returns a client using contract, which may be a fake.
+func NewClientFromContract(address common.Address, contract gethwrappers.RBACTimelockInterface, backend ReceiptBackend, opts *bind.TransactOpts) *Client {
+	return &Client{Address: address, contract: contract, backend: backend, opts: opts}
+}
+
+// Schedule schedules b with the given delay, or the minimum delay if delay is
+// nil, and returns the scheduled operation.
+func (c *Client) Schedule(ctx context.Context, b *Batch, delay *big.Int) (*Operation, error) {
+	if len(b.Calls) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	if delay == nil {
+		var err error
+		if delay, err = c.contract.GetMinDelay(&bind.CallOpts{Context: ctx}); err != nil {
+			return nil, fmt.Errorf("getMinDelay: %w", err)
+		}
+	}
+	tx, err := c.contract.ScheduleBatch(c.transactOpts(ctx), b.RBACTimelockCalls(), b.Predecessor, b.Salt, delay)
+	if err != nil {
+		return nil, fmt.Errorf("scheduleBatch: %w", err)
+	}
+	receipt, err := c.wait(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("scheduleBatch: %w", err)
+	}
+	ops, err := c.ParseScheduled(receipt)
+	if err != nil {
+		return nil, err
+	}
+	if len(ops) != 1 {
+		return nil, fmt.Errorf("scheduleBatch transaction %s scheduled %d operations", receipt.TxHash, len(ops))
+	}
+	op := ops[0]
+	op.Status, op.ReadyAt, err = c.Status(ctx, op.ID)
+	return op, err
+}
+
+// Status returns the status and getTimestamp value of operation id as of the
+// latest block. Cancelled operations are reported as StatusUnset.
+func (c *Client) Status(ctx context.Context, id common.Hash) (Status, *big.Int, error) {
+	head, err := c.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	timestamp, err := c.contract.GetTimestamp(&bind.CallOpts{Context: ctx, BlockNumber: head.Number}, id)
+	if err != nil {
+		return "", nil, fmt.Errorf("getTimestamp: %w", err)
+	}
+	return StatusAt(timestamp, head.Time), timestamp, nil
+}
+
+// WaitUntilReady blocks until operation id is ready. It fails if the
+// operation is not pending, e.g. because it was cancelled while waiting.
+func (c *Client) WaitUntilReady(ctx context.Context, id common.Hash) error {
+	interval := c.PollInterval
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	for {
+		status, _, err := c.Status(ctx, id)
+		if err != nil {
+			return err
+		}
+		switch status {
+		case StatusReady:
+			return nil
+		case StatusPending:
+		default:
+			return fmt.Errorf("operation %s is %s", id, status)
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Execute executes b, through CallProxy if set, and returns the executed
+// operation.
+func (c *Client) Execute(ctx context.Context, b *Batch) (*Operation, error) {
+	if len(b.Calls) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	var tx *types.Transaction
+	var err error
+	if c.CallProxy != nil {
+		var data []byte
+		if data, err = RBACTimelockABI.Pack("executeBatch", b.RBACTimelockCalls(), b.Predecessor, b.Salt); err != nil {
+			return nil, err
+		}
+		tx, err = c.CallProxy.Fallback(c.transactOpts(ctx), data)
+	} else {
+		tx, err = c.contract.ExecuteBatch(c.transactOpts(ctx), b.RBACTimelockCalls(), b.Predecessor, b.Salt)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("executeBatch: %w", err)
+	}
+	receipt, err := c.wait(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("executeBatch: %w", err)
+	}
+	ops, err := c.ParseExecuted(receipt)
+	if err != nil {
+		return nil, err
+	}
+	if len(ops) != 1 {
+		return nil, fmt.Errorf("executeBatch transaction %s executed %d operations", receipt.TxHash, len(ops))
+	}
+	op := ops[0]
+	op.Predecessor, op.Salt = b.Predecessor, b.Salt
+	return op, nil
+}
+
+// Cancel cancels operation id.
+func (c *Client) Cancel(ctx context.Context, id common.Hash) error {
+	tx, err := c.contract.Cancel(c.transactOpts(ctx), id)
+	if err != nil {
+		return fmt.Errorf("cancel: %w", err)
+	}
+	if _, err := c.wait(ctx, tx); err != nil {
+		return fmt.Errorf("cancel: %w", err)
+	}
+	return nil
+}
+
+// ParseScheduled reconstructs the operations scheduled in a transaction from
+// the CallScheduled events of the timelock in its receipt. ReadyAt and
+// Status are left unset.
+func (c *Client) ParseScheduled(receipt *types.Receipt) ([]*Operation, error) {
+	var ops []*Operation
+	byID := make(map[common.Hash]*Operation)
+	for _, l := range c.logs(receipt, "CallScheduled") {
+		ev, err := c.contract.ParseCallScheduled(*l)
+		if err != nil {
+			return nil, err
+		}
+		op, ok := byID[ev.Id]
+		if !ok {
+			op = &Operation{
+				ID:          ev.Id,
+				Batch:       Batch{Predecessor: ev.Predecessor, Salt: ev.Salt},
+				Delay:       ev.Delay,
+				BlockNumber: l.BlockNumber,
+				TxHash:      l.TxHash,
+			}
+			byID[ev.Id] = op
+			ops = append(ops, op)
+		}
+		op.Calls = append(op.Calls, Call{Target: ev.Target, Value: ev.Value, Data: ev.Data})
+	}
+	return ops, nil
+}
+
+// ParseExecuted reconstructs the operations executed in a transaction from
+// the CallExecuted events of the timelock in its receipt. CallExecuted does
+// not carry the predecessor and salt, so those are left zero.
+func (c *Client) ParseExecuted(receipt *types.Receipt) ([]*Operation, error) {
+	var ops []*Operation
+	byID := make(map[common.Hash]*Operation)
+	for _, l := range c.logs(receipt, "CallExecuted") {
+		ev, err := c.contract.ParseCallExecuted(*l)
+		if err != nil {
+			return nil, err
+		}
+		op, ok := byID[ev.Id]
+		if !ok {
+			op = &Operation{
+				ID:          ev.Id,
+				BlockNumber: l.BlockNumber,
+				TxHash:      l.TxHash,
+				ReadyAt:     new(big.Int).Set(doneTimestamp),
+				Status:      StatusDone,
+			}
+			byID[ev.Id] = op
+			ops = append(ops, op)
+		}
+		op.Calls = append(op.Calls, Call{Target: ev.Target, Value: ev.Value, Data: ev.Data})
+	}
+	return ops, nil
+}
+
+// logs returns the logs of the named timelock event in receipt.
+func (c *Client) logs(receipt *types.Receipt, event string) []*types.Log {
+	id := RBACTimelockABI.Events[event].ID
+	var logs []*types.Log
+	for _, l := range receipt.Logs {
+		if l.Address == c.Address && len(l.Topics) > 0 && l.Topics[0] == id {
+			logs = append(logs, l)
+		}
+	}
+	return logs
+}
+
+func (c *Client) transactOpts(ctx context.Context) *bind.TransactOpts {
+	opts := *c.opts
+	opts.Context = ctx
+	return &opts
+}
+
+// wait waits for tx to be mined and fails if it reverted.
+func (c *Client) wait(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	return evm.WaitSuccess(ctx, c.backend, tx)
+}
@@ -0,0 +1,131 @@
+package timelock_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/internal/harness"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/fake"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/timelock"
+)
+
+const minDelay = time.Hour
+
+func TestClient(t *testing.T) {
+	t.Run("simulated", func(t *testing.T) {
+		e := harness.NewBare(t)
+		d := e.Deployer
+		address, _, contract, err := gethwrappers.DeployRBACTimelock(d.Opts, e.Backend, big.NewInt(int64(minDelay/time.Second)),
+			d.Address, []common.Address{d.Address}, nil, []common.Address{d.Address}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e.Commit()
+		proxyAddress, _, _, err := gethwrappers.DeployCallProxy(d.Opts, e.Backend, address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e.Commit()
+		e.Send(contract.GrantRole(d.Opts, timelock.ExecutorRole.ID, proxyAddress))
+		client, err := timelock.NewClient(address, e.AutoMining(), d.Opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Bound to the auto-mining backend so that Execute's receipt arrives.
+		proxy, err := gethwrappers.NewCallProxy(proxyAddress, e.AutoMining())
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.CallProxy = proxy
+		testClient(t, client, e.AdvanceTime)
+	})
+	t.Run("fake", func(t *testing.T) {
+		d := harness.GenerateSigners(t, 1)[0]
+		chain := fake.NewChain(harness.ChainID, time.Unix(1_700_000_000, 0))
+		address, _, contract, err := fake.DeployRBACTimelock(d.Opts, chain, big.NewInt(int64(minDelay/time.Second)),
+			d.Address, []common.Address{d.Address}, []common.Address{d.Address}, []common.Address{d.Address}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testClient(t, timelock.NewClientFromContract(address, contract, chain, d.Opts), chain.AdvanceTime)
+	})
+}
+
+// testClient schedules, cancels and executes operations through client,
+// whose sender must be proposer, canceller and, directly or through the
+// client's CallProxy, executor. advance moves the chain's clock.
+func testClient(t *testing.T, client *timelock.Client, advance func(time.Duration)) {
+	ctx := context.Background()
+	client.PollInterval = time.Millisecond
+
+	// The timelock is its own target: a call to a function that only
+	// returns, onERC721Received, keeps the test independent of roles.
+	data, err := timelock.RBACTimelockABI.Pack("onERC721Received", common.Address{}, common.Address{}, new(big.Int), []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := &timelock.Batch{Calls: []timelock.Call{{Target: client.Address, Data: data}, {Target: client.Address, Value: new(big.Int), Data: data}}}
+	second := &timelock.Batch{Calls: first.Calls, Salt: common.Hash{1}}
+
+	op, err := client.Schedule(ctx, first, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := first.ID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.ID != id || len(op.Calls) != 2 || op.Status != timelock.StatusPending || op.Delay.Int64() != int64(minDelay/time.Second) {
+		t.Fatalf("scheduled operation = %+v", op)
+	}
+	if _, err := client.Schedule(ctx, second, big.NewInt(1)); err == nil {
+		t.Fatal("scheduling below the minimum delay succeeded")
+	}
+	if _, err := client.Schedule(ctx, &timelock.Batch{}, nil); !errors.Is(err, timelock.ErrEmptyBatch) {
+		t.Fatalf("Schedule of an empty batch: got %v", err)
+	}
+	if _, err := client.Execute(ctx, &timelock.Batch{}); !errors.Is(err, timelock.ErrEmptyBatch) {
+		t.Fatalf("Execute of an empty batch: got %v", err)
+	}
+	cancelled, err := client.Schedule(ctx, second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := client.WaitUntilReady(short, id); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitUntilReady before the delay passed: got %v", err)
+	}
+	if _, err := client.Execute(ctx, first); err == nil {
+		t.Fatal("executing a pending operation succeeded")
+	}
+
+	if err := client.Cancel(ctx, cancelled.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.WaitUntilReady(ctx, cancelled.ID); err == nil {
+		t.Fatal("WaitUntilReady of a cancelled operation succeeded")
+	}
+
+	advance(minDelay)
+	if err := client.WaitUntilReady(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	executed, err := client.Execute(ctx, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if executed.ID != id || len(executed.Calls) != 2 || executed.Status != timelock.StatusDone {
+		t.Fatalf("executed operation = %+v", executed)
+	}
+	if status, _, err := client.Status(ctx, id); err != nil || status != timelock.StatusDone {
+		t.Fatalf("status = %s, %v", status, err)
+	}
+}
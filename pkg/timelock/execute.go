@@ -0,0 +1,51 @@
+package timelock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+)
+
+// CheckExecutable returns the id of b and fails unless executeBatch would
+// pass its readiness checks: the operation must be ready and its predecessor,
+// if any, done.
+func CheckExecutable(ctx context.Context, backend Backend, timelock common.Address, b *Batch) (common.Hash, error) {
+	id, err := b.ID()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	status, _, err := GetStatus(ctx, backend, timelock, id)
+	if err != nil {
+		return id, err
+	}
+	if status != StatusReady {
+		return id, fmt.Errorf("operation %s is %s, not ready", id, status)
+	}
+	if b.Predecessor != (common.Hash{}) {
+		status, _, err := GetStatus(ctx, backend, timelock, b.Predecessor)
+		if err != nil {
+			return id, err
+		}
+		if status != StatusDone {
+			return id, fmt.Errorf("predecessor %s is %s, not done", b.Predecessor, status)
+		}
+	}
+	return id, nil
+}
+
+// ExecuteBatch sends executeBatch for b to via, which is either the
+// RBACTimelock itself or a CallProxy in front of it. A CallProxy forwards the
+// call data unchanged, so the RBACTimelock ABI is used for both; when going
+// through a CallProxy, the proxy must hold the EXECUTOR_ROLE.
+func ExecuteBatch(opts *bind.TransactOpts, backend bind.ContractTransactor, via common.Address, b *Batch) (*types.Transaction, error) {
+	transactor, err := gethwrappers.NewRBACTimelockTransactor(via, backend)
+	if err != nil {
+		return nil, err
+	}
+	return transactor.ExecuteBatch(opts, b.RBACTimelockCalls(), b.Predecessor, b.Salt)
+}
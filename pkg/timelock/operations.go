@@ -0,0 +1,179 @@
+package timelock
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+)
+
+// doneTimestamp is the timestamp RBACTimelock records for executed operations.
+var doneTimestamp = big.NewInt(1)
+
+// Backend is the subset of a chain client needed to read operations.
+type Backend interface {
+	bind.ContractCaller
+	bind.ContractFilterer
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Status is the state of an operation.
+type Status string
+
+const (
+	// StatusUnset means the operation is not scheduled.
+	StatusUnset Status = "unset"
+	// StatusPending means the operation is scheduled and its delay has not
+	// yet passed.
+	StatusPending Status = "pending"
+	// StatusReady means the operation can be executed.
+	StatusReady Status = "ready"
+	// StatusDone means the operation was executed.
+	StatusDone Status = "done"
+	// StatusCancelled means the operation was scheduled, then cancelled.
+	StatusCancelled Status = "cancelled"
+)
+
+// StatusAt returns the status of an operation with the given getTimestamp
+// value at block time now. It mirrors isOperationPending, isOperationReady
+// and isOperationDone, except that pending operations whose delay has passed
+// are reported as ready only.
+func StatusAt(timestamp *big.Int, now uint64) Status {
+	switch {
+	case timestamp.Sign() == 0:
+		return StatusUnset
+	case timestamp.Cmp(doneTimestamp) == 0:
+		return StatusDone
+	case timestamp.Cmp(new(big.Int).SetUint64(now)) <= 0:
+		return StatusReady
+	default:
+		return StatusPending
+	}
+}
+
+// GetStatus returns the status of operation id as of the latest block.
+func GetStatus(ctx context.Context, backend Backend, timelock common.Address, id common.Hash) (Status, *big.Int, error) {
+	caller, err := gethwrappers.NewRBACTimelockCaller(timelock, backend)
+	if err != nil {
+		return "", nil, err
+	}
+	head, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	timestamp, err := caller.GetTimestamp(&bind.CallOpts{Context: ctx, BlockNumber: head.Number}, id)
+	if err != nil {
+		return "", nil, err
+	}
+	return StatusAt(timestamp, head.Time), timestamp, nil
+}
+
+// Operation is a scheduled operation reconstructed from its CallScheduled
+// events.
+type Operation struct {
+	ID common.Hash `json:"id"`
+	Batch
+	Delay       *big.Int    `json:"delay"`
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      common.Hash `json:"txHash"`
+	// ReadyAt is the getTimestamp value: the time from which the operation
+	// can be executed, 1 once done and 0 if cancelled.
+	ReadyAt *big.Int `json:"readyAt"`
+	Status  Status   `json:"status"`
+}
+
+// FetchOperations reconstructs the operations scheduled on the RBACTimelock
+// at timelock between fromBlock and the latest block, querying batchSize
+// blocks at a time, and reports their current status. Operations are
+// returned in scheduling order.
+func FetchOperations(ctx context.Context, backend Backend, timelock common.Address, fromBlock, batchSize uint64) ([]*Operation, error) {
+	if batchSize == 0 {
+		batchSize = evm.DefaultLogBatchSize
+	}
+	filterer, err := gethwrappers.NewRBACTimelockFilterer(timelock, backend)
+	if err != nil {
+		return nil, err
+	}
+	caller, err := gethwrappers.NewRBACTimelockCaller(timelock, backend)
+	if err != nil {
+		return nil, err
+	}
+	head, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	latest := head.Number.Uint64()
+
+	byID := make(map[common.Hash]*Operation)
+	var ops []*Operation
+	cancelled := make(map[common.Hash]bool)
+	for start := fromBlock; start <= latest; start += batchSize {
+		end := start + batchSize - 1
+		if end > latest {
+			end = latest
+		}
+		filterOpts := &bind.FilterOpts{Start: start, End: &end, Context: ctx}
+		scheduled, err := filterer.FilterCallScheduled(filterOpts, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("fetching CallScheduled logs in blocks [%d, %d]: %w", start, end, err)
+		}
+		for scheduled.Next() {
+			ev := scheduled.Event
+			op, ok := byID[ev.Id]
+			if !ok || ev.Index.Sign() == 0 {
+				// A cancelled operation can be scheduled again; the
+				// latest scheduling wins.
+				if ok {
+					delete(cancelled, ev.Id)
+				} else {
+					op = &Operation{ID: ev.Id}
+					byID[ev.Id] = op
+					ops = append(ops, op)
+				}
+				op.Batch = Batch{Predecessor: ev.Predecessor, Salt: ev.Salt}
+				op.Delay = ev.Delay
+				op.BlockNumber = ev.Raw.BlockNumber
+				op.TxHash = ev.Raw.TxHash
+			}
+			op.Calls = append(op.Calls, Call{Target: ev.Target, Value: ev.Value, Data: ev.Data})
+		}
+		err = scheduled.Error()
+		scheduled.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		cancels, err := filterer.FilterCancelled(filterOpts, nil)
+		if err != nil {
+			return nil, fmt.Errorf("fetching Cancelled logs in blocks [%d, %d]: %w", start, end, err)
+		}
+		for cancels.Next() {
+			cancelled[cancels.Event.Id] = true
+		}
+		err = cancels.Error()
+		cancels.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx, BlockNumber: head.Number}
+	for _, op := range ops {
+		if op.ReadyAt, err = caller.GetTimestamp(callOpts, op.ID); err != nil {
+			return nil, err
+		}
+		op.Status = StatusAt(op.ReadyAt, head.Time)
+		if op.Status == StatusUnset && cancelled[op.ID] {
+			op.Status = StatusCancelled
+		}
+	}
+	sort.SliceStable(ops, func(i, j int) bool { return ops[i].BlockNumber < ops[j].BlockNumber })
+	return ops, nil
+}
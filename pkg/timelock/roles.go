@@ -0,0 +1,62 @@
+package timelock
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+)
+
+// Role is an RBACTimelock role.
+type Role struct {
+	Name string
+	ID   common.Hash
+}
+
+func newRole(name string) Role {
+	return Role{Name: name, ID: crypto.Keccak256Hash([]byte(name))}
+}
+
+// The roles of RBACTimelock. All of them are administered by AdminRole.
+var (
+	AdminRole     = newRole("ADMIN_ROLE")
+	ProposerRole  = newRole("PROPOSER_ROLE")
+	ExecutorRole  = newRole("EXECUTOR_ROLE")
+	CancellerRole = newRole("CANCELLER_ROLE")
+	BypasserRole  = newRole("BYPASSER_ROLE")
+
+	Roles = []Role{AdminRole, ProposerRole, ExecutorRole, CancellerRole, BypasserRole}
+)
+
+// RoleMembers lists the members of role.
+func RoleMembers(opts *bind.CallOpts, timelock gethwrappers.RBACTimelockCallerInterface, role Role) ([]common.Address, error) {
+	count, err := timelock.GetRoleMemberCount(opts, role.ID)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]common.Address, count.Uint64())
+	for i := range members {
+		if members[i], err = timelock.GetRoleMember(opts, role.ID, big.NewInt(int64(i))); err != nil {
+			return nil, err
+		}
+	}
+	return members, nil
+}
+
+// BlockedSelectors lists the function selectors that may not be scheduled.
+func BlockedSelectors(opts *bind.CallOpts, timelock gethwrappers.RBACTimelockCallerInterface) ([][4]byte, error) {
+	count, err := timelock.GetBlockedFunctionSelectorCount(opts)
+	if err != nil {
+		return nil, err
+	}
+	selectors := make([][4]byte, count.Uint64())
+	for i := range selectors {
+		if selectors[i], err = timelock.GetBlockedFunctionSelectorAt(opts, big.NewInt(int64(i))); err != nil {
+			return nil, err
+		}
+	}
+	return selectors, nil
+}
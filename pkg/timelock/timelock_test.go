@@ -0,0 +1,120 @@
+package timelock
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/evm"
+)
+
+func TestOperations(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{owner: {Balance: big.NewInt(1e18)}}, 30_000_000)
+	defer sim.Close()
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	if err != nil {
+		t.Fatal(err)
+	}
+	minDelay := big.NewInt(3600)
+	owners := []common.Address{owner}
+	timelockAddr, _, timelock, err := gethwrappers.DeployRBACTimelock(opts, sim, minDelay, owner, owners, nil, owners, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	proxy, _, _, err := gethwrappers.DeployCallProxy(opts, sim, timelockAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := timelock.GrantRole(opts, ExecutorRole.ID, proxy); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	members, err := RoleMembers(&bind.CallOpts{}, &timelock.RBACTimelockCaller, ExecutorRole)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0] != proxy {
+		t.Fatalf("executors = %v, want [%s]", members, proxy)
+	}
+
+	batch := &Batch{
+		Calls: []Call{
+			{Target: common.HexToAddress("0x1111"), Data: []byte{}},
+			{Target: common.HexToAddress("0x2222"), Value: big.NewInt(0), Data: []byte{0xab}},
+		},
+		Salt: common.HexToHash("0x01"),
+	}
+	id, err := batch.ID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := timelock.HashOperationBatch(&bind.CallOpts{}, batch.RBACTimelockCalls(), batch.Predecessor, batch.Salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != want {
+		t.Fatalf("ID = %s, hashOperationBatch = %x", id, want)
+	}
+
+	if _, err := timelock.ScheduleBatch(opts, batch.RBACTimelockCalls(), batch.Predecessor, batch.Salt, minDelay); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	ops, err := FetchOperations(ctx, sim, timelockAddr, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 1 || ops[0].ID != id || len(ops[0].Calls) != 2 || ops[0].Status != StatusPending {
+		t.Fatalf("unexpected operations %+v", ops)
+	}
+	if _, err := CheckExecutable(ctx, sim, timelockAddr, batch); err == nil {
+		t.Fatal("expected pending operation to be rejected")
+	}
+
+	if err := sim.AdjustTime(2 * time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	if _, err := CheckExecutable(ctx, sim, timelockAddr, batch); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ExecuteBatch(opts, sim, proxy, batch); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	cancelled := &Batch{Calls: batch.Calls, Predecessor: id}
+	cancelledID, _ := cancelled.ID()
+	if _, err := timelock.ScheduleBatch(opts, cancelled.RBACTimelockCalls(), cancelled.Predecessor, cancelled.Salt, minDelay); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	if _, err := timelock.Cancel(opts, cancelledID); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	ops, err = FetchOperations(ctx, sim, timelockAddr, 0, evm.DefaultLogBatchSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 2 || ops[0].Status != StatusDone || ops[1].ID != cancelledID || ops[1].Status != StatusCancelled {
+		t.Fatalf("unexpected operations %+v", ops)
+	}
+}
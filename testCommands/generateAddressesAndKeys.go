@@ -3,21 +3,45 @@ package main
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"math/big"
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/hkdf"
 )
 
 // The method in this file is used in the foundry tests for computing the test
 // addresses and their corresponding private keys. The addresses are sorted in
 // ascending order as required in ManyChainMultiSig.sol.
+//
+// Usage:
+//
+//	go run ./testCommands/generateAddressesAndKeys.go [-seed S] [-layout L] [-json] [numSigners]
+//
+// With -seed, keys are derived deterministically from S so that failing runs
+// can be reproduced. $GENERATE_KEYS_SEED is used when -seed is not given,
+// which lets a Foundry run be reproduced without changing the tests. With
+// -layout, signers are assigned to groups and the output also carries the
+// remaining setConfig arguments. numSigners may be omitted when -layout is
+// given.
+
+// seedEnv is read when -seed is not given.
+const seedEnv = "GENERATE_KEYS_SEED"
+
+// numGroups is ManyChainMultiSig.NUM_GROUPS.
+const numGroups = 32
 
 var (
 	uint256Array, _ = abi.NewType("uint256[]", "[]uint256",
@@ -30,10 +54,20 @@ var (
 			{Name: "val", Type: "address[]"},
 		},
 	)
+	uint8Array, _   = abi.NewType("uint8[]", "", nil)
+	uint8Array32, _ = abi.NewType("uint8[32]", "", nil)
+
 	encodingSigners = abi.Arguments{
 		{Type: uint256Array, Name: "privateKeys"},
 		{Type: addressesArray, Name: "signers"},
 	}
+	// encodingSignersWithConfig extends encodingSigners with the remaining
+	// setConfig arguments.
+	encodingSignersWithConfig = append(encodingSigners[:len(encodingSigners):len(encodingSigners)],
+		abi.Argument{Type: uint8Array, Name: "signerGroups"},
+		abi.Argument{Type: uint8Array32, Name: "groupQuorums"},
+		abi.Argument{Type: uint8Array32, Name: "groupParents"},
+	)
 )
 
 type bytesArrayForSort [][]byte
@@ -58,37 +92,141 @@ func (a bytesArrayForSort) Swap(i, j int) {
 	a[j], a[i] = a[i], a[j]
 }
 
+// group is one group of a layout.
+type group struct {
+	quorum  uint8
+	parent  uint8
+	signers int
+}
+
+// parseLayout parses a group layout of the form "QUORUM:PARENT:SIGNERS,...",
+// where the n-th entry describes group n and SIGNERS is the number of signers
+// directly in that group. For example "2:0:0,2:0:3,1:0:2" is a 2-of-2 root
+// group over a 2-of-3 and a 1-of-2 group.
+func parseLayout(s string) ([]group, error) {
+	entries := strings.Split(s, ",")
+	if len(entries) > numGroups {
+		return nil, fmt.Errorf("layout has %d groups, at most %d are supported", len(entries), numGroups)
+	}
+	groups := make([]group, len(entries))
+	for i, entry := range entries {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("group %d: %q is not QUORUM:PARENT:SIGNERS", i, entry)
+		}
+		var values [3]int
+		for j, part := range parts {
+			v, err := strconv.Atoi(part)
+			if err != nil || v < 0 {
+				return nil, fmt.Errorf("group %d: invalid number %q", i, part)
+			}
+			values[j] = v
+		}
+		if values[0] == 0 || values[0] > 255 {
+			return nil, fmt.Errorf("group %d: quorum must be between 1 and 255", i)
+		}
+		if (i == 0 && values[1] != 0) || (i > 0 && values[1] >= i) {
+			return nil, fmt.Errorf("group %d: parent must be a lower group (0 for the root group)", i)
+		}
+		groups[i] = group{quorum: uint8(values[0]), parent: uint8(values[1]), signers: values[2]}
+	}
+	return groups, nil
+}
+
+// keySource returns private keys, either random ones or ones derived from
+// seed with HKDF-SHA256.
+func keySource(seed string) func() *ecdsa.PrivateKey {
+	if seed == "" {
+		return func() *ecdsa.PrivateKey {
+			privateKey, err := crypto.GenerateKey()
+			if err != nil {
+				panic(err)
+			}
+			return privateKey
+		}
+	}
+	reader := hkdf.New(sha256.New, []byte(seed), nil, []byte("ccip-owner-contracts/generateAddressesAndKeys"))
+	return func() *ecdsa.PrivateKey {
+		// Candidates that are not valid secp256k1 scalars are skipped, which
+		// keeps the derivation deterministic.
+		candidate := make([]byte, 32)
+		for {
+			if _, err := io.ReadFull(reader, candidate); err != nil {
+				panic(err)
+			}
+			if privateKey, err := crypto.ToECDSA(candidate); err == nil {
+				return privateKey
+			}
+		}
+	}
+}
+
+type jsonSigner struct {
+	Address    common.Address `json:"address"`
+	PrivateKey hexutil.Bytes  `json:"privateKey"`
+	Group      *uint8         `json:"group,omitempty"`
+}
+
+type jsonSetConfig struct {
+	SignerAddresses []common.Address `json:"signerAddresses"`
+	SignerGroups    []uint8          `json:"signerGroups"`
+	GroupQuorums    [numGroups]uint8 `json:"groupQuorums"`
+	GroupParents    [numGroups]uint8 `json:"groupParents"`
+	ClearRoot       bool             `json:"clearRoot"`
+}
+
+type jsonOutput struct {
+	Signers   []jsonSigner   `json:"signers"`
+	SetConfig *jsonSetConfig `json:"setConfig,omitempty"`
+}
+
 // main receives the required number of signers' addresses and prints the encoded
 // (in HEX) list of private keys and (sorted) list of addresses. The encoding according to
-// the supported format in Solidity.
+// the supported format in Solidity. With -layout, the signer groups, group quorums and
+// group parents follow, i.e. (uint256[], address[], uint8[], uint8[32], uint8[32]).
 func main() {
-	if len(os.Args) < 2 {
-		panic("should pass the required number of addresses")
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	seed := fs.String("seed", os.Getenv(seedEnv), "derive the keys deterministically from this seed (default $"+seedEnv+")")
+	layoutFlag := fs.String("layout", "", "group layout QUORUM:PARENT:SIGNERS,... (group n is the n-th entry)")
+	jsonOut := fs.Bool("json", false, "print JSON instead of ABI encoded hex")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		panic(err)
 	}
-	var addresses [][]byte
-	addressesToPrivateKeys := make(map[common.Address]*big.Int)
 
-	numKeys, err := strconv.Atoi(os.Args[1])
-	if err != nil {
-		panic(err)
+	var layout []group
+	numKeys := -1
+	if *layoutFlag != "" {
+		var err error
+		if layout, err = parseLayout(*layoutFlag); err != nil {
+			panic(err)
+		}
+		numKeys = 0
+		for _, g := range layout {
+			numKeys += g.signers
+		}
 	}
-	for i := 0; i < numKeys; i++ {
-		privateKey, err := crypto.GenerateKey()
+	switch {
+	case fs.NArg() > 0:
+		n, err := strconv.Atoi(fs.Arg(0))
 		if err != nil {
 			panic(err)
 		}
-		publicKey := privateKey.Public()
-		publicKey, ok := publicKey.(*ecdsa.PublicKey)
-		if !ok {
-			panic("publicKey is not of type *ecdsa.PublicKey")
-		}
-		publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-		if !ok {
-			panic("publicKey is not of type *ecdsa.PublicKey")
+		if numKeys >= 0 && n != numKeys {
+			panic(fmt.Sprintf("layout has %d signers, not %d", numKeys, n))
 		}
-		address := crypto.PubkeyToAddress(*publicKeyECDSA)
+		numKeys = n
+	case numKeys < 0:
+		panic("should pass the required number of addresses")
+	}
+
+	var addresses [][]byte
+	addressesToPrivateKeys := make(map[common.Address]*ecdsa.PrivateKey)
+	nextKey := keySource(*seed)
+	for i := 0; i < numKeys; i++ {
+		privateKey := nextKey()
+		address := crypto.PubkeyToAddress(privateKey.PublicKey)
 		addresses = append(addresses, address[:])
-		addressesToPrivateKeys[address] = privateKey.D
+		addressesToPrivateKeys[address] = privateKey
 	}
 
 	sort.Sort(bytesArrayForSort(addresses))
@@ -97,15 +235,53 @@ func main() {
 	var sortedAddresses []common.Address
 	for _, addrInBytes := range addresses {
 		addr := common.BytesToAddress(addrInBytes)
-		sortedPrivateKeys = append(sortedPrivateKeys, addressesToPrivateKeys[addr])
+		sortedPrivateKeys = append(sortedPrivateKeys, addressesToPrivateKeys[addr].D)
 		sortedAddresses = append(sortedAddresses, addr)
 	}
-	packed, err := encodingSigners.Pack(
-		sortedPrivateKeys, sortedAddresses)
+
+	// Signers are assigned to groups in address order: the first
+	// layout[0].signers go to group 0, the next layout[1].signers to
+	// group 1 and so on.
+	var config *jsonSetConfig
+	if layout != nil {
+		config = &jsonSetConfig{SignerAddresses: sortedAddresses, SignerGroups: []uint8{}}
+		for i, g := range layout {
+			config.GroupQuorums[i] = g.quorum
+			config.GroupParents[i] = g.parent
+			for j := 0; j < g.signers; j++ {
+				config.SignerGroups = append(config.SignerGroups, uint8(i))
+			}
+		}
+	}
+
+	if *jsonOut {
+		out := jsonOutput{Signers: make([]jsonSigner, len(sortedAddresses)), SetConfig: config}
+		for i, addr := range sortedAddresses {
+			out.Signers[i] = jsonSigner{Address: addr, PrivateKey: crypto.FromECDSA(addressesToPrivateKeys[addr])}
+			if config != nil {
+				out.Signers[i].Group = &config.SignerGroups[i]
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	var packed []byte
+	var err error
+	if config != nil {
+		packed, err = encodingSignersWithConfig.Pack(
+			sortedPrivateKeys, sortedAddresses, config.SignerGroups, config.GroupQuorums, config.GroupParents)
+	} else {
+		packed, err = encodingSigners.Pack(
+			sortedPrivateKeys, sortedAddresses)
+	}
 	if err != nil {
 		panic(err)
 	}
 	// Must NOT print a new line
 	fmt.Print(common.Bytes2Hex(packed))
-
 }